@@ -0,0 +1,150 @@
+// Live updates of the dashboard pages using server-sent events
+(function () {
+    'use strict';
+
+    const eventsLink = document.body.dataset.events;
+    if (!eventsLink || !window.EventSource) {
+        return;
+    }
+
+    function plural(count) {
+        return count === 1 ? '' : 's';
+    }
+
+    function updateIndexCount(data) {
+        const el = document.getElementById(data.protocol + '-count');
+        if (!el) {
+            return;
+        }
+        el.textContent = '';
+        const text = data.count + ' active session' + plural(data.count);
+        if (data.count > 0) {
+            const a = document.createElement('a');
+            a.href = el.dataset.link;
+            a.textContent = text;
+            el.appendChild(a);
+        } else {
+            el.textContent = 'no active sessions';
+        }
+    }
+
+    function sessionsTable(protocol) {
+        const table = document.getElementById('sessions');
+        if (!table || table.dataset.protocol !== protocol) {
+            return null;
+        }
+        return table;
+    }
+
+    function updateFooter(table, count) {
+        const footer = document.getElementById('sessions-footer');
+        if (!footer) {
+            return;
+        }
+        const proto = table.dataset.title;
+        footer.textContent = count > 0 ?
+            'Showing ' + count + ' active ' + proto + ' session' + plural(count) :
+            'No active ' + proto + ' sessions';
+    }
+
+    function cell(text) {
+        const td = document.createElement('td');
+        td.textContent = text;
+        return td;
+    }
+
+    function button(href, text, cls, newTab) {
+        const a = document.createElement('a');
+        a.href = href;
+        a.role = 'button';
+        a.textContent = text;
+        if (cls) {
+            a.className = cls;
+        }
+        if (newTab) {
+            a.target = '_blank';
+        }
+        return a;
+    }
+
+    function addSessionRow(table, s) {
+        if (document.getElementById('session-' + s.id)) {
+            return;
+        }
+        const tr = document.createElement('tr');
+        tr.id = 'session-' + s.id;
+        const idCell = document.createElement('td');
+        const link = document.createElement('a');
+        link.href = s.detailsLink;
+        link.textContent = s.id;
+        idCell.appendChild(link);
+        tr.appendChild(idCell);
+        tr.appendChild(cell(s.createdAt));
+        tr.appendChild(cell(s.browser + ' ' + (s.browserVersion || 'latest')));
+        if (table.dataset.testName === 'true') {
+            const nameCell = cell(s.name);
+            if (s.manual) {
+                const mark = document.createElement('mark');
+                mark.textContent = 'manual';
+                nameCell.appendChild(document.createTextNode('\u00a0'));
+                nameCell.appendChild(mark);
+            }
+            tr.appendChild(nameCell);
+        }
+        const actions = document.createElement('td');
+        const group = document.createElement('div');
+        group.role = 'group';
+        if (s.vnc) {
+            group.appendChild(button(s.vncLink, 'VNC', '', true));
+        }
+        group.appendChild(button(s.resetLink, 'Reset', 'secondary', false));
+        actions.appendChild(group);
+        tr.appendChild(actions);
+        table.tBodies[0].appendChild(tr);
+    }
+
+    function onSessionCreated(e) {
+        const data = JSON.parse(e.data);
+        updateIndexCount(data);
+        const table = sessionsTable(data.protocol);
+        if (table) {
+            if (data.session) {
+                addSessionRow(table, data.session);
+            }
+            updateFooter(table, data.count);
+        }
+    }
+
+    function onSessionDeleted(e) {
+        const data = JSON.parse(e.data);
+        updateIndexCount(data);
+        const table = sessionsTable(data.protocol);
+        if (table) {
+            const row = document.getElementById('session-' + data.id);
+            if (row) {
+                row.remove();
+            }
+            updateFooter(table, data.count);
+        }
+    }
+
+    function setText(id, value) {
+        const el = document.getElementById(id);
+        if (el) {
+            el.textContent = value;
+        }
+    }
+
+    function onQuota(e) {
+        const data = JSON.parse(e.data);
+        setText('quota-allocated', data.allocated);
+        setText('quota-limit', data.limit);
+        setText('queue-size', data.queueSize);
+        setText('queue-limit', data.queueLimit);
+    }
+
+    const es = new EventSource(eventsLink);
+    es.addEventListener('session-created', onSessionCreated);
+    es.addEventListener('session-deleted', onSessionDeleted);
+    es.addEventListener('quota', onQuota);
+})();
@@ -10,10 +10,12 @@ import (
 )
 
 type dockerBrowser struct {
+	id            string
 	forwardedHost string
 	u             *url.URL
 	host          string
 	ports         map[models.ContainerPort]int
+	exec          func(ctx context.Context, cmd []string) error
 	close         func(ctx context.Context)
 }
 
@@ -26,6 +28,10 @@ func (b dockerBrowser) GetHost() string {
 	return b.host
 }
 
+func (b dockerBrowser) GetBackendID() string {
+	return b.id
+}
+
 func (b dockerBrowser) GetHostPort(name models.ContainerPort) string {
 	p := b.ports[name]
 	if p == 0 {
@@ -35,6 +41,10 @@ func (b dockerBrowser) GetHostPort(name models.ContainerPort) string {
 	return net.JoinHostPort(b.forwardedHost, strconv.Itoa(p))
 }
 
+func (b dockerBrowser) Exec(ctx context.Context, cmd []string) error {
+	return b.exec(ctx, cmd)
+}
+
 func (b dockerBrowser) Close(ctx context.Context, _ bool) {
 	b.close(ctx)
 }
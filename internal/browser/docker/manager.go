@@ -16,6 +16,7 @@ import (
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/network"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -26,6 +27,7 @@ import (
 	"github.com/selebrow/selebrow/pkg/capabilities"
 	"github.com/selebrow/selebrow/pkg/docker"
 	"github.com/selebrow/selebrow/pkg/models"
+	"github.com/selebrow/selebrow/pkg/tracing"
 )
 
 const (
@@ -164,12 +166,16 @@ func (m *DockerBrowserManager) Allocate(
 		return nil, models.NewBadRequestError(errors.Errorf("browser %s image flavor %s is not supported", browserName, flavor))
 	}
 
-	id, err := m.createContainer(ctx, verCfg, caps)
+	cCtx, span := tracing.Start(ctx, "docker.createContainer")
+	id, err := m.createContainer(cCtx, verCfg, caps)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
 
-	info, err := m.startContainer(ctx, id)
+	sCtx, span := tracing.Start(ctx, "docker.startContainer", tracing.BackendIDKey.String(id))
+	info, err := m.startContainer(sCtx, id)
+	tracing.End(span, err)
 	if err != nil {
 		m.removeContainer(context.Background(), id)
 		return nil, err
@@ -207,6 +213,7 @@ func (m *DockerBrowserManager) createContainer(
 	}
 
 	image := fmt.Sprintf("%s:%s", cfg.Image, tag)
+	trace.SpanFromContext(ctx).SetAttributes(tracing.ImageKey.String(image))
 	ports := cfg.GetPorts(caps.IsVNCEnabled())
 
 	config := &container.Config{
@@ -258,12 +265,18 @@ func (m *DockerBrowserManager) doCreateContainer(
 		if errdefs.IsNotFound(err) {
 			// pull image (if pre pull was disabled at startup)
 			errCh := make(chan error, 1)
+			_, span := tracing.Start(ctx, "docker.pullImage", tracing.ImageKey.String(config.Image))
+			trace := browser.ContextAllocationTrace(ctx)
 			//nolint:gosec // image pull should continue after request cancellation to warm Docker cache
 			go func() {
 				// we are using context.Background here to avoid pull cancel if client is not patient enough
 				// in this case it will continue in background
 				// it's safe to pull the same image from different requests (docker does proper locking internally)
-				errCh <- pullImage(context.Background(), m.client, config.Image, m.l)
+				start := time.Now()
+				err := pullImage(context.Background(), m.client, config.Image, m.l)
+				tracing.End(span, err)
+				trace.OnImagePulled(config.Image, time.Since(start), err)
+				errCh <- err
 			}()
 			select {
 			case <-ctx.Done():
@@ -330,6 +343,17 @@ func (m *DockerBrowserManager) removeContainer(ctx context.Context, id string) {
 	}
 }
 
+func (m *DockerBrowserManager) execContainer(ctx context.Context, id string, cmd []string) error {
+	code, out, err := m.client.ContainerExec(ctx, id, cmd)
+	if err != nil {
+		return errors.Wrapf(err, "failed to execute %v in container %s", cmd, id)
+	}
+	if code != 0 {
+		return errors.Errorf("command %v in container %s exited with code %d: %s", cmd, id, code, out)
+	}
+	return nil
+}
+
 func (m *DockerBrowserManager) createBrowser(
 	cfg models.BrowserImageConfig,
 	vncEnabled bool,
@@ -381,10 +405,14 @@ func (m *DockerBrowserManager) createBrowser(
 	delete(ports, models.BrowserPort)
 
 	return &dockerBrowser{
+		id:            info.ID,
 		forwardedHost: forwardedHost,
 		u:             u,
 		host:          host,
 		ports:         ports,
+		exec: func(ctx context.Context, cmd []string) error {
+			return m.execContainer(ctx, info.ID, cmd)
+		},
 		close: func(ctx context.Context) {
 			m.removeContainer(ctx, info.ID)
 		},
@@ -268,6 +268,7 @@ var (
 
 func TestKubernetesBrowserManager_Allocate_PortMap_Mode(t *testing.T) {
 	g := NewWithT(t)
+	inDocker = func() bool { return false }
 
 	cat := new(mocks.BrowsersCatalog)
 	client := new(mocks.DockerClient)
@@ -287,20 +288,21 @@ func TestKubernetesBrowserManager_Allocate_PortMap_Mode(t *testing.T) {
 	cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
 
 	expHostConfig := getExpHostConfig(expPortBindings)
-	client.EXPECT().ContainerCreate(context.TODO(), expConfig, expHostConfig, expNetworkingConfig, "").Return(createResp, nil).Once()
-	client.EXPECT().ContainerStart(context.TODO(), testContainerID).Return(nil).Once()
+	client.EXPECT().ContainerCreate(mock.Anything, expConfig, expHostConfig, expNetworkingConfig, "").Return(createResp, nil).Once()
+	client.EXPECT().ContainerStart(mock.Anything, testContainerID).Return(nil).Once()
 
 	// test port mapping wait loop code path
-	client.EXPECT().ContainerInspect(context.TODO(), testContainerID).Return(inspectRespNoPortMap, nil).Once()
-	client.EXPECT().ContainerInspect(context.TODO(), testContainerID).Return(inspectRespPartialPortMap, nil).Once()
+	client.EXPECT().ContainerInspect(mock.Anything, testContainerID).Return(inspectRespNoPortMap, nil).Once()
+	client.EXPECT().ContainerInspect(mock.Anything, testContainerID).Return(inspectRespPartialPortMap, nil).Once()
 
-	client.EXPECT().ContainerInspect(context.TODO(), testContainerID).Return(inspectRespPortMap, nil).Once()
+	client.EXPECT().ContainerInspect(mock.Anything, testContainerID).Return(inspectRespPortMap, nil).Once()
 	wd, err := mgr.Allocate(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 
 	u := wd.GetURL()
 	g.Expect(u.String()).To(Equal("http://dockerhost:787/wd"))
 	g.Expect(wd.GetHost()).To(Equal("4.5.6.7:123"))
+	g.Expect(wd.GetBackendID()).To(Equal(testContainerID))
 
 	g.Expect(wd.GetHostPort(models.ClipboardPort)).To(Equal("dockerhost:999"))
 	g.Expect(wd.GetHostPort(models.VNCPort)).To(BeEmpty())
@@ -358,20 +360,21 @@ func TestKubernetesBrowserManager_Allocate_DirectConnection(t *testing.T) {
 	expHostConfig := getExpHostConfig(nil)
 	// check image pull code path
 	client.EXPECT().
-		ContainerCreate(context.TODO(), expConfig, expHostConfig, expNetworkingConfig, "").
+		ContainerCreate(mock.Anything, expConfig, expHostConfig, expNetworkingConfig, "").
 		Return(container.CreateResponse{}, &fakeNotFound{}).
 		Once()
 	client.EXPECT().ImagePull(context.Background(), "apple/safari:test-1").Return(nil).Once()
 
-	client.EXPECT().ContainerCreate(context.TODO(), expConfig, expHostConfig, expNetworkingConfig, "").Return(createResp, nil).Once()
-	client.EXPECT().ContainerStart(context.TODO(), testContainerID).Return(nil).Once()
-	client.EXPECT().ContainerInspect(context.TODO(), testContainerID).Return(inspectRespNoPortMap, nil).Once()
+	client.EXPECT().ContainerCreate(mock.Anything, expConfig, expHostConfig, expNetworkingConfig, "").Return(createResp, nil).Once()
+	client.EXPECT().ContainerStart(mock.Anything, testContainerID).Return(nil).Once()
+	client.EXPECT().ContainerInspect(mock.Anything, testContainerID).Return(inspectRespNoPortMap, nil).Once()
 	wd, err := mgr.Allocate(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 
 	u := wd.GetURL()
 	g.Expect(u.String()).To(Equal("http://4.5.6.7:123/wd"))
 	g.Expect(wd.GetHost()).To(Equal("4.5.6.7:123"))
+	g.Expect(wd.GetBackendID()).To(Equal(testContainerID))
 
 	g.Expect(wd.GetHostPort(models.ClipboardPort)).To(Equal("4.5.6.7:777"))
 	g.Expect(wd.GetHostPort(models.VNCPort)).To(BeEmpty())
@@ -487,7 +490,7 @@ func TestKubernetesBrowserManager_Allocate_Negative(t *testing.T) {
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, cat *mocks.BrowsersCatalog, client *mocks.DockerClient) {
 				cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
 				client.EXPECT().
-					ContainerCreate(ctx, mock.Anything, mock.Anything, mock.Anything, "").
+					ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").
 					Return(container.CreateResponse{}, testError).
 					Once()
 			},
@@ -498,7 +501,7 @@ func TestKubernetesBrowserManager_Allocate_Negative(t *testing.T) {
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, cat *mocks.BrowsersCatalog, client *mocks.DockerClient) {
 				cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
 				client.EXPECT().
-					ContainerCreate(ctx, mock.Anything, mock.Anything, mock.Anything, "").
+					ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").
 					Return(container.CreateResponse{}, &fakeNotFound{}).
 					Once()
 				client.EXPECT().ImagePull(context.Background(), "apple/safari:test-1").Return(testError).Once()
@@ -510,7 +513,7 @@ func TestKubernetesBrowserManager_Allocate_Negative(t *testing.T) {
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, cat *mocks.BrowsersCatalog, client *mocks.DockerClient) {
 				cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
 				client.EXPECT().
-					ContainerCreate(ctx, mock.Anything, mock.Anything, mock.Anything, "").
+					ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").
 					Return(container.CreateResponse{}, &fakeNotFound{}).
 					Once()
 				client.EXPECT().ImagePull(context.Background(), "apple/safari:test-1").
@@ -526,8 +529,8 @@ func TestKubernetesBrowserManager_Allocate_Negative(t *testing.T) {
 			version: "135",
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, cat *mocks.BrowsersCatalog, client *mocks.DockerClient) {
 				cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
-				client.EXPECT().ContainerCreate(ctx, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
-				client.EXPECT().ContainerStart(ctx, testContainerID).Return(testError).Once()
+				client.EXPECT().ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
+				client.EXPECT().ContainerStart(mock.Anything, testContainerID).Return(testError).Once()
 				client.EXPECT().ContainerRemove(context.Background(), testContainerID, true).Return(nil).Once()
 			},
 		},
@@ -536,9 +539,9 @@ func TestKubernetesBrowserManager_Allocate_Negative(t *testing.T) {
 			version: "135",
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, cat *mocks.BrowsersCatalog, client *mocks.DockerClient) {
 				cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
-				client.EXPECT().ContainerCreate(ctx, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
-				client.EXPECT().ContainerStart(ctx, testContainerID).Return(nil).Once()
-				client.EXPECT().ContainerInspect(ctx, testContainerID).Return(container.InspectResponse{}, testError).Once()
+				client.EXPECT().ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
+				client.EXPECT().ContainerStart(mock.Anything, testContainerID).Return(nil).Once()
+				client.EXPECT().ContainerInspect(mock.Anything, testContainerID).Return(container.InspectResponse{}, testError).Once()
 				client.EXPECT().ContainerRemove(context.Background(), testContainerID, true).Return(nil).Once()
 			},
 		},
@@ -547,9 +550,9 @@ func TestKubernetesBrowserManager_Allocate_Negative(t *testing.T) {
 			version: "135",
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, cat *mocks.BrowsersCatalog, client *mocks.DockerClient) {
 				cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
-				client.EXPECT().ContainerCreate(ctx, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
-				client.EXPECT().ContainerStart(ctx, testContainerID).Return(nil).Once()
-				client.EXPECT().ContainerInspect(ctx, testContainerID).Return(inspectRespNotRunning, nil).Once()
+				client.EXPECT().ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
+				client.EXPECT().ContainerStart(mock.Anything, testContainerID).Return(nil).Once()
+				client.EXPECT().ContainerInspect(mock.Anything, testContainerID).Return(inspectRespNotRunning, nil).Once()
 				client.EXPECT().ContainerRemove(context.Background(), testContainerID, true).Return(nil).Once()
 			},
 		},
@@ -558,9 +561,9 @@ func TestKubernetesBrowserManager_Allocate_Negative(t *testing.T) {
 			version: "135",
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, cat *mocks.BrowsersCatalog, client *mocks.DockerClient) {
 				cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
-				client.EXPECT().ContainerCreate(ctx, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
-				client.EXPECT().ContainerStart(ctx, testContainerID).Return(nil).Once()
-				client.EXPECT().ContainerInspect(ctx, testContainerID).Return(inspectRespNoNetwork, nil).Once()
+				client.EXPECT().ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
+				client.EXPECT().ContainerStart(mock.Anything, testContainerID).Return(nil).Once()
+				client.EXPECT().ContainerInspect(mock.Anything, testContainerID).Return(inspectRespNoNetwork, nil).Once()
 				client.EXPECT().ContainerRemove(context.Background(), testContainerID, true).Return(nil).Once()
 			},
 		},
@@ -569,9 +572,9 @@ func TestKubernetesBrowserManager_Allocate_Negative(t *testing.T) {
 			version: "135",
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, cat *mocks.BrowsersCatalog, client *mocks.DockerClient) {
 				cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
-				client.EXPECT().ContainerCreate(ctx, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
-				client.EXPECT().ContainerStart(ctx, testContainerID).Return(nil).Once()
-				client.EXPECT().ContainerInspect(ctx, testContainerID).Return(inspectRespNoIP, nil).Once()
+				client.EXPECT().ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
+				client.EXPECT().ContainerStart(mock.Anything, testContainerID).Return(nil).Once()
+				client.EXPECT().ContainerInspect(mock.Anything, testContainerID).Return(inspectRespNoIP, nil).Once()
 				client.EXPECT().ContainerRemove(context.Background(), testContainerID, true).Return(nil).Once()
 			},
 		},
@@ -580,9 +583,9 @@ func TestKubernetesBrowserManager_Allocate_Negative(t *testing.T) {
 			version: "135",
 			setupMocks: func(ctx context.Context, cancel context.CancelFunc, cat *mocks.BrowsersCatalog, client *mocks.DockerClient) {
 				cat.EXPECT().LookupBrowserImage(testBrowserProtocol, "safari", "def").Return(testBrowsersConfig, true).Once()
-				client.EXPECT().ContainerCreate(ctx, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
-				client.EXPECT().ContainerStart(ctx, testContainerID).Return(nil).Once()
-				client.EXPECT().ContainerInspect(ctx, testContainerID).Return(inspectRespNoMappedPort, nil).Once()
+				client.EXPECT().ContainerCreate(mock.Anything, mock.Anything, mock.Anything, mock.Anything, "").Return(createResp, nil).Once()
+				client.EXPECT().ContainerStart(mock.Anything, testContainerID).Return(nil).Once()
+				client.EXPECT().ContainerInspect(mock.Anything, testContainerID).Return(inspectRespNoMappedPort, nil).Once()
 				client.EXPECT().ContainerRemove(context.Background(), testContainerID, true).Return(nil).Once()
 			},
 		},
@@ -0,0 +1,82 @@
+package evented
+
+import (
+	"context"
+	"time"
+
+	"github.com/selebrow/selebrow/internal/common/clock"
+	"github.com/selebrow/selebrow/pkg/browser"
+	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+// EventedBrowserManager publishes events for browser allocation stages reported by the underlying managers
+type EventedBrowserManager struct {
+	mgr browser.BrowserManager
+	eb  event.EventBroker
+	now clock.NowFunc
+}
+
+func NewEventedBrowserManager(mgr browser.BrowserManager, eb event.EventBroker, now clock.NowFunc) *EventedBrowserManager {
+	return &EventedBrowserManager{
+		mgr: mgr,
+		eb:  eb,
+		now: now,
+	}
+}
+
+func (m *EventedBrowserManager) Allocate(
+	ctx context.Context,
+	protocol models.BrowserProtocol,
+	caps capabilities.Capabilities,
+) (browser.Browser, error) {
+	req := evmodels.BrowserRequest{
+		RequestID:      event.ContextRequestID(ctx),
+		Protocol:       protocol,
+		BrowserName:    caps.GetName(),
+		BrowserVersion: caps.GetVersion(),
+		Labels:         caps.GetLabels(),
+	}
+
+	m.eb.Publish(evmodels.NewAllocationStartedEvent(evmodels.AllocationStarted{BrowserRequest: req}))
+	start := m.now()
+	br, err := m.mgr.Allocate(browser.WithAllocationTrace(ctx, m.trace(req)), protocol, caps)
+	m.eb.Publish(evmodels.NewAllocationFinishedEvent(evmodels.AllocationFinished{
+		BrowserRequest: req,
+		Duration:       m.now().Sub(start),
+		Error:          evmodels.ErrorString(err),
+	}))
+
+	return br, err
+}
+
+func (m *EventedBrowserManager) trace(req evmodels.BrowserRequest) *browser.AllocationTrace {
+	return &browser.AllocationTrace{
+		Queued: func(queueSize int) {
+			m.eb.Publish(evmodels.NewAllocationQueuedEvent(evmodels.AllocationQueued{
+				BrowserRequest: req,
+				QueueSize:      queueSize,
+			}))
+		},
+		Dequeued: func(waited time.Duration, err error) {
+			m.eb.Publish(evmodels.NewAllocationDequeuedEvent(evmodels.AllocationDequeued{
+				BrowserRequest: req,
+				WaitDuration:   waited,
+				Error:          evmodels.ErrorString(err),
+			}))
+		},
+		PoolCheckout: func(hit bool) {
+			m.eb.Publish(evmodels.NewPoolCheckoutEvent(hit, evmodels.PoolCheckout{BrowserRequest: req}))
+		},
+		ImagePulled: func(image string, duration time.Duration, err error) {
+			m.eb.Publish(evmodels.NewImagePulledEvent(evmodels.ImagePulled{
+				BrowserRequest: req,
+				Image:          image,
+				Duration:       duration,
+				Error:          evmodels.ErrorString(err),
+			}))
+		},
+	}
+}
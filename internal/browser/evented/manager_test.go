@@ -0,0 +1,102 @@
+package evented
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/browser"
+	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+const testProt models.BrowserProtocol = "test"
+
+func TestEventedBrowserManager_Allocate(t *testing.T) {
+	g := NewWithT(t)
+	mgr := mocks.NewBrowserManager(t)
+	eb := mocks.NewEventBroker(t)
+	caps := mocks.NewCapabilities(t)
+	caps.EXPECT().GetName().Return("chrome")
+	caps.EXPECT().GetVersion().Return("120.0")
+	caps.EXPECT().GetLabels().Return(map[string]string{"team": "qa"})
+
+	var events []evmodels.IEvent
+	eb.EXPECT().Publish(mock.Anything).Run(func(ev evmodels.IEvent) {
+		events = append(events, ev)
+	})
+
+	br := mocks.NewBrowser(t)
+	mgr.EXPECT().Allocate(mock.Anything, testProt, caps).RunAndReturn(
+		func(ctx context.Context, _ models.BrowserProtocol, _ capabilities.Capabilities) (browser.Browser, error) {
+			trace := browser.ContextAllocationTrace(ctx)
+			trace.OnQueued(3)
+			trace.OnDequeued(time.Second, nil)
+			trace.OnPoolCheckout(false)
+			trace.OnImagePulled("chrome:120.0", time.Minute, errors.New("pull failed"))
+			return br, nil
+		}).Once()
+
+	tm := time.UnixMilli(100)
+	now := func() time.Time {
+		tm = tm.Add(time.Second)
+		return tm
+	}
+	m := NewEventedBrowserManager(mgr, eb, now)
+
+	got, err := m.Allocate(event.WithRequestID(context.TODO(), "r1"), testProt, caps)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got).To(BeIdenticalTo(br))
+
+	req := evmodels.BrowserRequest{
+		RequestID:      "r1",
+		Protocol:       testProt,
+		BrowserName:    "chrome",
+		BrowserVersion: "120.0",
+		Labels:         map[string]string{"team": "qa"},
+	}
+	g.Expect(events).To(HaveLen(6))
+	g.Expect(events[0].(*evmodels.Event[evmodels.AllocationStarted]).Attributes).
+		To(Equal(evmodels.AllocationStarted{BrowserRequest: req}))
+	g.Expect(events[1].(*evmodels.Event[evmodels.AllocationQueued]).Attributes).
+		To(Equal(evmodels.AllocationQueued{BrowserRequest: req, QueueSize: 3}))
+	g.Expect(events[2].(*evmodels.Event[evmodels.AllocationDequeued]).Attributes).
+		To(Equal(evmodels.AllocationDequeued{BrowserRequest: req, WaitDuration: time.Second}))
+	g.Expect(events[3].EventType()).To(Equal(evmodels.PoolMissEventType))
+	g.Expect(events[3].(*evmodels.Event[evmodels.PoolCheckout]).Attributes).
+		To(Equal(evmodels.PoolCheckout{BrowserRequest: req}))
+	g.Expect(events[4].(*evmodels.Event[evmodels.ImagePulled]).Attributes).
+		To(Equal(evmodels.ImagePulled{BrowserRequest: req, Image: "chrome:120.0", Duration: time.Minute, Error: "pull failed"}))
+	g.Expect(events[5].(*evmodels.Event[evmodels.AllocationFinished]).Attributes).
+		To(Equal(evmodels.AllocationFinished{BrowserRequest: req, Duration: time.Second}))
+}
+
+func TestEventedBrowserManager_AllocateFailed(t *testing.T) {
+	g := NewWithT(t)
+	mgr := mocks.NewBrowserManager(t)
+	eb := mocks.NewEventBroker(t)
+	caps := mocks.NewCapabilities(t)
+	caps.EXPECT().GetName().Return("chrome")
+	caps.EXPECT().GetVersion().Return("120.0")
+	caps.EXPECT().GetLabels().Return(nil)
+
+	eb.EXPECT().Publish(mock.Anything).Run(func(ev evmodels.IEvent) {
+		g.Expect(ev.EventType()).To(Equal(evmodels.AllocationStartedEventType))
+	}).Once()
+	eb.EXPECT().Publish(mock.Anything).Run(func(ev evmodels.IEvent) {
+		g.Expect(ev.(*evmodels.Event[evmodels.AllocationFinished]).Attributes.Error).To(Equal("test error"))
+	}).Once()
+
+	mgr.EXPECT().Allocate(mock.Anything, testProt, caps).Return(nil, errors.New("test error")).Once()
+
+	m := NewEventedBrowserManager(mgr, eb, time.Now)
+	_, err := m.Allocate(context.TODO(), testProt, caps)
+	g.Expect(err).To(MatchError("test error"))
+}
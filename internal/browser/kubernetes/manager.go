@@ -18,8 +18,12 @@ import (
 	"github.com/selebrow/selebrow/pkg/capabilities"
 	"github.com/selebrow/selebrow/pkg/kubeapi"
 	"github.com/selebrow/selebrow/pkg/models"
+	"github.com/selebrow/selebrow/pkg/tracing"
 )
 
+// must match browser container name in the pod template
+const browserContainerName = "browser"
+
 type KubernetesBrowserManager struct {
 	cat     browsers.BrowsersCatalog
 	client  kubeapi.KubernetesClient
@@ -60,13 +64,20 @@ func (m *KubernetesBrowserManager) Allocate(
 		return nil, models.NewBadRequestError(errors.Errorf("browser %s image flavor %s is not supported", browserName, flavor))
 	}
 
-	p, err := m.createPod(ctx, verCfg, caps)
+	cCtx, span := tracing.Start(ctx, "kubernetes.createPod")
+	p, err := m.createPod(cCtx, verCfg, caps)
+	if err == nil {
+		span.SetAttributes(tracing.BackendIDKey.String(p.Name))
+	}
+	tracing.End(span, err)
 	if err != nil {
 		return nil, err
 	}
 
 	m.l.Infow("pod has been created", zap.String("pod", p.Name))
-	ip, err := m.w.WaitPodReady(ctx, p.Name)
+	wCtx, span := tracing.Start(ctx, "kubernetes.waitPodReady", tracing.BackendIDKey.String(p.Name))
+	ip, err := m.w.WaitPodReady(wCtx, p.Name)
+	tracing.End(span, err)
 
 	if err != nil {
 		m.deletePod(context.Background(), p.Name)
@@ -131,6 +142,14 @@ func (m *KubernetesBrowserManager) deletePod(ctx context.Context, podName string
 	}
 }
 
+func (m *KubernetesBrowserManager) execPod(ctx context.Context, podName string, cmd []string) error {
+	out, err := m.client.ExecPod(ctx, podName, browserContainerName, cmd)
+	if err != nil {
+		return errors.Wrapf(err, "failed to execute %v in pod %s: %s", cmd, podName, out)
+	}
+	return nil
+}
+
 func (m *KubernetesBrowserManager) createBrowser(
 	podName string,
 	ip string,
@@ -146,10 +165,14 @@ func (m *KubernetesBrowserManager) createBrowser(
 		}
 
 		return &kubernetesBrowser{
+			id:            podName,
 			forwardedHost: forwardedHost,
 			u:             u,
 			host:          host,
 			ports:         verCfg.GetPorts(vncEnabled),
+			exec: func(ctx context.Context, cmd []string) error {
+				return m.execPod(ctx, podName, cmd)
+			},
 			close: func(ctx context.Context) {
 				m.deletePod(ctx, podName)
 			},
@@ -185,10 +208,14 @@ func (m *KubernetesBrowserManager) createBrowser(
 		}
 	}
 	return &kubernetesBrowser{
+		id:            podName,
 		forwardedHost: forwardedHost,
 		u:             u,
 		host:          host,
 		ports:         localPorts,
+		exec: func(ctx context.Context, cmd []string) error {
+			return m.execPod(ctx, podName, cmd)
+		},
 		close: func(ctx context.Context) {
 			close(stopCh)
 			m.deletePod(ctx, podName)
@@ -54,14 +54,15 @@ func TestKubernetesBrowserManager_Allocate(t *testing.T) {
 
 	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "mypod"}}
 	bc.EXPECT().ToPod(cfg, caps).Return(pod, nil)
-	client.EXPECT().CreatePod(context.TODO(), &pod).Return(&pod, nil).Once()
-	w.EXPECT().WaitPodReady(context.TODO(), "mypod").Return("1.2.3.4", nil).Once()
+	client.EXPECT().CreatePod(mock.Anything, &pod).Return(&pod, nil).Once()
+	w.EXPECT().WaitPodReady(mock.Anything, "mypod").Return("1.2.3.4", nil).Once()
 	wd, err := mgr.Allocate(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 
 	u := wd.GetURL()
 	g.Expect(u.String()).To(Equal("http://1.2.3.4:123/wd"))
 	g.Expect(wd.GetHost()).To(Equal("1.2.3.4:123"))
+	g.Expect(wd.GetBackendID()).To(Equal("mypod"))
 
 	g.Expect(wd.GetHostPort(models.ClipboardPort)).To(Equal("1.2.3.4:777"))
 	g.Expect(wd.GetHostPort(models.VNCPort)).To(BeEmpty())
@@ -123,7 +124,7 @@ func TestKubernetesBrowserManager_AllocateCreatePodError(t *testing.T) {
 
 	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nocreate"}}
 	bc.EXPECT().ToPod(models.BrowserImageConfig{}, caps).Return(pod, nil)
-	client.EXPECT().CreatePod(context.TODO(), &pod).Return(&pod, errors.New("test pod create error")).Once()
+	client.EXPECT().CreatePod(mock.Anything, &pod).Return(&pod, errors.New("test pod create error")).Once()
 	_, err := mgr.Allocate(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).To(MatchError("test pod create error"))
 
@@ -147,7 +148,7 @@ func TestKubernetesBrowserManager_AllocateCreatePodError_Backoff(t *testing.T) {
 
 	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nocreate"}}
 	bc.EXPECT().ToPod(models.BrowserImageConfig{}, caps).Return(pod, nil)
-	client.EXPECT().CreatePod(context.TODO(), &pod).Return(nil, k8sErr.NewInternalError(errors.New("test pod retryable error"))).Twice()
+	client.EXPECT().CreatePod(mock.Anything, &pod).Return(nil, k8sErr.NewInternalError(errors.New("test pod retryable error"))).Twice()
 	_, err := mgr.Allocate(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).To(MatchError(MatchRegexp("test pod retryable error")))
 
@@ -168,8 +169,8 @@ func TestKubernetesBrowserManager_AllocatePodWatchError(t *testing.T) {
 
 	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "nostart"}}
 	bc.EXPECT().ToPod(models.BrowserImageConfig{}, caps).Return(pod, nil)
-	client.EXPECT().CreatePod(context.TODO(), &pod).Return(&pod, nil).Once()
-	w.EXPECT().WaitPodReady(context.TODO(), "nostart").Return("", errors.New("test pod watch error")).Once()
+	client.EXPECT().CreatePod(mock.Anything, &pod).Return(&pod, nil).Once()
+	w.EXPECT().WaitPodReady(mock.Anything, "nostart").Return("", errors.New("test pod watch error")).Once()
 	client.EXPECT().DeletePod(context.Background(), "nostart").Return(nil).Once()
 	_, err := mgr.Allocate(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).To(MatchError("test pod watch error"))
@@ -70,6 +70,16 @@ func (w *PodWatcherImpl) WaitPodReady(ctx context.Context, podName string) (stri
 	return ip, err
 }
 
+// Check returns error if pod events watch loop is terminated
+func (w *PodWatcherImpl) Check(_ context.Context) error {
+	select {
+	case <-w.done:
+		return errors.New("pod events watcher is not running")
+	default:
+	}
+	return nil
+}
+
 func (w *PodWatcherImpl) Shutdown(ctx context.Context) error {
 	w.l.Info("pod watcher is shutting down...")
 	w.cancel()
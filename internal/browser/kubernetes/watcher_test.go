@@ -117,3 +117,20 @@ func makePod(name, ip string, ready bool) *v1.Pod {
 
 	return p
 }
+
+func TestPodWatcherImpl_Check(t *testing.T) {
+	g := NewWithT(t)
+
+	client := new(mocks.KubernetesClient)
+	ch := make(chan *watch.Event)
+	client.EXPECT().Watch(mock.Anything, mock.Anything).Return(ch, nil)
+	w, err := NewPodWatcher(client, "123", zaptest.NewLogger(t))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(w.Check(context.TODO())).To(Succeed())
+
+	close(ch)
+	g.Eventually(func() error {
+		return w.Check(context.TODO())
+	}).Should(MatchError("pod events watcher is not running"))
+}
@@ -30,10 +30,18 @@ func (b *LimitedBrowser) GetHost() string {
 	return b.br.GetHost()
 }
 
+func (b *LimitedBrowser) GetBackendID() string {
+	return b.br.GetBackendID()
+}
+
 func (b *LimitedBrowser) GetHostPort(name models.ContainerPort) string {
 	return b.br.GetHostPort(name)
 }
 
+func (b *LimitedBrowser) Exec(ctx context.Context, cmd []string) error {
+	return b.br.Exec(ctx, cmd)
+}
+
 func (b *LimitedBrowser) Close(ctx context.Context, trash bool) {
 	b.release()
 	b.br.Close(ctx, trash)
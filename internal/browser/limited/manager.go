@@ -10,6 +10,7 @@ import (
 	"github.com/selebrow/selebrow/pkg/capabilities"
 	"github.com/selebrow/selebrow/pkg/models"
 	"github.com/selebrow/selebrow/pkg/quota"
+	"github.com/selebrow/selebrow/pkg/tracing"
 )
 
 type LimitedBrowserManager struct {
@@ -40,7 +41,10 @@ func (m *LimitedBrowserManager) Allocate(
 ) (browser.Browser, error) {
 	qCtx, cancel := context.WithTimeout(ctx, m.queueTimeout)
 	defer cancel()
-	if err := m.qa.Reserve(qCtx); err != nil {
+	qCtx, span := tracing.Start(qCtx, "quota.reserve")
+	err := m.qa.Reserve(qCtx)
+	tracing.End(span, err)
+	if err != nil {
 		return nil, err
 	}
 
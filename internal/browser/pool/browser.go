@@ -14,11 +14,12 @@ import (
 type CheckinFunc func(wd *PooledBrowser)
 
 type PooledBrowser struct {
-	id      string
-	br      browser.Browser
-	idle    *time.Timer
-	tm      *time.Time
-	checkin CheckinFunc
+	id        string
+	br        browser.Browser
+	idle      *time.Timer
+	idleSince time.Time
+	tm        *time.Time
+	checkin   CheckinFunc
 }
 
 func NewPooledBrowser(wd browser.Browser, ch CheckinFunc) *PooledBrowser {
@@ -39,10 +40,18 @@ func (w *PooledBrowser) GetHost() string {
 	return w.br.GetHost()
 }
 
+func (w *PooledBrowser) GetBackendID() string {
+	return w.br.GetBackendID()
+}
+
 func (w *PooledBrowser) GetHostPort(name models.ContainerPort) string {
 	return w.br.GetHostPort(name)
 }
 
+func (w *PooledBrowser) Exec(ctx context.Context, cmd []string) error {
+	return w.br.Exec(ctx, cmd)
+}
+
 func (w *PooledBrowser) Close(ctx context.Context, trash bool) {
 	if trash {
 		w.br.Close(ctx, true)
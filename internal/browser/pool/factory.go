@@ -8,23 +8,30 @@ import (
 )
 
 type BrowserPoolFactory interface {
-	GetPool(name string) BrowserPool
+	GetPool(key PoolKey) BrowserPool
 }
 
 type IdleBrowserPoolFactory struct {
 	cfg config.PoolConfig
 	mgr browser.BrowserManager
+	hc  HealthChecker
 	l   *zap.Logger
 }
 
-func NewIdleBrowserPoolFactory(cfg config.PoolConfig, mgr browser.BrowserManager, l *zap.Logger) *IdleBrowserPoolFactory {
+func NewIdleBrowserPoolFactory(
+	cfg config.PoolConfig,
+	mgr browser.BrowserManager,
+	hc HealthChecker,
+	l *zap.Logger,
+) *IdleBrowserPoolFactory {
 	return &IdleBrowserPoolFactory{
 		cfg: cfg,
 		mgr: mgr,
+		hc:  hc,
 		l:   l,
 	}
 }
 
-func (f *IdleBrowserPoolFactory) GetPool(name string) BrowserPool {
-	return NewIdleBrowserPool(name, f.mgr, f.cfg, f.l)
+func (f *IdleBrowserPoolFactory) GetPool(key PoolKey) BrowserPool {
+	return NewIdleBrowserPool(key, f.mgr, f.hc, f.cfg, f.l)
 }
@@ -0,0 +1,78 @@
+package pool
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/net/proxy"
+
+	"github.com/selebrow/selebrow/internal/common/client"
+	"github.com/selebrow/selebrow/pkg/browser"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+type HealthChecker interface {
+	Check(ctx context.Context, protocol models.BrowserProtocol, br browser.Browser) error
+}
+
+type ProbeHealthChecker struct {
+	client  client.HTTPClient
+	d       proxy.ContextDialer
+	timeout time.Duration
+}
+
+func NewProbeHealthChecker(hc client.HTTPClient, d proxy.ContextDialer, timeout time.Duration) *ProbeHealthChecker {
+	return &ProbeHealthChecker{
+		client:  hc,
+		d:       d,
+		timeout: timeout,
+	}
+}
+
+func (c *ProbeHealthChecker) Check(ctx context.Context, protocol models.BrowserProtocol, br browser.Browser) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	switch protocol {
+	case models.WebdriverProtocol:
+		return c.checkWebdriver(ctx, br)
+	case models.PlaywrightProtocol, models.PuppeteerProtocol:
+		return c.checkTCP(ctx, br)
+	default:
+		return nil
+	}
+}
+
+func (c *ProbeHealthChecker) checkWebdriver(ctx context.Context, br browser.Browser) error {
+	u := *br.GetURL()
+	u.Path = path.Join(u.Path, "status")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return err
+	}
+	req.Host = br.GetHost()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "status request %s failed", u.String())
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		return errors.Errorf("status request %s failed with code %d", u.String(), resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *ProbeHealthChecker) checkTCP(ctx context.Context, br browser.Browser) error {
+	hostport := br.GetURL().Host
+	conn, err := c.d.DialContext(ctx, "tcp", hostport)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to %s", hostport)
+	}
+	_ = conn.Close()
+	return nil
+}
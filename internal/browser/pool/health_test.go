@@ -0,0 +1,61 @@
+package pool_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/selebrow/selebrow/internal/browser/pool"
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestProbeHealthChecker_Webdriver(t *testing.T) {
+	g := NewWithT(t)
+
+	var code int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.URL.Path).To(Equal("/wd/hub/status"))
+		g.Expect(r.Host).To(Equal("browser-host"))
+		w.WriteHeader(code)
+	}))
+	defer srv.Close()
+
+	u, err := url.Parse(srv.URL + "/wd/hub")
+	g.Expect(err).ToNot(HaveOccurred())
+	br := new(mocks.Browser)
+	br.EXPECT().GetURL().Return(u)
+	br.EXPECT().GetHost().Return("browser-host")
+
+	c := pool.NewProbeHealthChecker(srv.Client(), &net.Dialer{}, time.Second)
+
+	code = http.StatusOK
+	g.Expect(c.Check(context.TODO(), models.WebdriverProtocol, br)).To(Succeed())
+
+	code = http.StatusInternalServerError
+	g.Expect(c.Check(context.TODO(), models.WebdriverProtocol, br)).To(MatchError(ContainSubstring("failed with code 500")))
+}
+
+func TestProbeHealthChecker_Playwright(t *testing.T) {
+	g := NewWithT(t)
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	u := &url.URL{Scheme: "ws", Host: ln.Addr().String()}
+	br := new(mocks.Browser)
+	br.EXPECT().GetURL().Return(u)
+
+	c := pool.NewProbeHealthChecker(http.DefaultClient, &net.Dialer{}, time.Second)
+	g.Expect(c.Check(context.TODO(), models.PlaywrightProtocol, br)).To(Succeed())
+	g.Expect(c.Check(context.TODO(), models.PuppeteerProtocol, br)).To(Succeed())
+
+	g.Expect(ln.Close()).To(Succeed())
+	g.Expect(c.Check(context.TODO(), models.PlaywrightProtocol, br)).To(HaveOccurred())
+}
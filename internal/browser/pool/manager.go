@@ -3,19 +3,28 @@ package pool
 import (
 	"context"
 	"encoding/hex"
-	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/pkg/errors"
 
 	"github.com/selebrow/selebrow/pkg/browser"
 	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/dto"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
+var ErrPoolNotFound = errors.New("pool not found")
+
 type (
 	GetHashFunc func(caps capabilities.Capabilities) []byte
 
+	PoolAdmin interface {
+		ListPools() []dto.Pool
+		DrainPool(ctx context.Context, name string) (int, error)
+		DrainPools(ctx context.Context) (int, error)
+	}
+
 	BrowserPoolManager struct {
 		pools    map[string]BrowserPool
 		m        sync.RWMutex
@@ -42,11 +51,11 @@ func (m *BrowserPoolManager) Allocate(
 		return nil, errors.New("Pool manager was shutdown")
 	}
 
-	name := m.getUniquePoolName(protocol, caps)
+	key := m.getPoolKey(protocol, caps)
 
-	pool, ok := m.getPool(name)
+	pool, ok := m.getPool(key.String())
 	if !ok {
-		pool = m.createPool(name)
+		pool = m.createPool(key)
 	}
 	return pool.Checkout(ctx, protocol, caps)
 }
@@ -79,6 +88,48 @@ func (m *BrowserPoolManager) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+func (m *BrowserPoolManager) ListPools() []dto.Pool {
+	pools := m.listPools()
+	res := make([]dto.Pool, 0, len(pools))
+	for _, p := range pools {
+		res = append(res, p.Info())
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
+func (m *BrowserPoolManager) DrainPool(ctx context.Context, name string) (int, error) {
+	p, ok := m.getPool(name)
+	if !ok {
+		return 0, ErrPoolNotFound
+	}
+	return p.Drain(ctx)
+}
+
+func (m *BrowserPoolManager) DrainPools(ctx context.Context) (int, error) {
+	var total int
+	for _, p := range m.listPools() {
+		n, err := p.Drain(ctx)
+		total += n
+		if err != nil {
+			return total, err
+		}
+	}
+	return total, nil
+}
+
+func (m *BrowserPoolManager) listPools() []BrowserPool {
+	m.m.RLock()
+	defer m.m.RUnlock()
+	res := make([]BrowserPool, 0, len(m.pools))
+	for _, p := range m.pools {
+		res = append(res, p)
+	}
+	return res
+}
+
 func (m *BrowserPoolManager) getPool(name string) (BrowserPool, bool) {
 	m.m.RLock()
 	defer m.m.RUnlock()
@@ -86,13 +137,14 @@ func (m *BrowserPoolManager) getPool(name string) (BrowserPool, bool) {
 	return p, ok
 }
 
-func (m *BrowserPoolManager) createPool(name string) BrowserPool {
+func (m *BrowserPoolManager) createPool(key PoolKey) BrowserPool {
 	m.m.Lock()
 	defer m.m.Unlock()
 	// double check to avoid race condition with getPool/createPool
+	name := key.String()
 	p, ok := m.pools[name]
 	if !ok {
-		p = m.f.GetPool(name)
+		p = m.f.GetPool(key)
 		m.pools[name] = p
 	}
 	return p
@@ -104,6 +156,10 @@ func (m *BrowserPoolManager) isShutdown() bool {
 	return m.shutdown
 }
 
-func (m *BrowserPoolManager) getUniquePoolName(protocol models.BrowserProtocol, caps capabilities.Capabilities) string {
-	return fmt.Sprintf("%s-%s-%s", string(protocol), caps.GetName(), hex.EncodeToString(m.getHash(caps)))
+func (m *BrowserPoolManager) getPoolKey(protocol models.BrowserProtocol, caps capabilities.Capabilities) PoolKey {
+	return PoolKey{
+		Protocol: protocol,
+		Browser:  caps.GetName(),
+		CapsHash: hex.EncodeToString(m.getHash(caps)),
+	}
 }
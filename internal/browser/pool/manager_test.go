@@ -10,6 +10,7 @@ import (
 	"github.com/selebrow/selebrow/internal/browser/pool"
 	"github.com/selebrow/selebrow/mocks"
 	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/dto"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
@@ -34,7 +35,7 @@ func TestBrowserPoolManager_Allocate(t *testing.T) {
 
 	pm := pool.NewBrowserPoolManager(f, gh)
 
-	f.EXPECT().GetPool("test-mosaic-dead").Return(p).Once()
+	f.EXPECT().GetPool(pool.PoolKey{Protocol: testBrowserProtocol, Browser: "mosaic", CapsHash: "dead"}).Return(p).Once()
 	p.EXPECT().Checkout(context.TODO(), testBrowserProtocol, caps).Return(br1, nil).Once()
 
 	got1, err := pm.Allocate(context.TODO(), testBrowserProtocol, caps)
@@ -58,3 +59,52 @@ func TestBrowserPoolManager_Allocate(t *testing.T) {
 	_, err = pm.Allocate(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).To(HaveOccurred())
 }
+
+func TestBrowserPoolManager_ListAndDrain(t *testing.T) {
+	g := NewWithT(t)
+
+	gh := func(caps capabilities.Capabilities) []byte {
+		return []byte{0xbe, 0xef}
+	}
+
+	caps1 := new(mocks.Capabilities)
+	caps1.EXPECT().GetName().Return("mosaic")
+	caps2 := new(mocks.Capabilities)
+	caps2.EXPECT().GetName().Return("lynx")
+
+	p1 := mocks.NewBrowserPool(t)
+	p2 := mocks.NewBrowserPool(t)
+	f := mocks.NewBrowserPoolFactory(t)
+
+	pm := pool.NewBrowserPoolManager(f, gh)
+
+	key1 := pool.PoolKey{Protocol: testBrowserProtocol, Browser: "mosaic", CapsHash: "beef"}
+	key2 := pool.PoolKey{Protocol: testBrowserProtocol, Browser: "lynx", CapsHash: "beef"}
+	f.EXPECT().GetPool(key1).Return(p1).Once()
+	f.EXPECT().GetPool(key2).Return(p2).Once()
+	p1.EXPECT().Checkout(context.TODO(), testBrowserProtocol, caps1).Return(new(mocks.Browser), nil).Once()
+	p2.EXPECT().Checkout(context.TODO(), testBrowserProtocol, caps2).Return(new(mocks.Browser), nil).Once()
+
+	_, err := pm.Allocate(context.TODO(), testBrowserProtocol, caps1)
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = pm.Allocate(context.TODO(), testBrowserProtocol, caps2)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	p1.EXPECT().Info().Return(dto.Pool{Name: key1.String()}).Once()
+	p2.EXPECT().Info().Return(dto.Pool{Name: key2.String()}).Once()
+	g.Expect(pm.ListPools()).To(Equal([]dto.Pool{{Name: "test-lynx-beef"}, {Name: "test-mosaic-beef"}}))
+
+	p1.EXPECT().Drain(context.TODO()).Return(2, nil).Once()
+	n, err := pm.DrainPool(context.TODO(), "test-mosaic-beef")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(n).To(Equal(2))
+
+	_, err = pm.DrainPool(context.TODO(), "test-netscape-beef")
+	g.Expect(err).To(MatchError(pool.ErrPoolNotFound))
+
+	p1.EXPECT().Drain(context.TODO()).Return(1, nil).Once()
+	p2.EXPECT().Drain(context.TODO()).Return(3, nil).Once()
+	n, err = pm.DrainPools(context.TODO())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(n).To(Equal(4))
+}
@@ -3,41 +3,77 @@ package pool
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/selebrow/selebrow/pkg/browser"
 	"github.com/selebrow/selebrow/pkg/capabilities"
 	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/dto"
 	"github.com/selebrow/selebrow/pkg/models"
+	"github.com/selebrow/selebrow/pkg/tracing"
 
 	"go.uber.org/zap"
 )
 
 type BrowserPool interface {
 	Checkout(ctx context.Context, protocol models.BrowserProtocol, caps capabilities.Capabilities) (browser.Browser, error)
+	Info() dto.Pool
+	Drain(ctx context.Context) (int, error)
 	Shutdown(ctx context.Context) error
 }
 
+type PoolKey struct {
+	Protocol models.BrowserProtocol
+	Browser  string
+	CapsHash string
+}
+
+func (k PoolKey) String() string {
+	return fmt.Sprintf("%s-%s-%s", string(k.Protocol), k.Browser, k.CapsHash)
+}
+
+type poolCounters struct {
+	hits                atomic.Uint64
+	misses              atomic.Uint64
+	evictions           atomic.Uint64
+	healthCheckFailures atomic.Uint64
+}
+
 type IdleBrowserPool struct {
+	key         PoolKey
 	name        string
 	idleWd      map[string]*PooledBrowser
 	mgr         browser.BrowserManager
+	hc          HealthChecker
+	stats       poolCounters
 	m           sync.RWMutex
 	maxIdle     int
 	maxAge      time.Duration
 	idleTimeout time.Duration
 	shutdown    bool
+	drained     time.Time
 	l           *zap.SugaredLogger
 }
 
-func NewIdleBrowserPool(name string, mgr browser.BrowserManager, cfg config.PoolConfig, l *zap.Logger) *IdleBrowserPool {
+func NewIdleBrowserPool(
+	key PoolKey,
+	mgr browser.BrowserManager,
+	hc HealthChecker,
+	cfg config.PoolConfig,
+	l *zap.Logger,
+) *IdleBrowserPool {
+	name := key.String()
 	pl := l.Sugar().With(zap.String("pool", name))
 	pl.Infof("starting pool: maxIdle=%d, maxAge=%v, idleTimeout=%v", cfg.MaxIdle(), cfg.MaxAge(), cfg.IdleTimeout())
 	return &IdleBrowserPool{
+		key:         key,
 		name:        name,
 		idleWd:      make(map[string]*PooledBrowser),
 		mgr:         mgr,
+		hc:          hc,
 		maxIdle:     cfg.MaxIdle(),
 		maxAge:      cfg.MaxAge(),
 		idleTimeout: cfg.IdleTimeout(),
@@ -51,11 +87,34 @@ func (p *IdleBrowserPool) Shutdown(ctx context.Context) error {
 	p.shutdown = true
 
 	p.l.Infof("shutting down the pool, idleCount=%d", len(p.idleWd))
-	var wg sync.WaitGroup
-	done := make(chan struct{})
+	return closeAll(ctx, p.takeIdle())
+}
+
+// Drain closes all idle browsers, browsers which are checked out at the moment will not be returned to the pool
+func (p *IdleBrowserPool) Drain(ctx context.Context) (int, error) {
+	p.m.Lock()
+	p.drained = time.Now()
+	idle := p.takeIdle()
+	p.m.Unlock()
+
+	p.l.Infof("draining the pool, idleCount=%d", len(idle))
+	return len(idle), closeAll(ctx, idle)
+}
+
+func (p *IdleBrowserPool) takeIdle() []*PooledBrowser {
+	res := make([]*PooledBrowser, 0, len(p.idleWd))
 	for id, wd := range p.idleWd {
 		wd.idle.Stop()
 		delete(p.idleWd, id)
+		res = append(res, wd)
+	}
+	return res
+}
+
+func closeAll(ctx context.Context, browsers []*PooledBrowser) error {
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for _, wd := range browsers {
 		wg.Add(1)
 		go func(wd *PooledBrowser) {
 			defer wg.Done()
@@ -80,22 +139,48 @@ func (p *IdleBrowserPool) Checkout(
 	ctx context.Context,
 	protocol models.BrowserProtocol,
 	caps capabilities.Capabilities,
-) (browser.Browser, error) {
-	wd, err := p.popIdle()
-	if err != nil {
-		return nil, err
-	}
+) (br browser.Browser, err error) {
+	ctx, span := tracing.Start(ctx, "pool.checkout", tracing.PoolHitKey.Bool(false))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	for {
+		wd, err := p.popIdle()
+		if err != nil {
+			return nil, err
+		}
+		if wd == nil {
+			break
+		}
 
-	if wd != nil {
+		if err := p.checkHealth(ctx, protocol, wd); err != nil {
+			if ctx.Err() != nil {
+				// browser might be healthy, it's the caller who gave up
+				p.checkin(wd)
+				return nil, ctx.Err()
+			}
+			p.stats.healthCheckFailures.Add(1)
+			p.l.With(zap.String("browser_id", wd.id), zap.String("url", wd.br.GetURL().String())).
+				Warnw("dropping unhealthy browser", zap.Error(err))
+			wd.br.Close(context.Background(), true)
+			continue
+		}
+
+		p.stats.hits.Add(1)
+		span.SetAttributes(tracing.PoolHitKey.Bool(true))
+		browser.ContextAllocationTrace(ctx).OnPoolCheckout(true)
 		return wd, nil
 	}
 
-	wd, err = p.mgr.Allocate(ctx, protocol, caps)
+	p.stats.misses.Add(1)
+	browser.ContextAllocationTrace(ctx).OnPoolCheckout(false)
+	br, err = p.mgr.Allocate(ctx, protocol, caps)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewPooledBrowser(wd, p.checkin), nil
+	return NewPooledBrowser(br, p.checkin), nil
 }
 
 func (p *IdleBrowserPool) PoolState() (int, bool) {
@@ -104,7 +189,51 @@ func (p *IdleBrowserPool) PoolState() (int, bool) {
 	return len(p.idleWd), p.shutdown
 }
 
-func (p *IdleBrowserPool) popIdle() (browser.Browser, error) {
+func (p *IdleBrowserPool) Stats() dto.PoolStats {
+	return dto.PoolStats{
+		Hits:                p.stats.hits.Load(),
+		Misses:              p.stats.misses.Load(),
+		Evictions:           p.stats.evictions.Load(),
+		HealthCheckFailures: p.stats.healthCheckFailures.Load(),
+	}
+}
+
+func (p *IdleBrowserPool) Info() dto.Pool {
+	p.m.RLock()
+	now := time.Now()
+	idle := make([]dto.IdleBrowser, 0, len(p.idleWd))
+	for _, wd := range p.idleWd {
+		idle = append(idle, dto.IdleBrowser{
+			ID:       wd.id,
+			URL:      wd.br.GetURL().String(),
+			Age:      now.Sub(*wd.tm).Round(time.Millisecond).String(),
+			IdleTime: now.Sub(wd.idleSince).Round(time.Millisecond).String(),
+		})
+	}
+	p.m.RUnlock()
+
+	sort.Slice(idle, func(i, j int) bool {
+		return idle[i].ID < idle[j].ID
+	})
+	return dto.Pool{
+		Name:      p.name,
+		Protocol:  string(p.key.Protocol),
+		Browser:   p.key.Browser,
+		CapsHash:  p.key.CapsHash,
+		IdleCount: len(idle),
+		Idle:      idle,
+		Stats:     p.Stats(),
+	}
+}
+
+func (p *IdleBrowserPool) checkHealth(ctx context.Context, protocol models.BrowserProtocol, wd *PooledBrowser) error {
+	if p.hc == nil {
+		return nil
+	}
+	return p.hc.Check(ctx, protocol, wd.br)
+}
+
+func (p *IdleBrowserPool) popIdle() (*PooledBrowser, error) {
 	p.m.Lock()
 	defer p.m.Unlock()
 	if p.shutdown {
@@ -127,7 +256,15 @@ func (p *IdleBrowserPool) checkin(wd *PooledBrowser) {
 		return
 	}
 
+	if p.isDrainedAfter(*wd.tm) {
+		p.l.With(zap.String("browser_id", wd.id), zap.String("url", wd.br.GetURL().String())).
+			Debug("dropping browser created before the pool was drained")
+		wd.br.Close(context.Background(), true)
+		return
+	}
+
 	if age := time.Since(*wd.tm); age > p.maxAge {
+		p.stats.evictions.Add(1)
 		p.l.With(zap.String("browser_id", wd.id), zap.String("url", wd.br.GetURL().String())).
 			Debugf("recycling aged browser, age=%v", age)
 		wd.br.Close(context.Background(), true)
@@ -137,6 +274,12 @@ func (p *IdleBrowserPool) checkin(wd *PooledBrowser) {
 	p.pushIdle(wd)
 }
 
+func (p *IdleBrowserPool) isDrainedAfter(t time.Time) bool {
+	p.m.RLock()
+	defer p.m.RUnlock()
+	return t.Before(p.drained)
+}
+
 func (p *IdleBrowserPool) pushIdle(wd *PooledBrowser) {
 	p.m.Lock()
 	defer p.m.Unlock()
@@ -147,6 +290,7 @@ func (p *IdleBrowserPool) pushIdle(wd *PooledBrowser) {
 		timeout = p.idleTimeout
 	}
 
+	wd.idleSince = time.Now()
 	wd.idle = time.AfterFunc(timeout, func() {
 		p.evictIdle(wd)
 	})
@@ -159,6 +303,7 @@ func (p *IdleBrowserPool) evictIdle(wd *PooledBrowser) {
 	if _, ok := p.idleWd[wd.id]; ok {
 		delete(p.idleWd, wd.id)
 		p.m.Unlock()
+		p.stats.evictions.Add(1)
 		p.l.Debugw("evicting browser", zap.String("browser_id", wd.id), zap.String("url", wd.br.GetURL().String()))
 		wd.br.Close(context.Background(), true)
 	} else {
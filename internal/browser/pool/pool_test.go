@@ -8,12 +8,16 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap/zaptest"
 
 	"github.com/selebrow/selebrow/internal/browser/pool"
 	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/dto"
 )
 
+var testPoolKey = pool.PoolKey{Protocol: testBrowserProtocol, Browser: "mosaic", CapsHash: "abc"}
+
 func TestIdleBrowserPool_CheckoutReuse(t *testing.T) {
 	g := NewWithT(t)
 
@@ -23,7 +27,7 @@ func TestIdleBrowserPool_CheckoutReuse(t *testing.T) {
 	cfg.EXPECT().IdleTimeout().Return(50 * time.Millisecond)
 	cfg.EXPECT().MaxAge().Return(2 * time.Second)
 	cfg.EXPECT().MaxIdle().Return(1)
-	p := pool.NewIdleBrowserPool("abc", mgr, cfg, zaptest.NewLogger(t))
+	p := pool.NewIdleBrowserPool(testPoolKey, mgr, nil, cfg, zaptest.NewLogger(t))
 
 	caps := new(mocks.Capabilities)
 
@@ -32,7 +36,7 @@ func TestIdleBrowserPool_CheckoutReuse(t *testing.T) {
 	br1 := new(mocks.Browser)
 	br1.EXPECT().GetURL().Return(u1)
 
-	mgr.EXPECT().Allocate(context.TODO(), testBrowserProtocol, caps).Return(br1, nil).Once()
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br1, nil).Once()
 	got1, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(got1.GetURL()).To(Equal(u1))
@@ -55,11 +59,11 @@ func TestIdleBrowserPool_CheckoutError(t *testing.T) {
 	cfg.EXPECT().IdleTimeout().Return(50 * time.Millisecond)
 	cfg.EXPECT().MaxAge().Return(2 * time.Second)
 	cfg.EXPECT().MaxIdle().Return(1)
-	p := pool.NewIdleBrowserPool("abc", mgr, cfg, zaptest.NewLogger(t))
+	p := pool.NewIdleBrowserPool(testPoolKey, mgr, nil, cfg, zaptest.NewLogger(t))
 
 	caps := new(mocks.Capabilities)
 
-	mgr.EXPECT().Allocate(context.TODO(), testBrowserProtocol, caps).Return(nil, errors.New("fake error")).Once()
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(nil, errors.New("fake error")).Once()
 	_, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).To(MatchError("fake error"))
 	size, _ := p.PoolState()
@@ -77,7 +81,7 @@ func TestIdleBrowserPool_CheckoutEvictIdle(t *testing.T) {
 	cfg.EXPECT().IdleTimeout().Return(50 * time.Millisecond)
 	cfg.EXPECT().MaxAge().Return(2 * time.Second)
 	cfg.EXPECT().MaxIdle().Return(1)
-	p := pool.NewIdleBrowserPool("abc", mgr, cfg, zaptest.NewLogger(t))
+	p := pool.NewIdleBrowserPool(testPoolKey, mgr, nil, cfg, zaptest.NewLogger(t))
 
 	caps := new(mocks.Capabilities)
 
@@ -86,7 +90,7 @@ func TestIdleBrowserPool_CheckoutEvictIdle(t *testing.T) {
 	br1 := new(mocks.Browser)
 	br1.EXPECT().GetURL().Return(u1)
 
-	mgr.EXPECT().Allocate(context.TODO(), testBrowserProtocol, caps).Return(br1, nil).Once()
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br1, nil).Once()
 	got1, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(got1.GetURL()).To(Equal(u1))
@@ -113,7 +117,7 @@ func TestIdleBrowserPool_CheckoutMaxIdle(t *testing.T) {
 	cfg.EXPECT().IdleTimeout().Return(1 * time.Second)
 	cfg.EXPECT().MaxAge().Return(2 * time.Second)
 	cfg.EXPECT().MaxIdle().Return(1)
-	p := pool.NewIdleBrowserPool("abc", mgr, cfg, zaptest.NewLogger(t))
+	p := pool.NewIdleBrowserPool(testPoolKey, mgr, nil, cfg, zaptest.NewLogger(t))
 
 	caps := new(mocks.Capabilities)
 
@@ -122,7 +126,7 @@ func TestIdleBrowserPool_CheckoutMaxIdle(t *testing.T) {
 	br1 := new(mocks.Browser)
 	br1.EXPECT().GetURL().Return(u1)
 
-	mgr.EXPECT().Allocate(context.TODO(), testBrowserProtocol, caps).Return(br1, nil).Once()
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br1, nil).Once()
 	got1, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(got1.GetURL()).To(Equal(u1))
@@ -132,7 +136,7 @@ func TestIdleBrowserPool_CheckoutMaxIdle(t *testing.T) {
 	br2 := new(mocks.Browser)
 	br2.EXPECT().GetURL().Return(u2)
 
-	mgr.EXPECT().Allocate(context.TODO(), testBrowserProtocol, caps).Return(br2, nil).Once()
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br2, nil).Once()
 	got2, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(got2.GetURL()).To(Equal(u2))
@@ -164,7 +168,7 @@ func TestIdleBrowserPool_CheckoutMaxAge(t *testing.T) {
 	cfg.EXPECT().IdleTimeout().Return(2 * time.Second)
 	cfg.EXPECT().MaxAge().Return(50 * time.Millisecond)
 	cfg.EXPECT().MaxIdle().Return(1)
-	p := pool.NewIdleBrowserPool("abc", mgr, cfg, zaptest.NewLogger(t))
+	p := pool.NewIdleBrowserPool(testPoolKey, mgr, nil, cfg, zaptest.NewLogger(t))
 
 	caps := new(mocks.Capabilities)
 
@@ -173,7 +177,7 @@ func TestIdleBrowserPool_CheckoutMaxAge(t *testing.T) {
 	br1 := new(mocks.Browser)
 	br1.EXPECT().GetURL().Return(u1)
 
-	mgr.EXPECT().Allocate(context.TODO(), testBrowserProtocol, caps).Return(br1, nil).Once()
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br1, nil).Once()
 	got1, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(got1.GetURL()).To(Equal(u1))
@@ -183,7 +187,7 @@ func TestIdleBrowserPool_CheckoutMaxAge(t *testing.T) {
 	br2 := new(mocks.Browser)
 	br2.EXPECT().GetURL().Return(u2)
 
-	mgr.EXPECT().Allocate(context.TODO(), testBrowserProtocol, caps).Return(br2, nil).Once()
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br2, nil).Once()
 	got2, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(got2.GetURL()).To(Equal(u2))
@@ -216,7 +220,7 @@ func TestIdleBrowserPool_Shutdown(t *testing.T) {
 	cfg.EXPECT().IdleTimeout().Return(1 * time.Second)
 	cfg.EXPECT().MaxAge().Return(1 * time.Second)
 	cfg.EXPECT().MaxIdle().Return(1)
-	p := pool.NewIdleBrowserPool("abc", mgr, cfg, zaptest.NewLogger(t))
+	p := pool.NewIdleBrowserPool(testPoolKey, mgr, nil, cfg, zaptest.NewLogger(t))
 
 	caps := new(mocks.Capabilities)
 
@@ -225,7 +229,7 @@ func TestIdleBrowserPool_Shutdown(t *testing.T) {
 	br1 := new(mocks.Browser)
 	br1.EXPECT().GetURL().Return(u1)
 
-	mgr.EXPECT().Allocate(context.TODO(), testBrowserProtocol, caps).Return(br1, nil).Once()
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br1, nil).Once()
 	got1, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(got1.GetURL()).To(Equal(u1))
@@ -245,3 +249,156 @@ func TestIdleBrowserPool_Shutdown(t *testing.T) {
 	_, err = p.Checkout(context.TODO(), testBrowserProtocol, caps)
 	g.Expect(err).To(MatchError(MatchRegexp(`.*shutdown.*`)))
 }
+
+func TestIdleBrowserPool_CheckoutHealthCheck(t *testing.T) {
+	g := NewWithT(t)
+
+	mgr := new(mocks.BrowserManager)
+	cfg := new(mocks.PoolConfig)
+	hc := mocks.NewHealthChecker(t)
+
+	cfg.EXPECT().IdleTimeout().Return(1 * time.Second)
+	cfg.EXPECT().MaxAge().Return(2 * time.Second)
+	cfg.EXPECT().MaxIdle().Return(2)
+	p := pool.NewIdleBrowserPool(testPoolKey, mgr, hc, cfg, zaptest.NewLogger(t))
+
+	caps := new(mocks.Capabilities)
+
+	u1, err := url.Parse("http://host1")
+	g.Expect(err).ToNot(HaveOccurred())
+	br1 := new(mocks.Browser)
+	br1.EXPECT().GetURL().Return(u1)
+
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br1, nil).Once()
+	got1, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
+	g.Expect(err).ToNot(HaveOccurred())
+	got1.Close(context.TODO(), false)
+
+	// healthy browser is reused
+	hc.EXPECT().Check(mock.Anything, testBrowserProtocol, br1).Return(nil).Once()
+	got2, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got2.GetURL()).To(Equal(u1))
+	got2.Close(context.TODO(), false)
+
+	// dead browser is trashed and a new one is allocated instead
+	u2, err := url.Parse("http://host2")
+	g.Expect(err).ToNot(HaveOccurred())
+	br2 := new(mocks.Browser)
+	br2.EXPECT().GetURL().Return(u2)
+
+	hc.EXPECT().Check(mock.Anything, testBrowserProtocol, br1).Return(errors.New("connection refused")).Once()
+	br1.EXPECT().Close(context.Background(), true).Once()
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br2, nil).Once()
+	got3, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(got3.GetURL()).To(Equal(u2))
+	br1.AssertExpectations(t)
+
+	g.Expect(p.Stats()).To(Equal(dto.PoolStats{
+		Hits:                1,
+		Misses:              2,
+		HealthCheckFailures: 1,
+	}))
+
+	g.Expect(p.Shutdown(context.TODO())).To(Succeed())
+}
+
+func TestIdleBrowserPool_CheckoutHealthCheckCancelled(t *testing.T) {
+	g := NewWithT(t)
+
+	mgr := new(mocks.BrowserManager)
+	cfg := new(mocks.PoolConfig)
+	hc := mocks.NewHealthChecker(t)
+
+	cfg.EXPECT().IdleTimeout().Return(1 * time.Second)
+	cfg.EXPECT().MaxAge().Return(2 * time.Second)
+	cfg.EXPECT().MaxIdle().Return(1)
+	p := pool.NewIdleBrowserPool(testPoolKey, mgr, hc, cfg, zaptest.NewLogger(t))
+
+	caps := new(mocks.Capabilities)
+
+	u1, err := url.Parse("http://host1")
+	g.Expect(err).ToNot(HaveOccurred())
+	br1 := new(mocks.Browser)
+	br1.EXPECT().GetURL().Return(u1)
+
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br1, nil).Once()
+	got1, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
+	g.Expect(err).ToNot(HaveOccurred())
+	got1.Close(context.TODO(), false)
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	hc.EXPECT().Check(mock.Anything, testBrowserProtocol, br1).Return(context.Canceled).Once()
+	_, err = p.Checkout(ctx, testBrowserProtocol, caps)
+	g.Expect(err).To(MatchError(context.Canceled))
+
+	// browser should have been returned to the pool
+	size, _ := p.PoolState()
+	g.Expect(size).To(Equal(1))
+	g.Expect(p.Stats().HealthCheckFailures).To(BeZero())
+
+	br1.EXPECT().Close(context.TODO(), true).Once()
+	g.Expect(p.Shutdown(context.TODO())).To(Succeed())
+}
+
+func TestIdleBrowserPool_InfoAndDrain(t *testing.T) {
+	g := NewWithT(t)
+
+	mgr := new(mocks.BrowserManager)
+	cfg := new(mocks.PoolConfig)
+
+	cfg.EXPECT().IdleTimeout().Return(1 * time.Second)
+	cfg.EXPECT().MaxAge().Return(2 * time.Second)
+	cfg.EXPECT().MaxIdle().Return(2)
+	p := pool.NewIdleBrowserPool(testPoolKey, mgr, nil, cfg, zaptest.NewLogger(t))
+
+	caps := new(mocks.Capabilities)
+
+	u1, err := url.Parse("http://host1")
+	g.Expect(err).ToNot(HaveOccurred())
+	br1 := new(mocks.Browser)
+	br1.EXPECT().GetURL().Return(u1)
+
+	u2, err := url.Parse("http://host2")
+	g.Expect(err).ToNot(HaveOccurred())
+	br2 := new(mocks.Browser)
+	br2.EXPECT().GetURL().Return(u2)
+
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br1, nil).Once()
+	got1, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
+	g.Expect(err).ToNot(HaveOccurred())
+	mgr.EXPECT().Allocate(mock.Anything, testBrowserProtocol, caps).Return(br2, nil).Once()
+	got2, err := p.Checkout(context.TODO(), testBrowserProtocol, caps)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got1.Close(context.TODO(), false)
+
+	info := p.Info()
+	g.Expect(info.Name).To(Equal("test-mosaic-abc"))
+	g.Expect(info.Protocol).To(Equal("test"))
+	g.Expect(info.Browser).To(Equal("mosaic"))
+	g.Expect(info.CapsHash).To(Equal("abc"))
+	g.Expect(info.IdleCount).To(Equal(1))
+	g.Expect(info.Idle).To(HaveLen(1))
+	g.Expect(info.Idle[0].URL).To(Equal("http://host1"))
+	g.Expect(info.Stats).To(Equal(dto.PoolStats{Misses: 2}))
+
+	br1.EXPECT().Close(context.TODO(), true).Once()
+	n, err := p.Drain(context.TODO())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(n).To(Equal(1))
+	br1.AssertExpectations(t)
+
+	// browser checked out before drain should not return to the pool
+	br2.EXPECT().Close(context.Background(), true).Once()
+	got2.Close(context.TODO(), false)
+	br2.AssertExpectations(t)
+
+	size, shutdown := p.PoolState()
+	g.Expect(size).To(BeZero())
+	g.Expect(shutdown).To(BeFalse())
+
+	g.Expect(p.Shutdown(context.TODO())).To(Succeed())
+}
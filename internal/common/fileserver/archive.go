@@ -0,0 +1,111 @@
+package fileserver
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type ArchiveFormat string
+
+const (
+	ArchiveZip   ArchiveFormat = "zip"
+	ArchiveTarGz ArchiveFormat = "tar.gz"
+)
+
+func ParseArchiveFormat(s string) (ArchiveFormat, error) {
+	switch f := ArchiveFormat(s); f {
+	case ArchiveZip, ArchiveTarGz:
+		return f, nil
+	default:
+		return "", errors.Errorf("unsupported archive format %s, expected one of: %s, %s", s, ArchiveZip, ArchiveTarGz)
+	}
+}
+
+func (f ArchiveFormat) ContentType() string {
+	if f == ArchiveTarGz {
+		return "application/gzip"
+	}
+	return "application/zip"
+}
+
+// Archive streams downloaded files to w as a single archive
+func (c *Client) Archive(ctx context.Context, baseURL *url.URL, names []string, format ArchiveFormat, w io.Writer) error {
+	if format == ArchiveTarGz {
+		return c.archiveTarGz(ctx, baseURL, names, w)
+	}
+	return c.archiveZip(ctx, baseURL, names, w)
+}
+
+func (c *Client) archiveZip(ctx context.Context, baseURL *url.URL, names []string, w io.Writer) error {
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		err := c.copyFile(ctx, baseURL, name, func(rc io.Reader, _ int64) error {
+			fw, err := zw.Create(name)
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(fw, rc)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func (c *Client) archiveTarGz(ctx context.Context, baseURL *url.URL, names []string, w io.Writer) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	now := time.Now()
+	for _, name := range names {
+		err := c.copyFile(ctx, baseURL, name, func(r io.Reader, size int64) error {
+			// tar header requires file size upfront
+			if size < 0 {
+				b, err := io.ReadAll(r)
+				if err != nil {
+					return err
+				}
+				r, size = bytes.NewReader(b), int64(len(b))
+			}
+			if err := tw.WriteHeader(&tar.Header{
+				Name:    name,
+				Mode:    0o644,
+				Size:    size,
+				ModTime: now,
+			}); err != nil {
+				return err
+			}
+			_, err := io.Copy(tw, r)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+// copyFile passes downloaded file to fn, files removed since listing are skipped
+func (c *Client) copyFile(ctx context.Context, baseURL *url.URL, name string, fn func(r io.Reader, size int64) error) error {
+	rc, size, err := c.Open(ctx, baseURL, name)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil
+		}
+		return err
+	}
+	defer rc.Close()
+	return errors.Wrapf(fn(rc, size), "failed to archive %s", name)
+}
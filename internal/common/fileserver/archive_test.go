@@ -0,0 +1,83 @@
+package fileserver
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParseArchiveFormat(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ParseArchiveFormat("zip")).To(Equal(ArchiveZip))
+	g.Expect(ParseArchiveFormat("tar.gz")).To(Equal(ArchiveTarGz))
+	_, err := ParseArchiveFormat("rar")
+	g.Expect(err).To(MatchError("unsupported archive format rar, expected one of: zip, tar.gz"))
+
+	g.Expect(ArchiveZip.ContentType()).To(Equal("application/zip"))
+	g.Expect(ArchiveTarGz.ContentType()).To(Equal("application/gzip"))
+}
+
+func TestClient_Archive(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/a.txt":
+			_, _ = w.Write([]byte("hello"))
+		case "/b.txt":
+			_, _ = w.Write([]byte("world"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	baseURL, err := url.Parse(srv.URL)
+	g.Expect(err).ToNot(HaveOccurred())
+	c := NewClient(srv.Client())
+	names := []string{"a.txt", "removed.txt", "b.txt"}
+	expected := map[string]string{"a.txt": "hello", "b.txt": "world"}
+
+	var buf bytes.Buffer
+	g.Expect(c.Archive(context.Background(), baseURL, names, ArchiveZip, &buf)).To(Succeed())
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	g.Expect(err).ToNot(HaveOccurred())
+	files := make(map[string]string)
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		g.Expect(err).ToNot(HaveOccurred())
+		b, err := io.ReadAll(rc)
+		g.Expect(err).ToNot(HaveOccurred())
+		files[f.Name] = string(b)
+	}
+	g.Expect(files).To(Equal(expected))
+
+	buf.Reset()
+	g.Expect(c.Archive(context.Background(), baseURL, names, ArchiveTarGz, &buf)).To(Succeed())
+	gr, err := gzip.NewReader(&buf)
+	g.Expect(err).ToNot(HaveOccurred())
+	tr := tar.NewReader(gr)
+	files = make(map[string]string)
+	for {
+		h, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		g.Expect(err).ToNot(HaveOccurred())
+		b, err := io.ReadAll(tr)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(h.Size).To(BeEquivalentTo(len(b)))
+		files[h.Name] = string(b)
+	}
+	g.Expect(files).To(Equal(expected))
+}
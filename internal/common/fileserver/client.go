@@ -0,0 +1,111 @@
+package fileserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/common/client"
+)
+
+var ErrNotFound = errors.New("file not found")
+
+// Client talks to the fileserver running in the browser container, which serves browser downloads directory
+type Client struct {
+	hc client.HTTPClient
+}
+
+func NewClient(hc client.HTTPClient) *Client {
+	return &Client{hc: hc}
+}
+
+// List returns names of the downloaded files
+func (c *Client) List(ctx context.Context, baseURL *url.URL) ([]string, error) {
+	u := *baseURL
+	q := make(url.Values)
+	q.Set("json", "true")
+	u.RawQuery = q.Encode()
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.hc.Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(r, resp); err != nil {
+		return nil, err
+	}
+	var res []string
+	err = json.NewDecoder(resp.Body).Decode(&res)
+	return res, err
+}
+
+// Get returns contents of the downloaded file, ErrNotFound is returned when file doesn't exist
+func (c *Client) Get(ctx context.Context, baseURL *url.URL, name string) ([]byte, error) {
+	rc, _, err := c.Open(ctx, baseURL, name)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// Open returns reader of the downloaded file and its size, size is -1 when unknown
+func (c *Client) Open(ctx context.Context, baseURL *url.URL, name string) (io.ReadCloser, int64, error) {
+	u := *baseURL
+	u.Path = path.Join(u.Path, name)
+	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := c.hc.Do(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		_ = resp.Body.Close()
+		return nil, 0, errors.Wrap(ErrNotFound, name)
+	}
+	if err := checkResponse(r, resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, 0, err
+	}
+	return resp.Body, resp.ContentLength, nil
+}
+
+// Delete removes the downloaded file, missing files are ignored
+func (c *Client) Delete(ctx context.Context, baseURL *url.URL, name string) error {
+	u := *baseURL
+	u.Path = path.Join(u.Path, name)
+	r, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := c.hc.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	return checkResponse(r, resp)
+}
+
+func checkResponse(r *http.Request, resp *http.Response) error {
+	if resp.StatusCode/100 == 2 {
+		return nil
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrapf(err, "%s %s failed with code %d", r.Method, r.URL.String(), resp.StatusCode)
+	}
+	return errors.Errorf("%s %s failed with code %d: %s", r.Method, r.URL.String(), resp.StatusCode, string(b))
+}
@@ -0,0 +1,50 @@
+package fileserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestClient(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleted []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/" && r.URL.Query().Get("json") == "true":
+			_, _ = w.Write([]byte(`["a.txt","b.txt"]`))
+		case r.Method == http.MethodGet && r.URL.Path == "/a.txt":
+			_, _ = w.Write([]byte("hello"))
+		case r.Method == http.MethodDelete && r.URL.Path == "/a.txt":
+			deleted = append(deleted, r.URL.Path)
+		case r.Method == http.MethodDelete && r.URL.Path == "/broken.txt":
+			http.Error(w, "permission denied", http.StatusForbidden)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	baseURL, err := url.Parse(srv.URL)
+	g.Expect(err).ToNot(HaveOccurred())
+	c := NewClient(srv.Client())
+	ctx := context.Background()
+
+	g.Expect(c.List(ctx, baseURL)).To(Equal([]string{"a.txt", "b.txt"}))
+	g.Expect(c.Get(ctx, baseURL, "a.txt")).To(Equal([]byte("hello")))
+
+	_, err = c.Get(ctx, baseURL, "c.txt")
+	g.Expect(err).To(MatchError(ErrNotFound))
+
+	g.Expect(c.Delete(ctx, baseURL, "a.txt")).To(Succeed())
+	g.Expect(c.Delete(ctx, baseURL, "c.txt")).To(Succeed())
+	g.Expect(deleted).To(Equal([]string{"/a.txt"}))
+
+	err = c.Delete(ctx, baseURL, "broken.txt")
+	g.Expect(err).To(MatchError(ContainSubstring("failed with code 403: permission denied")))
+}
@@ -13,7 +13,8 @@ import (
 )
 
 type WSProxy interface {
-	Handler(hostport string) websocket.Handler
+	// Handler returns handler proxying websocket to hostport, touch (if not nil) is called on traffic in either direction
+	Handler(hostport string, touch func()) websocket.Handler
 }
 
 type WSProxyImpl struct {
@@ -28,7 +29,7 @@ func NewWSProxyImpl(connfactory conn.ConnFactory, l *zap.Logger) *WSProxyImpl {
 	}
 }
 
-func (w *WSProxyImpl) Handler(hostport string) websocket.Handler {
+func (w *WSProxyImpl) Handler(hostport string, touch func()) websocket.Handler {
 	return func(wsconn *websocket.Conn) {
 		cn, err := w.connfactory.GetConn(wsconn.Request().Context(), hostport)
 		if err != nil {
@@ -42,7 +43,7 @@ func (w *WSProxyImpl) Handler(hostport string) websocket.Handler {
 		go func() {
 			defer wg.Done()
 			defer wsconn.Close()
-			_, err = io.Copy(wsconn, cn)
+			_, err = io.Copy(touchWriter{Writer: wsconn, touch: touch}, cn)
 			if logCopyErr(err) {
 				w.l.Errorw("WS Proxy error", zap.Error(err))
 			}
@@ -52,7 +53,7 @@ func (w *WSProxyImpl) Handler(hostport string) websocket.Handler {
 		go func() {
 			defer wg.Done()
 			defer cn.Close()
-			_, err = io.Copy(cn, wsconn)
+			_, err = io.Copy(touchWriter{Writer: cn, touch: touch}, wsconn)
 			if logCopyErr(err) {
 				w.l.Errorw("WS Proxy error", zap.Error(err))
 			}
@@ -61,6 +62,19 @@ func (w *WSProxyImpl) Handler(hostport string) websocket.Handler {
 	}
 }
 
+type touchWriter struct {
+	io.Writer
+	touch func()
+}
+
+func (t touchWriter) Write(p []byte) (int, error) {
+	n, err := t.Writer.Write(p)
+	if n > 0 && t.touch != nil {
+		t.touch()
+	}
+	return n, err
+}
+
 func logCopyErr(err error) bool {
 	// net.ErrClosed is valid case when client closes connection, io.ErrClosedPipe - same in tests we have to exclude it
 	// to avoid race condition with goroutine using logger when actual test is complete
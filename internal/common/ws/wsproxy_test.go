@@ -3,6 +3,7 @@ package ws
 import (
 	"net"
 	"net/http"
+	"sync/atomic"
 	"testing"
 
 	"github.com/gorilla/websocket"
@@ -19,7 +20,8 @@ func TestWSProxyImpl_Handler_Serve(t *testing.T) {
 	g := NewWithT(t)
 	cf := new(mocks.ConnFactory)
 	p := NewWSProxyImpl(cf, zaptest.NewLogger(t))
-	h := p.Handler("wshost:1234")
+	var touched atomic.Int32
+	h := p.Handler("wshost:1234", func() { touched.Add(1) })
 	d := wstest.NewDialer(h)
 
 	clconn, srvconn := net.Pipe()
@@ -41,6 +43,7 @@ func TestWSProxyImpl_Handler_Serve(t *testing.T) {
 	_, err = srvconn.Read(got)
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(string(got)).To(Equal(test))
+	g.Eventually(touched.Load).Should(BeNumerically(">", 0))
 
 	ch := make(chan struct{})
 	wc.SetCloseHandler(func(code int, text string) error {
@@ -58,7 +61,7 @@ func TestWSProxyImpl_Handler_Serve_ConnError(t *testing.T) {
 	g := NewWithT(t)
 	cf := new(mocks.ConnFactory)
 	p := NewWSProxyImpl(cf, zaptest.NewLogger(t))
-	h := p.Handler("wshost:1234")
+	h := p.Handler("wshost:1234", nil)
 	d := wstest.NewDialer(h)
 
 	cf.EXPECT().GetConn(mock.Anything, "wshost:1234").Return(nil, errors.New("test conn error"))
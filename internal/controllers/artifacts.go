@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/artifacts"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+type ArtifactsController struct {
+	store artifacts.ArtifactStore
+}
+
+func NewArtifactsController(store artifacts.ArtifactStore) *ArtifactsController {
+	return &ArtifactsController{store: store}
+}
+
+func (a *ArtifactsController) Screenshots(c echo.Context) error {
+	res, err := a.store.ListScreenshots()
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+func (a *ArtifactsController) Screenshot(c echo.Context) error {
+	id := c.Param(router.SessionParam)
+	sc, err := a.store.Screenshot(id)
+	if err != nil {
+		if errors.Is(err, artifacts.ErrNotFound) {
+			return models.NewNotFoundError(errors.Errorf("screenshot of session %s is not found", id))
+		}
+		return err
+	}
+	return c.File(sc.Path)
+}
+
+// DownloadsArchive returns zip archive of the files downloaded by the deleted session
+func (a *ArtifactsController) DownloadsArchive(c echo.Context) error {
+	id := c.Param(router.SessionParam)
+	p, err := a.store.DownloadsArchive(id)
+	if err != nil {
+		if errors.Is(err, artifacts.ErrNotFound) {
+			return models.NewNotFoundError(errors.Errorf("downloads archive of session %s is not found", id))
+		}
+		return err
+	}
+	return c.Attachment(p, id+"-downloads.zip")
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/artifacts"
+	"github.com/selebrow/selebrow/mocks"
+)
+
+func TestArtifactsController_Screenshots(t *testing.T) {
+	g := NewWithT(t)
+	store := mocks.NewArtifactStore(t)
+	cntr := NewArtifactsController(store)
+
+	store.EXPECT().ListScreenshots().Return([]artifacts.Screenshot{
+		{SessionID: "s1", TestName: "test1", Created: time.UnixMilli(1000).UTC(), Path: "/tmp/s1/test1.png"},
+	}, nil).Once()
+
+	c, rec := getSessionContext(router.ScreenshotsPath, "/screenshots", "")
+	g.Expect(cntr.Screenshots(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(`[{"sessionId":"s1","testName":"test1","created":"1970-01-01T00:00:01Z"}]`))
+}
+
+func TestArtifactsController_Screenshot(t *testing.T) {
+	g := NewWithT(t)
+	store := mocks.NewArtifactStore(t)
+	cntr := NewArtifactsController(store)
+
+	p := filepath.Join(t.TempDir(), "screenshot.png")
+	g.Expect(os.WriteFile(p, []byte("\x89PNG\r\n\x1a\n"), 0o644)).To(Succeed())
+	store.EXPECT().Screenshot("s1").Return(&artifacts.Screenshot{SessionID: "s1", Path: p}, nil).Once()
+
+	c, rec := getSessionContext(router.SessRoute(router.ScreenshotsPath+"/:%s"), "/screenshots/s1", "s1")
+	g.Expect(cntr.Screenshot(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Header().Get("Content-Type")).To(Equal("image/png"))
+	g.Expect(rec.Body.String()).To(Equal("\x89PNG\r\n\x1a\n"))
+}
+
+func TestArtifactsController_ScreenshotNotFound(t *testing.T) {
+	g := NewWithT(t)
+	store := mocks.NewArtifactStore(t)
+	cntr := NewArtifactsController(store)
+
+	store.EXPECT().Screenshot("s1").Return(nil, artifacts.ErrNotFound).Once()
+	c, _ := getSessionContext(router.SessRoute(router.ScreenshotsPath+"/:%s"), "/screenshots/s1", "s1")
+	err := cntr.Screenshot(c)
+	g.Expect(err).To(MatchError("screenshot of session s1 is not found"))
+	e, ok := unwrapErrorWithCode(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusNotFound))
+
+	store.EXPECT().Screenshot("s1").Return(nil, errors.New("read error")).Once()
+	g.Expect(cntr.Screenshot(c)).To(MatchError("read error"))
+}
+
+func TestArtifactsController_DownloadsArchive(t *testing.T) {
+	g := NewWithT(t)
+	store := mocks.NewArtifactStore(t)
+	cntr := NewArtifactsController(store)
+
+	p := filepath.Join(t.TempDir(), "downloads.zip")
+	g.Expect(os.WriteFile(p, []byte("zip"), 0o644)).To(Succeed())
+	store.EXPECT().DownloadsArchive("s1").Return(p, nil).Once()
+
+	c, rec := getSessionContext(router.SessRoute(router.ArchivesPath+"/:%s"), "/archives/s1", "s1")
+	g.Expect(cntr.DownloadsArchive(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Header().Get(echo.HeaderContentDisposition)).To(Equal(`attachment; filename="s1-downloads.zip"`))
+	g.Expect(rec.Body.String()).To(Equal("zip"))
+
+	store.EXPECT().DownloadsArchive("s2").Return("", artifacts.ErrNotFound).Once()
+	c, _ = getSessionContext(router.SessRoute(router.ArchivesPath+"/:%s"), "/archives/s2", "s2")
+	err := cntr.DownloadsArchive(c)
+	g.Expect(err).To(MatchError("downloads archive of session s2 is not found"))
+	e, ok := unwrapErrorWithCode(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusNotFound))
+}
@@ -1,7 +1,11 @@
 package controllers
 
 import (
+	"fmt"
+	"maps"
 	"net/http"
+	"slices"
+	"strings"
 
 	"github.com/selebrow/selebrow/internal/router"
 	"github.com/selebrow/selebrow/pkg/browsers"
@@ -30,3 +34,104 @@ func (b *BrowsersCatalogController) Browsers(c echo.Context) error {
 	}
 	return c.JSON(http.StatusOK, br)
 }
+
+const maxSuggestions = 3
+
+// validateBrowser checks requested browser against the catalog before any resources are reserved,
+// error lists available browsers, versions or flavors suggesting the closest ones
+func validateBrowser(cat browsers.BrowsersCatalog, protocol models.BrowserProtocol, name, version, flavor string) error {
+	if flavor != "" {
+		flavors := cat.GetFlavors(protocol)
+		if !slices.Contains(flavors, flavor) {
+			return notSupportedError("flavor", flavor, flavors)
+		}
+	}
+
+	ic, ok := cat.LookupBrowserImage(protocol, name, flavor)
+	if !ok {
+		var names []string
+		for _, b := range cat.GetBrowsers(protocol, flavor) {
+			names = append(names, b.Name)
+		}
+		slices.Sort(names)
+		return notSupportedError(fmt.Sprintf("%s browser", protocol), name, names)
+	}
+
+	// default version is used when omitted
+	if version == "" {
+		return nil
+	}
+	if _, ok := ic.GetTag(version); !ok {
+		versions := slices.SortedFunc(maps.Keys(ic.VersionTags), func(a, b string) int {
+			return compareVersions(b, a)
+		})
+		return notSupportedError(fmt.Sprintf("%s version", name), version, versions)
+	}
+	return nil
+}
+
+func notSupportedError(kind, value string, available []string) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %q is not supported, ", kind, value)
+	if s := closestMatches(value, available); len(s) > 0 {
+		fmt.Fprintf(&sb, "did you mean %s? ", strings.Join(s, " or "))
+	}
+	if len(available) > 0 {
+		fmt.Fprintf(&sb, "available: %s", strings.Join(available, ", "))
+	} else {
+		sb.WriteString("nothing is available")
+	}
+	return errors.New(sb.String())
+}
+
+// closestMatches returns available values closest to the requested one by edit distance,
+// values starting with the requested one are considered closest
+func closestMatches(value string, available []string) []string {
+	type match struct {
+		value string
+		dist  int
+	}
+	var matches []match
+	lower := strings.ToLower(value)
+	for _, a := range available {
+		al := strings.ToLower(a)
+		d := editDistance(lower, al)
+		if lower != "" && strings.HasPrefix(al, lower) {
+			d = 0
+		}
+		if d <= max(len(lower), len(al))/2 {
+			matches = append(matches, match{value: a, dist: d})
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return a.dist - b.dist
+	})
+
+	var res []string
+	for i := 0; i < len(matches) && i < maxSuggestions; i++ {
+		res = append(res, fmt.Sprintf("%q", matches[i].value))
+	}
+	return res
+}
+
+// editDistance returns Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(br)]
+}
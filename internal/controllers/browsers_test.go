@@ -10,6 +10,7 @@ import (
 
 	"github.com/selebrow/selebrow/internal/router"
 	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/browsers"
 	"github.com/selebrow/selebrow/pkg/dto"
 	"github.com/selebrow/selebrow/pkg/models"
 )
@@ -104,3 +105,99 @@ func TestBrowsersCatalogController_Browsers_Not_Found(t *testing.T) {
 
 	cat.AssertExpectations(t)
 }
+
+const validateCatalog = `
+webdriver:
+  chrome:
+    images:
+      default:
+        image: webdriver/chrome
+        defaultVersion: "120.0"
+        versionTags:
+          "119.0": "119.0"
+          "120.0": "120.0"
+      cp:
+        image: webdriver/chrome-cp
+        defaultVersion: "120.0"
+        versionTags:
+          "120.0": "120.0"
+  firefox:
+    images:
+      default:
+        image: webdriver/firefox
+        defaultVersion: "121.0"
+        versionTags:
+          "121.0": "121.0"
+`
+
+func TestValidateBrowser(t *testing.T) {
+	tests := []struct {
+		name    string
+		browser string
+		version string
+		flavor  string
+		errMsg  string
+	}{
+		{
+			name:    "Default version",
+			browser: "chrome",
+		},
+		{
+			name:    "Version and flavor",
+			browser: "chrome",
+			version: "120.0",
+			flavor:  "cp",
+		},
+		{
+			name:    "Unknown browser",
+			browser: "chorme",
+			errMsg:  `webdriver browser "chorme" is not supported, did you mean "chrome"? available: chrome, firefox`,
+		},
+		{
+			name:    "Unknown browser without suggestions",
+			browser: "safari",
+			errMsg:  `webdriver browser "safari" is not supported, available: chrome, firefox`,
+		},
+		{
+			name:    "Browser missing in flavor",
+			browser: "firefox",
+			flavor:  "cp",
+			errMsg:  `webdriver browser "firefox" is not supported, available: chrome`,
+		},
+		{
+			name:    "Unknown version",
+			browser: "chrome",
+			version: "12",
+			errMsg:  `chrome version "12" is not supported, did you mean "120.0"? available: 120.0, 119.0`,
+		},
+		{
+			name:    "Unknown flavor",
+			browser: "chrome",
+			flavor:  "CP",
+			errMsg:  `flavor "CP" is not supported, did you mean "cp"? available: cp, default`,
+		},
+	}
+
+	cat, err := browsers.NewYamlBrowsersCatalog([]byte(validateCatalog), "")
+	NewWithT(t).Expect(err).ToNot(HaveOccurred())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateBrowser(cat, models.WebdriverProtocol, tc.browser, tc.version, tc.flavor)
+			if tc.errMsg == "" {
+				g.Expect(err).ToNot(HaveOccurred())
+			} else {
+				g.Expect(err).To(MatchError(tc.errMsg))
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(editDistance("", "")).To(Equal(0))
+	g.Expect(editDistance("chrome", "")).To(Equal(6))
+	g.Expect(editDistance("chorme", "chrome")).To(Equal(2))
+	g.Expect(editDistance("kitten", "sitting")).To(Equal(3))
+}
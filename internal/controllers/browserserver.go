@@ -0,0 +1,305 @@
+package controllers
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/selebrow/selebrow/internal/common/clock"
+	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
+	"github.com/selebrow/selebrow/pkg/models"
+	"github.com/selebrow/selebrow/pkg/tracing"
+)
+
+// genRequestID generates ID correlating session request with events of browser allocation made for it
+var genRequestID = uuid.NewString
+
+// browserServerOptions are container options common for browser server protocols (Playwright and Puppeteer)
+type browserServerOptions struct {
+	Flavor     string
+	Name       string
+	Version    string
+	VNCEnabled bool
+	Resolution string
+	Env        []string
+	Links      []string
+	Hosts      []string
+	Networks   []string
+	Labels     map[string]string
+	Timeout    time.Duration
+}
+
+func newBrowserServerOptions(c echo.Context) browserServerOptions {
+	return browserServerOptions{
+		Flavor:  c.QueryParam(router.FlavorQParam),
+		Name:    c.Param(router.NameParam),
+		Version: c.Param(router.VersionParam),
+	}
+}
+
+// parseHeadless applies vnc and headless query parameters, disabling headless mode enables VNC
+func (o *browserServerOptions) parseHeadless(c echo.Context, headless **bool) error {
+	if vnc := c.QueryParam(PWVncParamQ); vnc != "" {
+		v, err := strconv.ParseBool(vnc)
+		if err != nil {
+			return errors.Wrap(err, "bad vnc parameter")
+		}
+		o.VNCEnabled = v
+		*headless = ref(!v)
+	}
+
+	if h := c.QueryParam(PWHeadlessParamQ); h != "" {
+		v, err := strconv.ParseBool(h)
+		if err != nil {
+			return errors.Wrap(err, "bad headless parameter")
+		}
+		*headless = ref(v)
+	}
+
+	if *headless != nil && !**headless {
+		o.VNCEnabled = true
+	}
+	return nil
+}
+
+// parseContainer applies query parameters configuring browser container
+func (o *browserServerOptions) parseContainer(c echo.Context) error {
+	if res := c.QueryParam(PWResolutionParamQ); res != "" {
+		if !resolutionRegex.MatchString(res) {
+			return errors.New("incorrect resolution parameter format (expected WIDTHxHEIGHTxBPP)")
+		}
+		o.Resolution = res
+	}
+
+	if env := c.QueryParams()[PWEnvParamQ]; len(env) > 0 {
+		if err := validatePlaywrightEnv(env); err != nil {
+			return errors.Wrap(err, "bad environment")
+		}
+		o.Env = append(o.Env, env...)
+	}
+
+	if links := c.QueryParams()[PWLinkParamQ]; len(links) > 0 {
+		o.Links = links
+	}
+
+	if hosts := c.QueryParams()[PWHostParamQ]; len(hosts) > 0 {
+		o.Hosts = hosts
+	}
+
+	if networks := c.QueryParams()[PWNetworkParamQ]; len(networks) > 0 {
+		o.Networks = networks
+	}
+
+	if labels := c.QueryParams()[PWLabelParamQ]; len(labels) > 0 {
+		labelsMap, err := parsePlaywrightLabels(labels)
+		if err != nil {
+			return errors.Wrap(err, "bad label")
+		}
+		o.Labels = labelsMap
+	}
+
+	if timeout := c.QueryParam(PWSessionTimeoutParamQ); timeout != "" {
+		t, err := time.ParseDuration(timeout)
+		if err != nil {
+			return errors.Wrap(err, "bad sessionTimeout parameter")
+		}
+		o.Timeout = t
+	}
+	return nil
+}
+
+func (o *browserServerOptions) capabilities() *models.PWCapabilities {
+	return &models.PWCapabilities{
+		Flavor:           o.Flavor,
+		Browser:          o.Name,
+		Version:          o.Version,
+		VNCEnabled:       o.VNCEnabled,
+		ScreenResolution: o.Resolution,
+		Env:              o.Env,
+		Links:            o.Links,
+		Hosts:            o.Hosts,
+		Networks:         o.Networks,
+		Labels:           o.Labels,
+		Timeout:          o.Timeout,
+	}
+}
+
+// browserServerSessions ties browser server session to the lifetime of the client connection
+type browserServerSessions struct {
+	protocol models.BrowserProtocol
+	svc      session.SessionService
+	eb       event.EventBroker
+	now      clock.NowFunc
+	l        *zap.SugaredLogger
+}
+
+// serve creates session unless options parsing failed with parseErr and passes it to serve func,
+// session is released once serve func returns
+func (b *browserServerSessions) serve(
+	c echo.Context,
+	opts *browserServerOptions,
+	caps *models.PWCapabilities,
+	parseErr error,
+	serve func(sess *session.Session) error,
+) error {
+	ev := evmodels.SessionRequested{
+		Protocol:       b.protocol,
+		RequestID:      genRequestID(),
+		BrowserName:    opts.Name,
+		BrowserVersion: opts.Version,
+		Labels:         opts.Labels,
+	}
+	if parseErr != nil {
+		err := ev.SetError(models.NewBadRequestError(parseErr))
+		b.eb.Publish(evmodels.NewSessionRequestedEvent(ev))
+		return err
+	}
+
+	sess, err := b.createSession(c, ev, caps)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		ev := evmodels.SessionReleased{
+			Protocol:        b.protocol,
+			ID:              sess.ID(),
+			BrowserName:     opts.Name,
+			BrowserVersion:  opts.Version,
+			Labels:          opts.Labels,
+			SessionDuration: b.now().Sub(sess.Created()),
+		}
+		b.eb.Publish(evmodels.NewSessionReleasedEvent(ev))
+		b.svc.DeleteSession(sess)
+	}()
+
+	// XXX We need to use wrapped context here to allow resetting connections from UI
+	// that's a bit awkward, need to think about resetting connections from Browser.Close()
+	c.SetRequest(c.Request().Clone(sess.Context()))
+	return serve(sess)
+}
+
+func (b *browserServerSessions) createSession(
+	c echo.Context,
+	ev evmodels.SessionRequested,
+	caps *models.PWCapabilities,
+) (*session.Session, error) {
+	defer func() {
+		if r := recover(); r != nil {
+			_ = ev.SetError(models.NewInternalServerError(errors.Errorf("panic: %v", r)))
+			b.eb.Publish(evmodels.NewSessionRequestedEvent(ev))
+			panic(r)
+		}
+		b.eb.Publish(evmodels.NewSessionRequestedEvent(ev))
+	}()
+
+	start := b.now()
+	ctx, span := tracing.StartServer(
+		event.WithRequestID(c.Request().Context(), ev.RequestID),
+		fmt.Sprintf("%s.createSession", b.protocol),
+		tracing.BrowserAttributes(b.protocol, caps)...,
+	)
+	sess, err := b.svc.CreateSession(ctx, caps)
+	if err == nil {
+		span.SetAttributes(tracing.SessionIDKey.String(sess.ID()))
+	}
+	tracing.End(span, err)
+	if err != nil {
+		b.l.Errorw(fmt.Sprintf("failed to create %s session", b.protocol), zap.Error(err))
+		return nil, ev.SetError(models.WrapCancelledErr(err))
+	}
+	ev.ID = sess.ID()
+	ev.StartDuration = sess.Created().Sub(start)
+	return sess, nil
+}
+
+// validateSession finds session by id path parameter and stores it in the context
+func (b *browserServerSessions) validateSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id := c.Param(router.SessionParam)
+		if !strings.Contains(c.Path(), "/:"+router.SessionParam) {
+			b.l.Panicf("Middleware applied to the wrong route: %s", c.Path())
+		}
+
+		sess, err := b.svc.FindSession(id)
+		if err != nil {
+			return models.NewNotFoundError(err)
+		}
+
+		c.Set(SessionKey, sess)
+		return next(c)
+	}
+}
+
+// proxyBrowserSession proxies request to the browser server of the session tracking websocket traffic as session activity
+func proxyBrowserSession(
+	c echo.Context,
+	sess *session.Session,
+	transport http.RoundTripper,
+	now clock.NowFunc,
+	l *zap.SugaredLogger,
+	director func(r *http.Request),
+) {
+	(&httputil.ReverseProxy{
+		Transport: transport,
+		Director:  director,
+		ModifyResponse: func(resp *http.Response) error {
+			if resp.StatusCode == http.StatusSwitchingProtocols {
+				if rwc, ok := resp.Body.(io.ReadWriteCloser); ok {
+					resp.Body = &activityConn{
+						ReadWriteCloser: rwc,
+						touch:           func() { sess.SetLastUsed(now()) },
+					}
+				}
+			}
+			return nil
+		},
+		ErrorHandler: browserProxyErrorHandler(l, c.RealIP()),
+	}).ServeHTTP(c.Response(), c.Request())
+}
+
+func browserProxyErrorHandler(l *zap.SugaredLogger, remote string) func(http.ResponseWriter, *http.Request, error) {
+	return func(w http.ResponseWriter, r *http.Request, err error) {
+		msg := fmt.Sprintf("proxy error %s->%v", remote, r.URL)
+		l.Errorw(msg, zap.Error(err))
+		w.WriteHeader(http.StatusBadGateway)
+		//nolint:gosec // plain text error response, no user-controlled HTML
+		if _, respErr := fmt.Fprintf(w, "%s: %v", msg, err); respErr != nil {
+			l.Errorw("write error", zap.Error(respErr))
+		}
+	}
+}
+
+// activityConn tracks traffic of the upgraded websocket connection in both directions
+type activityConn struct {
+	io.ReadWriteCloser
+	touch func()
+}
+
+func (c *activityConn) Read(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Read(p)
+	if n > 0 {
+		c.touch()
+	}
+	return n, err
+}
+
+func (c *activityConn) Write(p []byte) (int, error) {
+	n, err := c.ReadWriteCloser.Write(p)
+	if n > 0 {
+		c.touch()
+	}
+	return n, err
+}
@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/cmdlog"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+const downloadQParam = "download"
+
+type CommandsController struct {
+	svc    session.SessionService
+	cmdLog cmdlog.CommandLog
+}
+
+func NewCommandsController(svc session.SessionService, cmdLog cmdlog.CommandLog) *CommandsController {
+	return &CommandsController{
+		svc:    svc,
+		cmdLog: cmdLog,
+	}
+}
+
+// Commands returns command log of the Webdriver session, log is sent as attachment when download=true
+func (cc *CommandsController) Commands(c echo.Context) error {
+	id := c.Param(router.SessionParam)
+	log, err := getCommandLog(cc.svc, cc.cmdLog, id)
+	if err != nil {
+		return err
+	}
+
+	if download, _ := strconv.ParseBool(c.QueryParam(downloadQParam)); download {
+		c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", id+"-commands.json"))
+	}
+	return c.JSON(http.StatusOK, log)
+}
+
+// getCommandLog returns recorded or persisted log, empty log is returned for running session
+// which has not sent any commands yet
+func getCommandLog(svc session.SessionService, cmdLog cmdlog.CommandLog, id string) (*cmdlog.Log, error) {
+	log, err := cmdLog.Get(id)
+	if err == nil {
+		return log, nil
+	}
+	if !errors.Is(err, cmdlog.ErrNotFound) {
+		return nil, err
+	}
+
+	sess, findErr := svc.FindSession(id)
+	if findErr != nil {
+		return nil, models.NewNotFoundError(errors.Wrapf(err, "session %s", id))
+	}
+	if !cmdLog.Enabled(sess) {
+		return nil, models.NewNotFoundError(errors.Errorf("command log is not enabled for session %s", id))
+	}
+	return &cmdlog.Log{SessionID: id, Entries: []cmdlog.Entry{}}, nil
+}
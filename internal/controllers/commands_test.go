@@ -0,0 +1,101 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/cmdlog"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/mocks"
+)
+
+func TestCommandsController_Commands(t *testing.T) {
+	g := NewWithT(t)
+	svc := mocks.NewSessionService(t)
+	cmdLog := mocks.NewCommandLog(t)
+	cntr := NewCommandsController(svc, cmdLog)
+
+	log := &cmdlog.Log{
+		SessionID: "123",
+		Entries: []cmdlog.Entry{{
+			Time:       time.UnixMilli(1000).UTC(),
+			Method:     http.MethodGet,
+			Path:       "/title",
+			Status:     http.StatusOK,
+			DurationMs: 15,
+		}},
+	}
+	cmdLog.EXPECT().Get("123").Return(log, nil).Twice()
+
+	c, rec := getSessionContext(router.SessRoute(router.CommandsPath+"/:%s"), "/commands/123", "123")
+	g.Expect(cntr.Commands(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Header().Get(echo.HeaderContentDisposition)).To(BeEmpty())
+
+	var got cmdlog.Log
+	g.Expect(json.NewDecoder(rec.Body).Decode(&got)).To(Succeed())
+	g.Expect(&got).To(Equal(log))
+
+	c, rec = getSessionContext(router.SessRoute(router.CommandsPath+"/:%s"), "/commands/123?download=true", "123")
+	g.Expect(cntr.Commands(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Header().Get(echo.HeaderContentDisposition)).To(Equal(`attachment; filename="123-commands.json"`))
+}
+
+func TestCommandsController_CommandsEmpty(t *testing.T) {
+	g := NewWithT(t)
+	svc := mocks.NewSessionService(t)
+	cmdLog := mocks.NewCommandLog(t)
+	cntr := NewCommandsController(svc, cmdLog)
+
+	sess := session.NewSession("123", "LINUX", nil, nil, nil, time.Now(), nil, nil)
+	cmdLog.EXPECT().Get("123").Return(nil, cmdlog.ErrNotFound).Once()
+	svc.EXPECT().FindSession("123").Return(sess, nil).Once()
+	cmdLog.EXPECT().Enabled(sess).Return(true).Once()
+
+	c, rec := getSessionContext(router.SessRoute(router.CommandsPath+"/:%s"), "/commands/123", "123")
+	g.Expect(cntr.Commands(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"sessionId":"123","entries":[]}`))
+}
+
+func TestCommandsController_CommandsNotFound(t *testing.T) {
+	g := NewWithT(t)
+	svc := mocks.NewSessionService(t)
+	cmdLog := mocks.NewCommandLog(t)
+	cntr := NewCommandsController(svc, cmdLog)
+
+	cmdLog.EXPECT().Get("123").Return(nil, cmdlog.ErrNotFound).Once()
+	svc.EXPECT().FindSession("123").Return(nil, errors.New("not found")).Once()
+
+	c, _ := getSessionContext(router.SessRoute(router.CommandsPath+"/:%s"), "/commands/123", "123")
+	err := cntr.Commands(c)
+	g.Expect(err).To(HaveOccurred())
+	e, ok := unwrapErrorWithCode(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusNotFound))
+
+	sess := session.NewSession("123", "LINUX", nil, nil, nil, time.Now(), nil, nil)
+	cmdLog.EXPECT().Get("123").Return(nil, cmdlog.ErrNotFound).Once()
+	svc.EXPECT().FindSession("123").Return(sess, nil).Once()
+	cmdLog.EXPECT().Enabled(sess).Return(false).Once()
+
+	err = cntr.Commands(c)
+	g.Expect(err).To(MatchError(ContainSubstring("command log is not enabled for session 123")))
+	e, ok = unwrapErrorWithCode(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusNotFound))
+
+	cmdLog.EXPECT().Get("123").Return(nil, errors.New("read error")).Once()
+	err = cntr.Commands(c)
+	g.Expect(err).To(MatchError("read error"))
+	_, ok = unwrapErrorWithCode(err)
+	g.Expect(ok).To(BeFalse())
+}
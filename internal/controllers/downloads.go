@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/common/client"
+	"github.com/selebrow/selebrow/internal/common/fileserver"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+const archiveQParam = "archive"
+
+type (
+	namesValue struct {
+		Names []string `json:"names"`
+	}
+
+	namesResponse struct {
+		Value namesValue `json:"value"`
+	}
+
+	fileValue struct {
+		FileName string `json:"filename"`
+		Contents string `json:"contents"`
+	}
+
+	fileResponse struct {
+		Value fileValue `json:"value"`
+	}
+
+	nullResponse struct {
+		Value any `json:"value"`
+	}
+
+	fileRequest struct {
+		Name string `json:"name"`
+	}
+)
+
+// DownloadsController implements Selenium managed downloads endpoints on top of the browser fileserver
+type DownloadsController struct {
+	files *fileserver.Client
+}
+
+func NewDownloadsController(hc client.HTTPClient) *DownloadsController {
+	return &DownloadsController{
+		files: fileserver.NewClient(hc),
+	}
+}
+
+// ListFiles returns names of the files downloaded by the browser
+func (d *DownloadsController) ListFiles(c echo.Context) error {
+	baseURL, err := downloadsURL(c)
+	if err != nil {
+		return err
+	}
+
+	names, err := d.files.List(c.Request().Context(), baseURL)
+	if err != nil {
+		return models.NewW3CErr(http.StatusInternalServerError, models.UnknownErr,
+			errors.Wrap(err, "failed to list downloaded files"))
+	}
+	if names == nil {
+		names = []string{}
+	}
+	return c.JSON(http.StatusOK, &namesResponse{Value: namesValue{Names: names}})
+}
+
+// GetFile returns requested file as base64 encoded zip archive, the same way Selenium Grid does
+func (d *DownloadsController) GetFile(c echo.Context) error {
+	baseURL, err := downloadsURL(c)
+	if err != nil {
+		return err
+	}
+
+	var req fileRequest
+	if err := c.Bind(&req); err != nil {
+		return models.NewW3CErr(http.StatusBadRequest, models.InvalidArgumentErr, errors.Wrap(err, "failed to parse request"))
+	}
+	if req.Name == "" || strings.ContainsAny(req.Name, `/\`) || req.Name == ".." {
+		return models.NewW3CErr(http.StatusBadRequest, models.InvalidArgumentErr, errors.Errorf("invalid file name %q", req.Name))
+	}
+
+	data, err := d.files.Get(c.Request().Context(), baseURL, req.Name)
+	if err != nil {
+		if errors.Is(err, fileserver.ErrNotFound) {
+			return models.NewW3CErr(http.StatusNotFound, models.UnknownErr,
+				errors.Errorf("cannot find file %s in downloads directory", req.Name))
+		}
+		return models.NewW3CErr(http.StatusInternalServerError, models.UnknownErr,
+			errors.Wrapf(err, "failed to get downloaded file %s", req.Name))
+	}
+
+	contents, err := zipFile(req.Name, data)
+	if err != nil {
+		return models.NewW3CErr(http.StatusInternalServerError, models.UnknownErr,
+			errors.Wrapf(err, "failed to archive downloaded file %s", req.Name))
+	}
+	return c.JSON(http.StatusOK, &fileResponse{Value: fileValue{FileName: req.Name, Contents: contents}})
+}
+
+// DeleteFiles removes all the files downloaded by the browser
+func (d *DownloadsController) DeleteFiles(c echo.Context) error {
+	baseURL, err := downloadsURL(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	names, err := d.files.List(ctx, baseURL)
+	if err == nil {
+		for _, name := range names {
+			if err = d.files.Delete(ctx, baseURL, name); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		return models.NewW3CErr(http.StatusInternalServerError, models.UnknownErr,
+			errors.Wrap(err, "failed to delete downloaded files"))
+	}
+	return c.JSON(http.StatusOK, &nullResponse{})
+}
+
+// ArchiveDownloads streams all the downloaded files as a single archive when requested with archive query parameter,
+// otherwise request is passed through to the fileserver
+func (d *DownloadsController) ArchiveDownloads(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		formatParam := c.QueryParam(archiveQParam)
+		if formatParam == "" || c.Request().Method != http.MethodGet {
+			return next(c)
+		}
+		format, err := fileserver.ParseArchiveFormat(formatParam)
+		if err != nil {
+			return models.NewBadRequestError(err)
+		}
+
+		sess, _ := c.Get(SessionKey).(*session.Session)
+		hp := sess.Browser().GetHostPort(models.FileserverPort)
+		if hp == "" {
+			return models.NewServiceUnavailableError(errors.Errorf("port %v is not supported or not enabled", models.FileserverPort))
+		}
+		baseURL := &url.URL{Scheme: "http", Host: hp}
+
+		ctx := c.Request().Context()
+		names, err := d.files.List(ctx, baseURL)
+		if err != nil {
+			return errors.Wrap(err, "failed to list downloaded files")
+		}
+
+		resp := c.Response()
+		resp.Header().Set(echo.HeaderContentType, format.ContentType())
+		resp.Header().Set(echo.HeaderContentDisposition,
+			fmt.Sprintf("attachment; filename=%q", sess.ID()+"-downloads."+string(format)))
+		resp.WriteHeader(http.StatusOK)
+		// response is already committed at this point, so the error only aborts the stream
+		return errors.Wrap(d.files.Archive(ctx, baseURL, names, format, resp), "failed to archive downloaded files")
+	}
+}
+
+func downloadsURL(c echo.Context) (*url.URL, error) {
+	sess, _ := c.Get(SessionKey).(*session.Session)
+	if wc, ok := sess.ReqCaps().(capabilities.WDCapabilities); !ok || !wc.IsDownloadsEnabled() {
+		return nil, models.NewW3CErr(http.StatusInternalServerError, models.UnknownErr,
+			errors.Errorf("managed downloads are not enabled for this session, set %s capability to true",
+				models.DownloadsEnabledCapability))
+	}
+	hp := sess.Browser().GetHostPort(models.FileserverPort)
+	if hp == "" {
+		return nil, models.NewW3CErr(http.StatusInternalServerError, models.UnknownErr,
+			errors.New("managed downloads are not supported by the browser"))
+	}
+	return &url.URL{Scheme: "http", Host: hp}, nil
+}
+
+func zipFile(name string, data []byte) (string, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(name)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,224 @@
+package controllers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestDownloadsController_ListFiles(t *testing.T) {
+	g := NewWithT(t)
+	hc := mocks.NewHTTPClient(t)
+	cntr := NewDownloadsController(hc)
+
+	c, rec := getDownloadsContext(t, http.MethodGet, "", true, "host1:8080")
+	hc.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.Method).To(Equal(http.MethodGet))
+		g.Expect(req.URL.String()).To(Equal("http://host1:8080?json=true"))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`["a.txt","b.pdf"]`))}, nil).Once()
+
+	g.Expect(cntr.ListFiles(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"value":{"names":["a.txt","b.pdf"]}}`))
+}
+
+func TestDownloadsController_ListFilesNotEnabled(t *testing.T) {
+	g := NewWithT(t)
+	cntr := NewDownloadsController(nil)
+
+	c, _ := getDownloadsContext(t, http.MethodGet, "", false, "")
+	err := cntr.ListFiles(c)
+	var w3cErr *models.W3CError
+	g.Expect(errors.As(err, &w3cErr)).To(BeTrue())
+	g.Expect(w3cErr.Code()).To(Equal(http.StatusInternalServerError))
+	g.Expect(w3cErr.Value.Error).To(Equal(models.UnknownErr))
+	g.Expect(w3cErr.Value.Message).To(ContainSubstring("se:downloadsEnabled"))
+
+	c, _ = getDownloadsContext(t, http.MethodGet, "", true, "")
+	g.Expect(cntr.ListFiles(c)).To(MatchError("managed downloads are not supported by the browser"))
+}
+
+func TestDownloadsController_GetFile(t *testing.T) {
+	g := NewWithT(t)
+	hc := mocks.NewHTTPClient(t)
+	cntr := NewDownloadsController(hc)
+
+	c, rec := getDownloadsContext(t, http.MethodPost, `{"name":"a.txt"}`, true, "host1:8080")
+	hc.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.Method).To(Equal(http.MethodGet))
+		g.Expect(req.URL.String()).To(Equal("http://host1:8080/a.txt"))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`hello`))}, nil).Once()
+
+	g.Expect(cntr.GetFile(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+
+	var resp fileResponse
+	g.Expect(json.NewDecoder(rec.Body).Decode(&resp)).To(Succeed())
+	g.Expect(resp.Value.FileName).To(Equal("a.txt"))
+
+	b, err := base64.StdEncoding.DecodeString(resp.Value.Contents)
+	g.Expect(err).ToNot(HaveOccurred())
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(zr.File).To(HaveLen(1))
+	g.Expect(zr.File[0].Name).To(Equal("a.txt"))
+	f, err := zr.File[0].Open()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(io.ReadAll(f)).To(Equal([]byte("hello")))
+}
+
+func TestDownloadsController_GetFileErrors(t *testing.T) {
+	g := NewWithT(t)
+	hc := mocks.NewHTTPClient(t)
+	cntr := NewDownloadsController(hc)
+
+	var w3cErr *models.W3CError
+	c, _ := getDownloadsContext(t, http.MethodPost, `{"name":"../etc/passwd"}`, true, "host1:8080")
+	g.Expect(errors.As(cntr.GetFile(c), &w3cErr)).To(BeTrue())
+	g.Expect(w3cErr.Code()).To(Equal(http.StatusBadRequest))
+	g.Expect(w3cErr.Value.Error).To(Equal(models.InvalidArgumentErr))
+
+	c, _ = getDownloadsContext(t, http.MethodPost, `{"name":"a.txt"}`, true, "host1:8080")
+	hc.EXPECT().Do(mock.Anything).
+		Return(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(``))}, nil).Once()
+	g.Expect(errors.As(cntr.GetFile(c), &w3cErr)).To(BeTrue())
+	g.Expect(w3cErr.Code()).To(Equal(http.StatusNotFound))
+	g.Expect(w3cErr.Value.Message).To(Equal("cannot find file a.txt in downloads directory"))
+}
+
+func TestDownloadsController_DeleteFiles(t *testing.T) {
+	g := NewWithT(t)
+	hc := mocks.NewHTTPClient(t)
+	cntr := NewDownloadsController(hc)
+
+	c, rec := getDownloadsContext(t, http.MethodDelete, "", true, "host1:8080")
+	hc.EXPECT().Do(mock.Anything).
+		Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`["a.txt","b.pdf"]`))}, nil).Once()
+	for _, name := range []string{"a.txt", "b.pdf"} {
+		hc.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+			g.Expect(req.Method).To(Equal(http.MethodDelete))
+			g.Expect(req.URL.String()).To(Equal("http://host1:8080/" + name))
+		}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(``))}, nil).Once()
+	}
+
+	g.Expect(cntr.DeleteFiles(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"value":null}`))
+}
+
+func TestDownloadsController_ArchiveDownloads(t *testing.T) {
+	g := NewWithT(t)
+	hc := mocks.NewHTTPClient(t)
+	cntr := NewDownloadsController(hc)
+
+	br := mocks.NewBrowser(t)
+	br.EXPECT().GetHostPort(models.FileserverPort).Return("host1:8080").Once()
+	c, rec := getArchiveContext(br, http.MethodGet, "?archive=zip")
+	hc.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.URL.String()).To(Equal("http://host1:8080?json=true"))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`["a.txt"]`))}, nil).Once()
+	hc.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.URL.String()).To(Equal("http://host1:8080/a.txt"))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`hello`))}, nil).Once()
+
+	next := func(echo.Context) error {
+		t.Fatal("next handler must not be called")
+		return nil
+	}
+	g.Expect(cntr.ArchiveDownloads(next)(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Header().Get(echo.HeaderContentType)).To(Equal("application/zip"))
+	g.Expect(rec.Header().Get(echo.HeaderContentDisposition)).To(Equal(`attachment; filename="s1-downloads.zip"`))
+
+	zr, err := zip.NewReader(bytes.NewReader(rec.Body.Bytes()), int64(rec.Body.Len()))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(zr.File).To(HaveLen(1))
+	g.Expect(zr.File[0].Name).To(Equal("a.txt"))
+}
+
+func TestDownloadsController_ArchiveDownloadsPassThrough(t *testing.T) {
+	g := NewWithT(t)
+	cntr := NewDownloadsController(nil)
+
+	called := 0
+	next := func(echo.Context) error {
+		called++
+		return nil
+	}
+	c, _ := getArchiveContext(nil, http.MethodGet, "")
+	g.Expect(cntr.ArchiveDownloads(next)(c)).To(Succeed())
+	c, _ = getArchiveContext(nil, http.MethodDelete, "?archive=zip")
+	g.Expect(cntr.ArchiveDownloads(next)(c)).To(Succeed())
+	g.Expect(called).To(Equal(2))
+}
+
+func TestDownloadsController_ArchiveDownloadsErrors(t *testing.T) {
+	g := NewWithT(t)
+	cntr := NewDownloadsController(nil)
+
+	c, _ := getArchiveContext(nil, http.MethodGet, "?archive=rar")
+	err := cntr.ArchiveDownloads(nil)(c)
+	e, ok := unwrapErrorWithCode(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusBadRequest))
+
+	br := mocks.NewBrowser(t)
+	br.EXPECT().GetHostPort(models.FileserverPort).Return("").Once()
+	c, _ = getArchiveContext(br, http.MethodGet, "?archive=tar.gz")
+	err = cntr.ArchiveDownloads(nil)(c)
+	e, ok = unwrapErrorWithCode(err)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusServiceUnavailable))
+}
+
+func getArchiveContext(br *mocks.Browser, method, query string) (echo.Context, *httptest.ResponseRecorder) {
+	sess := session.NewSession("s1", "LINUX", br, nil, nil, time.Now(), nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(method, "/download/s1"+query, nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames(router.SessionParam)
+	c.SetParamValues("s1")
+	c.Set(SessionKey, sess)
+	return c, rec
+}
+
+func getDownloadsContext(t *testing.T, method, body string, enabled bool, fileserver string) (echo.Context, *httptest.ResponseRecorder) {
+	caps := mocks.NewWDCapabilities(t)
+	caps.EXPECT().IsDownloadsEnabled().Return(enabled)
+	br := mocks.NewBrowser(t)
+	if enabled {
+		br.EXPECT().GetHostPort(models.FileserverPort).Return(fileserver)
+	}
+	sess := session.NewSession("s1", "LINUX", br, caps, nil, time.Now(), nil, nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(method, "/wd/hub/session/s1/se/files", strings.NewReader(body))
+	if body != "" {
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames(router.SessionParam)
+	c.SetParamValues("s1")
+	c.Set(SessionKey, sess)
+	return c, rec
+}
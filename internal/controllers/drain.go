@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/selebrow/selebrow/internal/services/drain"
+	"github.com/selebrow/selebrow/pkg/dto"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+type DrainController struct {
+	svc drain.DrainService
+}
+
+func NewDrainController(svc drain.DrainService) *DrainController {
+	return &DrainController{svc: svc}
+}
+
+func (d *DrainController) Status(c echo.Context) error {
+	return c.JSON(http.StatusOK, d.status())
+}
+
+func (d *DrainController) Drain(c echo.Context) error {
+	d.svc.Drain()
+	return c.JSON(http.StatusOK, d.status())
+}
+
+func (d *DrainController) Resume(c echo.Context) error {
+	if err := d.svc.Resume(); err != nil {
+		return models.NewErrorMessage(http.StatusConflict, err)
+	}
+	return c.JSON(http.StatusOK, d.status())
+}
+
+// RejectWhenDraining middleware rejects new session requests with 503, so they can be routed to other instances
+func (d *DrainController) RejectWhenDraining(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if d.svc.Draining() {
+			return models.NewServiceUnavailableError(drain.ErrDraining)
+		}
+		return next(c)
+	}
+}
+
+func (d *DrainController) status() *dto.DrainStatus {
+	return &dto.DrainStatus{
+		Draining: d.svc.Draining(),
+		Sessions: d.svc.ActiveSessions(),
+	}
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/gomega"
+
+	"github.com/selebrow/selebrow/internal/services/drain"
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestDrainController_Status(t *testing.T) {
+	g := NewWithT(t)
+
+	d := mocks.NewDrainService(t)
+	dc := NewDrainController(d)
+	c, rec := getDrainContext(http.MethodGet)
+
+	d.EXPECT().Draining().Return(false).Once()
+	d.EXPECT().ActiveSessions().Return(3).Once()
+
+	err := dc.Status(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"draining":false,"sessions":3}`))
+}
+
+func TestDrainController_Drain(t *testing.T) {
+	g := NewWithT(t)
+
+	d := mocks.NewDrainService(t)
+	dc := NewDrainController(d)
+	c, rec := getDrainContext(http.MethodPost)
+
+	d.EXPECT().Drain().Return(true).Once()
+	d.EXPECT().Draining().Return(true).Once()
+	d.EXPECT().ActiveSessions().Return(2).Once()
+
+	err := dc.Drain(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"draining":true,"sessions":2}`))
+}
+
+func TestDrainController_Resume(t *testing.T) {
+	g := NewWithT(t)
+
+	d := mocks.NewDrainService(t)
+	dc := NewDrainController(d)
+	c, rec := getDrainContext(http.MethodDelete)
+
+	d.EXPECT().Resume().Return(nil).Once()
+	d.EXPECT().Draining().Return(false).Once()
+	d.EXPECT().ActiveSessions().Return(0).Once()
+
+	err := dc.Resume(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"draining":false,"sessions":0}`))
+
+	d.EXPECT().Resume().Return(drain.ErrShuttingDown).Once()
+	err = dc.Resume(c)
+	g.Expect(err).To(MatchError("server is shutting down"))
+	g.Expect(err.(models.ErrorWithCode).Code()).To(Equal(http.StatusConflict))
+}
+
+func TestDrainController_RejectWhenDraining(t *testing.T) {
+	g := NewWithT(t)
+
+	d := mocks.NewDrainService(t)
+	dc := NewDrainController(d)
+	c, rec := getDrainContext(http.MethodPost)
+
+	called := false
+	h := dc.RejectWhenDraining(func(c echo.Context) error {
+		called = true
+		return c.NoContent(http.StatusOK)
+	})
+
+	d.EXPECT().Draining().Return(false).Once()
+	err := h(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(called).To(BeTrue())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+
+	called = false
+	d.EXPECT().Draining().Return(true).Once()
+	err = h(c)
+	g.Expect(err).To(MatchError(drain.ErrDraining))
+	g.Expect(err.(models.ErrorWithCode).Code()).To(Equal(http.StatusServiceUnavailable))
+	g.Expect(called).To(BeFalse())
+}
+
+func getDrainContext(method string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/admin/drain", http.NoBody)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
@@ -0,0 +1,34 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/selebrow/selebrow/internal/services/health"
+	"github.com/selebrow/selebrow/pkg/dto"
+)
+
+type HealthController struct {
+	svc health.HealthService
+}
+
+func NewHealthController(svc health.HealthService) *HealthController {
+	return &HealthController{svc: svc}
+}
+
+func (h *HealthController) Healthz(c echo.Context) error {
+	return healthResponse(c, h.svc.Liveness(c.Request().Context()))
+}
+
+func (h *HealthController) Readyz(c echo.Context) error {
+	return healthResponse(c, h.svc.Readiness(c.Request().Context()))
+}
+
+func healthResponse(c echo.Context, res *dto.Health) error {
+	code := http.StatusOK
+	if !res.OK() {
+		code = http.StatusServiceUnavailable
+	}
+	return c.JSON(code, res)
+}
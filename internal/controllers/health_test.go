@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/selebrow/selebrow/internal/services/health"
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/dto"
+)
+
+func TestHealthController_Healthz(t *testing.T) {
+	g := NewWithT(t)
+
+	svc := mocks.NewHealthService(t)
+	hc := NewHealthController(svc)
+	c, rec := getHealthContext("/healthz")
+
+	svc.EXPECT().Liveness(mock.Anything).Return(&dto.Health{
+		Status: dto.HealthStatusOK,
+		Checks: map[string]dto.HealthCheck{"podWatcher": {Status: dto.HealthStatusOK}},
+	}).Once()
+
+	err := hc.Healthz(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"status":"ok","checks":{"podWatcher":{"status":"ok"}}}`))
+}
+
+func TestHealthController_Readyz(t *testing.T) {
+	g := NewWithT(t)
+
+	svc := mocks.NewHealthService(t)
+	hc := NewHealthController(svc)
+	c, rec := getHealthContext("/readyz")
+
+	svc.EXPECT().Readiness(mock.Anything).Return(&dto.Health{
+		Status: dto.HealthStatusFail,
+		Checks: map[string]dto.HealthCheck{
+			"docker":  {Status: dto.HealthStatusFail, Error: "connection refused"},
+			"catalog": {Status: dto.HealthStatusOK},
+		},
+	}).Once()
+
+	err := hc.Readyz(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusServiceUnavailable))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{
+		"status": "fail",
+		"checks": {
+			"docker": {"status": "fail", "error": "connection refused"},
+			"catalog": {"status": "ok"}
+		}
+	}`))
+}
+
+func getHealthContext(path string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, path, http.NoBody)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestHealthController_Healthz_BackendPingFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	svc := health.NewHealthService(time.Second)
+	svc.AddLivenessCheckThreshold("docker", func(_ context.Context) error {
+		return errors.New("connection refused")
+	}, 2)
+	hc := NewHealthController(svc)
+
+	c, rec := getHealthContext("/healthz")
+	g.Expect(hc.Healthz(c)).To(Succeed())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+
+	c, rec = getHealthContext("/healthz")
+	g.Expect(hc.Healthz(c)).To(Succeed())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusServiceUnavailable))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"status":"fail","checks":{"docker":{"status":"fail","error":"connection refused"}}}`))
+}
@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/browser/pool"
+	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/pkg/dto"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+var errPoolDisabled = errors.New("browser pool is disabled")
+
+type PoolController struct {
+	admin pool.PoolAdmin
+}
+
+// NewPoolController admin can be nil when pool is disabled
+func NewPoolController(admin pool.PoolAdmin) *PoolController {
+	return &PoolController{admin: admin}
+}
+
+func (p *PoolController) ListPools(c echo.Context) error {
+	if p.admin == nil {
+		return models.NewServiceUnavailableError(errPoolDisabled)
+	}
+	return c.JSON(http.StatusOK, p.admin.ListPools())
+}
+
+func (p *PoolController) DrainPool(c echo.Context) error {
+	if p.admin == nil {
+		return models.NewServiceUnavailableError(errPoolDisabled)
+	}
+
+	name := c.Param(router.NameParam)
+	n, err := p.admin.DrainPool(c.Request().Context(), name)
+	if err != nil {
+		if errors.Is(err, pool.ErrPoolNotFound) {
+			return models.NewNotFoundError(errors.Errorf("pool %s not found", name))
+		}
+		return models.NewInternalServerError(errors.Wrapf(err, "failed to drain pool %s", name))
+	}
+	return c.JSON(http.StatusOK, dto.PoolDrain{Drained: n})
+}
+
+func (p *PoolController) DrainPools(c echo.Context) error {
+	if p.admin == nil {
+		return models.NewServiceUnavailableError(errPoolDisabled)
+	}
+
+	n, err := p.admin.DrainPools(c.Request().Context())
+	if err != nil {
+		return models.NewInternalServerError(errors.Wrap(err, "failed to drain pools"))
+	}
+	return c.JSON(http.StatusOK, dto.PoolDrain{Drained: n})
+}
@@ -0,0 +1,127 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/selebrow/selebrow/internal/browser/pool"
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/dto"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestPoolController_ListPools(t *testing.T) {
+	g := NewWithT(t)
+
+	admin := mocks.NewPoolAdmin(t)
+	pc := NewPoolController(admin)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/admin/pools", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	expResp := []dto.Pool{{
+		Name:      "webdriver-chrome-abc",
+		Protocol:  "webdriver",
+		Browser:   "chrome",
+		CapsHash:  "abc",
+		IdleCount: 1,
+		Idle:      []dto.IdleBrowser{{ID: "123", URL: "http://host1", Age: "1m0s", IdleTime: "5s"}},
+		Stats:     dto.PoolStats{Hits: 1, Misses: 2, Evictions: 3, HealthCheckFailures: 4},
+	}}
+	admin.EXPECT().ListPools().Return(expResp).Once()
+	err := pc.ListPools(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+
+	var gotResp []dto.Pool
+	err = json.NewDecoder(rec.Body).Decode(&gotResp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(gotResp).To(Equal(expResp))
+}
+
+func TestPoolController_DrainPool(t *testing.T) {
+	g := NewWithT(t)
+
+	admin := mocks.NewPoolAdmin(t)
+	pc := NewPoolController(admin)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/admin/pools/webdriver-chrome-abc", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("name")
+	c.SetParamValues("webdriver-chrome-abc")
+
+	admin.EXPECT().DrainPool(mock.Anything, "webdriver-chrome-abc").Return(3, nil).Once()
+	err := pc.DrainPool(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"drained":3}`))
+}
+
+func TestPoolController_DrainPoolNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	admin := mocks.NewPoolAdmin(t)
+	pc := NewPoolController(admin)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/admin/pools/qqq", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("name")
+	c.SetParamValues("qqq")
+
+	admin.EXPECT().DrainPool(mock.Anything, "qqq").Return(0, pool.ErrPoolNotFound).Once()
+	err := pc.DrainPool(c)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.(models.ErrorWithCode).Code()).To(Equal(http.StatusNotFound))
+}
+
+func TestPoolController_DrainPools(t *testing.T) {
+	g := NewWithT(t)
+
+	admin := mocks.NewPoolAdmin(t)
+	pc := NewPoolController(admin)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/admin/pools", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	admin.EXPECT().DrainPools(mock.Anything).Return(5, nil).Once()
+	err := pc.DrainPools(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec.Body.String()).To(MatchJSON(`{"drained":5}`))
+
+	admin.EXPECT().DrainPools(mock.Anything).Return(1, errors.New("timeout")).Once()
+	err = pc.DrainPools(c)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.(models.ErrorWithCode).Code()).To(Equal(http.StatusInternalServerError))
+}
+
+func TestPoolController_Disabled(t *testing.T) {
+	g := NewWithT(t)
+
+	pc := NewPoolController(nil)
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/admin/pools", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	for _, h := range []echo.HandlerFunc{pc.ListPools, pc.DrainPool, pc.DrainPools} {
+		err := h(c)
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(err.(models.ErrorWithCode).Code()).To(Equal(http.StatusServiceUnavailable))
+	}
+}
@@ -1,22 +1,35 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
 	"path"
+	"slices"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.41.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/selebrow/selebrow/internal/common/ws"
 	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/cmdlog"
 	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
+	"github.com/selebrow/selebrow/pkg/tracing"
 )
 
 const (
@@ -26,19 +39,47 @@ const (
 
 var proxyRewriteRules = map[string]string{"/se/file": "/file"}
 
+// commandRoute is a Webdriver command route pattern, "*" segment matches any single path segment e.g. element ID
+type commandRoute struct {
+	method    string
+	segments  []string
+	wildcards int
+	timeout   time.Duration
+}
+
 type ProxyController struct {
-	rules     map[string]string
-	transport http.RoundTripper
-	wsproxy   ws.WSProxy
-	l         *zap.SugaredLogger
+	rules       map[string]string
+	transport   http.RoundTripper
+	wsproxy     ws.WSProxy
+	terminator  session.SessionTerminator
+	cmdLog      cmdlog.CommandLog
+	eb          event.EventBroker
+	cmdTimeout  time.Duration
+	cmdTimeouts []commandRoute
+	killAfter   int
+	l           *zap.SugaredLogger
 }
 
-func NewProxyController(transport http.RoundTripper, wsproxy ws.WSProxy, l *zap.Logger) *ProxyController {
+func NewProxyController(
+	transport http.RoundTripper,
+	wsproxy ws.WSProxy,
+	terminator session.SessionTerminator,
+	cmdLog cmdlog.CommandLog,
+	eb event.EventBroker,
+	cfg config.CommandTimeoutConfig,
+	l *zap.Logger,
+) *ProxyController {
 	return &ProxyController{
-		rules:     proxyRewriteRules,
-		transport: transport,
-		wsproxy:   wsproxy,
-		l:         l.Sugar(),
+		rules:       proxyRewriteRules,
+		transport:   transport,
+		wsproxy:     wsproxy,
+		terminator:  terminator,
+		cmdLog:      cmdLog,
+		eb:          eb,
+		cmdTimeout:  cfg.CommandTimeout(),
+		cmdTimeouts: newCommandRoutes(cfg.CommandTimeoutOverrides()),
+		killAfter:   cfg.CommandTimeoutKillAfter(),
+		l:           l.Sugar(),
 	}
 }
 
@@ -119,20 +160,181 @@ func (p *ProxyController) Proxy(c echo.Context) error {
 	proxyURL, _ := c.Get(ProxyURLKey).(*url.URL)
 	proxyHost, _ := c.Get(ProxyHostKey).(string)
 
+	ctx, span := p.startProxySpan(c)
+	defer span.End()
+
+	sess, _ := c.Get(SessionKey).(*session.Session)
+	cmd, timeout := p.commandTimeout(c)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	req := c.Request().WithContext(ctx)
+	var logged *cmdlog.Command
+	if cmd != "" && sess != nil && p.cmdLog != nil && p.cmdLog.Enabled(sess) {
+		logged = p.cmdLog.Begin(sess, cmd, req)
+	}
+
 	(&httputil.ReverseProxy{
 		Transport: p.transport,
 		Director: func(r *http.Request) {
 			r.Host = proxyHost
 			r.URL = proxyURL
+			tracing.Inject(r.Context(), r.Header)
+		},
+		ModifyResponse: func(resp *http.Response) error {
+			span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+			if resp.StatusCode >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, resp.Status)
+			}
+			if sess != nil {
+				sess.ResetCommandTimeouts()
+			}
+			if logged != nil {
+				logged.Response(resp)
+			}
+			return nil
+		},
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+			if logged != nil {
+				logged.Error(err)
+			}
+			if timeout > 0 && errors.Is(err, context.DeadlineExceeded) {
+				p.commandTimedOut(w, sess, cmd, timeout, err)
+				return
+			}
+			p.checkBrowserCrashed(c, err)
+			p.defaultErrorHandler(c.RealIP())(w, r, err)
 		},
-		ErrorHandler: p.defaultErrorHandler(c.RealIP()),
-	}).ServeHTTP(c.Response(), c.Request())
+	}).ServeHTTP(c.Response(), req)
 	return nil
 }
 
+// commandTimeout returns Webdriver command path relative to session and its timeout,
+// zero timeout is returned for non Webdriver requests
+func (p *ProxyController) commandTimeout(c echo.Context) (string, time.Duration) {
+	if !strings.HasPrefix(c.Path(), router.WDHUBPath) {
+		return "", 0
+	}
+	cmd := strings.Trim(c.Param("*"), "/")
+	segments := strings.Split(cmd, "/")
+	for _, r := range p.cmdTimeouts {
+		if r.matches(c.Request().Method, segments) {
+			return cmd, r.timeout
+		}
+	}
+	return cmd, p.cmdTimeout
+}
+
+// newCommandRoutes parses "METHOD pattern" keyed timeouts, routes with fewer wildcards take precedence
+func newCommandRoutes(timeouts map[string]time.Duration) []commandRoute {
+	res := make([]commandRoute, 0, len(timeouts))
+	for k, t := range timeouts {
+		method, pattern, _ := strings.Cut(k, " ")
+		r := commandRoute{method: method, segments: strings.Split(pattern, "/"), timeout: t}
+		r.wildcards = strings.Count(pattern, "*")
+		res = append(res, r)
+	}
+	slices.SortFunc(res, func(a, b commandRoute) int {
+		return a.wildcards - b.wildcards
+	})
+	return res
+}
+
+func (r commandRoute) matches(method string, segments []string) bool {
+	if r.method != method || len(r.segments) != len(segments) {
+		return false
+	}
+	for i, s := range r.segments {
+		if s != "*" && s != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *ProxyController) commandTimedOut(w http.ResponseWriter, sess *session.Session, cmd string, timeout time.Duration, err error) {
+	err = errors.Wrapf(err, "command %s did not complete within %v", cmd, timeout)
+	l := p.l.With(zap.Error(err))
+	w.Header().Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	w.WriteHeader(http.StatusInternalServerError)
+	if respErr := json.NewEncoder(w).Encode(models.NewW3CErr(http.StatusInternalServerError, models.TimeoutErr, err)); respErr != nil {
+		p.l.Errorw("write error", zap.Error(respErr))
+	}
+	if sess == nil {
+		l.Warn("Webdriver command timed out")
+		return
+	}
+
+	l = l.With(zap.String("session_id", sess.ID()))
+	n := sess.CommandTimedOut()
+	if p.killAfter <= 0 || n < p.killAfter {
+		l.Warnf("Webdriver command timed out (%d in a row)", n)
+		return
+	}
+	reason := fmt.Sprintf("%d consecutive commands timed out", n)
+	l.Warnf("terminating Webdriver session: %s", reason)
+	// browser is likely hung, so don't keep client waiting while session is deleted
+	go p.terminator.TerminateSession(sess, reason)
+}
+
+// checkBrowserCrashed publishes BrowserCrashed event when Webdriver command can't reach the browser
+func (p *ProxyController) checkBrowserCrashed(c echo.Context, err error) {
+	if !strings.HasPrefix(c.Path(), router.WDHUBPath) ||
+		!(errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.ECONNRESET)) {
+		return
+	}
+	sess, ok := c.Get(SessionKey).(*session.Session)
+	if !ok || sess == nil {
+		return
+	}
+
+	caps := sess.ReqCaps()
+	p.eb.Publish(evmodels.NewBrowserCrashedEvent(evmodels.BrowserCrashed{
+		Protocol:       models.WebdriverProtocol,
+		ID:             sess.ID(),
+		BrowserName:    caps.GetName(),
+		BrowserVersion: caps.GetVersion(),
+		Labels:         caps.GetLabels(),
+		Error:          err.Error(),
+	}))
+}
+
+// startProxySpan starts span for the proxied request, session creation span is used as a parent
+// unless client propagated its own trace context
+func (p *ProxyController) startProxySpan(c echo.Context) (context.Context, trace.Span) {
+	ctx := c.Request().Context()
+	name := "session.proxy"
+	if strings.HasPrefix(c.Path(), router.WDHUBPath) {
+		name = "webdriver.command"
+	}
+	attrs := []attribute.KeyValue{
+		semconv.HTTPRequestMethodKey.String(c.Request().Method),
+		semconv.HTTPRoute(c.Path()),
+	}
+
+	if sess, ok := c.Get(SessionKey).(*session.Session); ok && sess != nil {
+		attrs = append(attrs, tracing.SessionIDKey.String(sess.ID()))
+		if !trace.SpanContextFromContext(ctx).IsValid() && sess.SpanContext().IsValid() {
+			ctx = trace.ContextWithRemoteSpanContext(ctx, sess.SpanContext())
+		}
+	}
+
+	return tracing.Start(ctx, name, attrs...)
+}
+
+// VNCProxy proxies VNC websocket to the browser, VNC traffic keeps session from being closed by idle timeout
 func (p *ProxyController) VNCProxy(c echo.Context) error {
 	proxyURL, _ := c.Get(ProxyURLKey).(*url.URL)
-	p.wsproxy.Handler(proxyURL.Host).ServeHTTP(c.Response(), c.Request())
+	var touch func()
+	if sess, ok := c.Get(SessionKey).(*session.Session); ok && sess != nil {
+		touch = func() { sess.SetLastUsed(time.Now()) }
+	}
+	p.wsproxy.Handler(proxyURL.Host, touch).ServeHTTP(c.Response(), c.Request())
 	return nil
 }
 
@@ -3,9 +3,12 @@ package controllers
 import (
 	"encoding/json"
 	"io"
+	"net"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
@@ -14,18 +17,28 @@ import (
 	"github.com/pkg/errors"
 	"github.com/posener/wstest"
 	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 	"go.uber.org/zap/zaptest"
 	ws "golang.org/x/net/websocket"
 
 	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/cmdlog"
 	"github.com/selebrow/selebrow/internal/services/session"
 	"github.com/selebrow/selebrow/mocks"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
+	"github.com/selebrow/selebrow/pkg/tracing"
 )
 
 func TestWDProxyController_SetProxyUrl(t *testing.T) {
 	g := NewGomegaWithT(t)
-	cntr := NewProxyController(nil, nil, zaptest.NewLogger(t))
+	cntr := NewProxyController(nil, nil, nil, nil, nil, noCommandTimeouts(t), zaptest.NewLogger(t))
 
 	u := "http://host:5566/wdhub"
 	s := session.NewSession("12345", "DARWIN", getWebdriverMock(g, u, "hst:321"), nil, nil, time.Now(), nil, nil)
@@ -48,7 +61,7 @@ func TestWDProxyController_SetProxyUrl(t *testing.T) {
 
 func TestWDProxyController_SetPortProxyUrl(t *testing.T) {
 	g := NewGomegaWithT(t)
-	cntr := NewProxyController(nil, nil, zaptest.NewLogger(t))
+	cntr := NewProxyController(nil, nil, nil, nil, nil, noCommandTimeouts(t), zaptest.NewLogger(t))
 
 	hp := "fs1:8088"
 	s := session.NewSession("1122", "OPENBSD", getWebdriverPortMock(models.FileserverPort, hp), nil, nil, time.Now(), nil, nil)
@@ -76,7 +89,7 @@ func TestWDProxyController_SetPortProxyUrl(t *testing.T) {
 
 func TestWDProxyController_SetPortProxyUrlUnavailable(t *testing.T) {
 	g := NewGomegaWithT(t)
-	cntr := NewProxyController(nil, nil, zaptest.NewLogger(t))
+	cntr := NewProxyController(nil, nil, nil, nil, nil, noCommandTimeouts(t), zaptest.NewLogger(t))
 
 	s := session.NewSession("1122", "OPENBSD", getWebdriverPortMock(models.ClipboardPort, ""), nil, nil, time.Now(), nil, nil)
 	path := "/session/1122/tail"
@@ -116,7 +129,7 @@ func TestWDProxyController_ProxyURLRewrite(t *testing.T) {
 
 func TestWDProxyController_ProxyURLRewriteSeDownload(t *testing.T) {
 	g := NewGomegaWithT(t)
-	cntr := NewProxyController(nil, nil, zaptest.NewLogger(t))
+	cntr := NewProxyController(nil, nil, nil, nil, nil, noCommandTimeouts(t), zaptest.NewLogger(t))
 	route := "http://host.tld:1234"
 
 	path1 := "/session/12345/se/file"
@@ -141,7 +154,7 @@ func TestWDProxyController_ProxyURLRewriteSeDownload(t *testing.T) {
 func TestWDProxyController_Proxy(t *testing.T) {
 	g := NewGomegaWithT(t)
 	rt := new(mocks.RoundTripper)
-	cntr := NewProxyController(rt, nil, zaptest.NewLogger(t))
+	cntr := NewProxyController(rt, nil, nil, nil, nil, noCommandTimeouts(t), zaptest.NewLogger(t))
 
 	u, err := url.Parse("http://host:3215/wd/hub/session/s1/some-request")
 	g.Expect(err).ToNot(HaveOccurred())
@@ -168,7 +181,7 @@ func TestWDProxyController_Proxy(t *testing.T) {
 func TestWDProxyController_ProxyError(t *testing.T) {
 	g := NewGomegaWithT(t)
 	rt := new(mocks.RoundTripper)
-	cntr := NewProxyController(rt, nil, zaptest.NewLogger(t))
+	cntr := NewProxyController(rt, nil, nil, nil, nil, noCommandTimeouts(t), zaptest.NewLogger(t))
 
 	u, err := url.Parse("http://host:3215/wd/hub/session/s1/some-request")
 	g.Expect(err).ToNot(HaveOccurred())
@@ -189,18 +202,231 @@ func TestWDProxyController_ProxyError(t *testing.T) {
 	g.Expect(w3cErr.Value.StackTrace).To(MatchRegexp(".*test proxy error.*"))
 }
 
+func TestWDProxyController_ProxyBrowserCrashed(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rt := new(mocks.RoundTripper)
+	eb := mocks.NewEventBroker(t)
+	cntr := NewProxyController(rt, nil, nil, nil, eb, noCommandTimeouts(t), zaptest.NewLogger(t))
+
+	u, err := url.Parse("http://host:3215/wd/hub/session/s1/url")
+	g.Expect(err).ToNot(HaveOccurred())
+	ctx, rec := getSessionContext(router.SessRoute("/wd/hub/session/:%s/*"), "/wd/hub/session/s1/url", "s1")
+	ctx.Set(ProxyURLKey, u)
+	ctx.Set(ProxyHostKey, "hst:123")
+
+	caps := mocks.NewCapabilities(t)
+	caps.EXPECT().GetName().Return("chrome")
+	caps.EXPECT().GetVersion().Return("120.0")
+	caps.EXPECT().GetLabels().Return(map[string]string{"team": "qa"})
+	ctx.Set(SessionKey, session.NewSession("s1", "LINUX", nil, caps, nil, time.Now(), nil, nil))
+
+	var nilResp *http.Response
+	rt.EXPECT().RoundTrip(mock.Anything).Return(nilResp, &net.OpError{Op: "dial", Err: syscall.ECONNREFUSED})
+	eb.EXPECT().Publish(mock.Anything).Run(func(ev evmodels.IEvent) {
+		g.Expect(ev.EventType()).To(Equal(evmodels.BrowserCrashedEventType))
+		g.Expect(ev.(*evmodels.Event[evmodels.BrowserCrashed]).Attributes).To(Equal(evmodels.BrowserCrashed{
+			Protocol:       models.WebdriverProtocol,
+			ID:             "s1",
+			BrowserName:    "chrome",
+			BrowserVersion: "120.0",
+			Labels:         map[string]string{"team": "qa"},
+			Error:          "dial: connection refused",
+		}))
+	}).Once()
+
+	g.Expect(cntr.Proxy(ctx)).To(Succeed())
+	g.Expect(rec.Code).Should(Equal(http.StatusBadGateway))
+}
+
+func TestWDProxyController_ProxyCommandTimeout(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rt := mocks.NewRoundTripper(t)
+	term := mocks.NewSessionTerminator(t)
+	cfg := mocks.NewCommandTimeoutConfig(t)
+	cfg.EXPECT().CommandTimeout().Return(time.Hour)
+	cfg.EXPECT().CommandTimeoutOverrides().Return(map[string]time.Duration{"POST url": 20 * time.Millisecond})
+	cfg.EXPECT().CommandTimeoutKillAfter().Return(2)
+	cntr := NewProxyController(rt, nil, term, nil, nil, cfg, zaptest.NewLogger(t))
+
+	u, err := url.Parse("http://host:3215/wd/hub/session/s1/url")
+	g.Expect(err).ToNot(HaveOccurred())
+	sess := session.NewSession("s1", "LINUX", nil, nil, nil, time.Now(), nil, nil)
+
+	hung := func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	}
+	ok := func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(``))}, nil
+	}
+	rt.EXPECT().RoundTrip(mock.Anything).RunAndReturn(hung).Once()
+	rt.EXPECT().RoundTrip(mock.Anything).RunAndReturn(ok).Once()
+	rt.EXPECT().RoundTrip(mock.Anything).RunAndReturn(hung).Twice()
+
+	terminated := make(chan struct{})
+	term.EXPECT().TerminateSession(sess, "2 consecutive commands timed out").Run(func(*session.Session, string) {
+		close(terminated)
+	}).Once()
+
+	proxy := func() *httptest.ResponseRecorder {
+		ctx, rec := getSessionContext(router.SessRoute("/wd/hub/session/:%s/*"), "/wd/hub/session/s1/url", "s1")
+		ctx.SetParamNames(router.SessionParam, "*")
+		ctx.SetParamValues("s1", "url")
+		ctx.Request().Method = http.MethodPost
+		ctx.Set(ProxyURLKey, u)
+		ctx.Set(ProxyHostKey, "hst:123")
+		ctx.Set(SessionKey, sess)
+		g.Expect(cntr.Proxy(ctx)).To(Succeed())
+		return rec
+	}
+
+	rec := proxy()
+	g.Expect(rec.Code).To(Equal(http.StatusInternalServerError))
+	g.Expect(rec.Header().Get(echo.HeaderContentType)).To(Equal(echo.MIMEApplicationJSON))
+	var w3cErr models.W3CError
+	g.Expect(json.NewDecoder(rec.Body).Decode(&w3cErr)).To(Succeed())
+	g.Expect(w3cErr.Value.Error).To(Equal(models.TimeoutErr))
+	g.Expect(w3cErr.Value.Message).To(ContainSubstring("command url did not complete within 20ms"))
+
+	// successful command resets consecutive timeouts counter
+	g.Expect(proxy().Code).To(Equal(http.StatusOK))
+	g.Expect(proxy().Code).To(Equal(http.StatusInternalServerError))
+	g.Consistently(terminated, 50*time.Millisecond).ShouldNot(BeClosed())
+	g.Expect(proxy().Code).To(Equal(http.StatusInternalServerError))
+	g.Eventually(terminated).Should(BeClosed())
+}
+
+func TestWDProxyController_CommandTimeoutRoutes(t *testing.T) {
+	g := NewGomegaWithT(t)
+	cfg := mocks.NewCommandTimeoutConfig(t)
+	cfg.EXPECT().CommandTimeout().Return(time.Minute)
+	cfg.EXPECT().CommandTimeoutOverrides().Return(map[string]time.Duration{
+		"POST url":              5 * time.Second,
+		"POST element/*/click":  10 * time.Second,
+		"POST element/e1/click": 15 * time.Second,
+		"GET element/*/*":       20 * time.Second,
+	})
+	cfg.EXPECT().CommandTimeoutKillAfter().Return(0)
+	cntr := NewProxyController(nil, nil, nil, nil, nil, cfg, zaptest.NewLogger(t))
+
+	tests := []struct {
+		method string
+		cmd    string
+		want   time.Duration
+	}{
+		{method: http.MethodPost, cmd: "url", want: 5 * time.Second},
+		{method: http.MethodGet, cmd: "url", want: time.Minute},
+		{method: http.MethodPost, cmd: "element/f.108D1E1B-7B4A-4A2E/click", want: 10 * time.Second},
+		{method: http.MethodPost, cmd: "element/e1/click", want: 15 * time.Second},
+		{method: http.MethodGet, cmd: "element/e2/text", want: 20 * time.Second},
+		{method: http.MethodPost, cmd: "element/e2/clear", want: time.Minute},
+		{method: http.MethodPost, cmd: "element/e2/click/extra", want: time.Minute},
+	}
+	for _, tt := range tests {
+		ctx, _ := getSessionContext(router.SessRoute("/wd/hub/session/:%s/*"), "/wd/hub/session/s1/"+tt.cmd, "s1")
+		ctx.SetParamNames(router.SessionParam, "*")
+		ctx.SetParamValues("s1", tt.cmd)
+		ctx.Request().Method = tt.method
+
+		cmd, timeout := cntr.commandTimeout(ctx)
+		g.Expect(cmd).To(Equal(tt.cmd))
+		g.Expect(timeout).To(Equal(tt.want), "%s %s", tt.method, tt.cmd)
+	}
+}
+
+func TestWDProxyController_ProxyCommandLog(t *testing.T) {
+	g := NewGomegaWithT(t)
+	rt := mocks.NewRoundTripper(t)
+	cmdLog := cmdlog.NewStore(t.TempDir(), true, time.Now, zaptest.NewLogger(t))
+	cntr := NewProxyController(rt, nil, nil, cmdLog, nil, noCommandTimeouts(t), zaptest.NewLogger(t))
+
+	u, err := url.Parse("http://host:3215/wd/hub/session/s1/title")
+	g.Expect(err).ToNot(HaveOccurred())
+	sess := session.NewSession("s1", "LINUX", nil, nil, nil, time.Now(), nil, nil)
+
+	rt.EXPECT().RoundTrip(mock.Anything).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"value":"Test page"}`)),
+	}, nil).Once()
+
+	ctx, rec := getSessionContext(router.SessRoute("/wd/hub/session/:%s/*"), "/wd/hub/session/s1/title", "s1")
+	ctx.SetParamNames(router.SessionParam, "*")
+	ctx.SetParamValues("s1", "title")
+	ctx.Set(ProxyURLKey, u)
+	ctx.Set(ProxyHostKey, "hst:123")
+	ctx.Set(SessionKey, sess)
+	g.Expect(cntr.Proxy(ctx)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+
+	log, err := cmdLog.Get("s1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(log.Entries).To(HaveLen(1))
+	g.Expect(log.Entries[0].Method).To(Equal(http.MethodGet))
+	g.Expect(log.Entries[0].Path).To(Equal("/title"))
+	g.Expect(log.Entries[0].Status).To(Equal(http.StatusOK))
+	g.Expect(log.Entries[0].Response).To(Equal(`{"value":"Test page"}`))
+}
+
+func TestWDProxyController_ProxyTracing(t *testing.T) {
+	g := NewGomegaWithT(t)
+	sr := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(noop.NewTracerProvider())
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator())
+	})
+
+	rt := new(mocks.RoundTripper)
+	cntr := NewProxyController(rt, nil, nil, nil, nil, noCommandTimeouts(t), zaptest.NewLogger(t))
+
+	u, err := url.Parse("http://host:3215/wd/hub/session/s1/url")
+	g.Expect(err).ToNot(HaveOccurred())
+	ctx, _ := getSessionContext(router.SessRoute("/wd/hub/session/:%s/*"), "/wd/hub/session/s1/url", "s1")
+	ctx.Set(ProxyURLKey, u)
+	ctx.Set(ProxyHostKey, "hst:123")
+
+	parent := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+	s := session.NewSession("s1", "LINUX", nil, nil, nil, time.Now(), nil, nil)
+	s.SetSpanContext(parent)
+	ctx.Set(SessionKey, s)
+
+	rt.EXPECT().RoundTrip(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.Header.Get("traceparent")).To(HavePrefix("00-" + parent.TraceID().String()))
+	}).Return(&http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Status:     "500 Internal Server Error",
+		Body:       io.NopCloser(strings.NewReader(``)),
+	}, nil)
+
+	g.Expect(cntr.Proxy(ctx)).To(Succeed())
+
+	spans := sr.Ended()
+	g.Expect(spans).To(HaveLen(1))
+	g.Expect(spans[0].Name()).To(Equal("webdriver.command"))
+	g.Expect(spans[0].Parent()).To(Equal(parent.WithRemote(true)))
+	g.Expect(spans[0].Status().Code).To(Equal(codes.Error))
+	g.Expect(spans[0].Attributes()).To(ContainElement(tracing.SessionIDKey.String("s1")))
+}
+
 func TestWDProxyController_VNCProxy(t *testing.T) {
 	g := NewGomegaWithT(t)
 	p := new(mocks.WSProxy)
-	cntr := NewProxyController(nil, p, zaptest.NewLogger(t))
+	cntr := NewProxyController(nil, p, nil, nil, nil, noCommandTimeouts(t), zaptest.NewLogger(t))
 
 	u, err := url.Parse("http://vnchost:4321/ignored")
 	g.Expect(err).ToNot(HaveOccurred())
 
 	e := echo.New()
+	sess := session.NewSession("s1", "LINUX", nil, nil, nil, time.Now(), nil, nil)
 	e.GET(router.SessRoute("/session/vnc/:%s"), cntr.VNCProxy, func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			c.Set(ProxyURLKey, u)
+			c.Set(SessionKey, sess)
 			return next(c)
 		}
 	})
@@ -210,8 +436,11 @@ func TestWDProxyController_VNCProxy(t *testing.T) {
 	hdr.Set("Origin", "http://testclient")
 
 	ch := make(chan struct{})
-	p.EXPECT().Handler("vnchost:4321").Return(func(conn *ws.Conn) {
-		close(ch)
+	p.EXPECT().Handler("vnchost:4321", mock.Anything).RunAndReturn(func(_ string, touch func()) ws.Handler {
+		return func(conn *ws.Conn) {
+			touch()
+			close(ch)
+		}
 	})
 
 	wc, r, err := d.Dial("ws://ignored/session/vnc/s1", hdr)
@@ -222,6 +451,7 @@ func TestWDProxyController_VNCProxy(t *testing.T) {
 	g.Expect(r).To(HaveHTTPStatus("101 Switching Protocols"))
 	p.AssertExpectations(t)
 	g.Eventually(ch).Should(BeClosed())
+	g.Expect(sess.LastUsed()).ToNot(BeZero())
 }
 
 func getWebdriverPortMock(port models.ContainerPort, hostport string) *mocks.Browser {
@@ -229,3 +459,11 @@ func getWebdriverPortMock(port models.ContainerPort, hostport string) *mocks.Bro
 	wd.EXPECT().GetHostPort(port).Return(hostport)
 	return wd
 }
+
+func noCommandTimeouts(t *testing.T) *mocks.CommandTimeoutConfig {
+	cfg := mocks.NewCommandTimeoutConfig(t)
+	cfg.EXPECT().CommandTimeout().Return(0)
+	cfg.EXPECT().CommandTimeoutOverrides().Return(nil)
+	cfg.EXPECT().CommandTimeoutKillAfter().Return(0)
+	return cfg
+}
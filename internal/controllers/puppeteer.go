@@ -0,0 +1,143 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/selebrow/selebrow/internal/common/clock"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/event"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+const (
+	puppeteerProxyServerArg = "--proxy-server"
+	puppeteerProxyBypassArg = "--proxy-bypass-list"
+)
+
+// PuppeteerController proxies Puppeteer websocket connection to the browser server container,
+// browser server is expected to accept Puppeteer launch options as JSON in launch-options query parameter
+type PuppeteerController struct {
+	sessions  *browserServerSessions
+	transport http.RoundTripper
+	now       clock.NowFunc
+	proxyArgs []string
+	l         *zap.SugaredLogger
+}
+
+type puppeteerLaunchOptions struct {
+	Args              []string          `json:"args,omitempty"`
+	Headless          *bool             `json:"headless,omitempty"`
+	IgnoreDefaultArgs []string          `json:"ignoreDefaultArgs,omitempty"`
+	Env               map[string]string `json:"env,omitempty"`
+}
+
+type puppeteerOptions struct {
+	browserServerOptions
+	LaunchOpts puppeteerLaunchOptions
+}
+
+func NewPuppeteerController(
+	svc session.SessionService,
+	transport http.RoundTripper,
+	eb event.EventBroker,
+	now clock.NowFunc,
+	opts *config.ProxyOpts,
+	l *zap.Logger,
+) *PuppeteerController {
+	var proxyArgs []string
+	if opts != nil && opts.ProxyHost != "" {
+		proxyArgs = append(proxyArgs, fmt.Sprintf("%s=%s", puppeteerProxyServerArg, opts.ProxyHost))
+		if opts.NoProxy != "" {
+			// chromium expects semicolon separated bypass list
+			proxyArgs = append(proxyArgs,
+				fmt.Sprintf("%s=%s", puppeteerProxyBypassArg, strings.ReplaceAll(opts.NoProxy, ",", ";")))
+		}
+	}
+	return &PuppeteerController{
+		sessions: &browserServerSessions{
+			protocol: models.PuppeteerProtocol,
+			svc:      svc,
+			eb:       eb,
+			now:      now,
+			l:        l.Sugar(),
+		},
+		transport: transport,
+		now:       now,
+		proxyArgs: proxyArgs,
+		l:         l.Sugar(),
+	}
+}
+
+func (p *PuppeteerController) CreateSession(c echo.Context) error {
+	opts, err := p.parseOptions(c)
+	return p.sessions.serve(c, &opts.browserServerOptions, opts.capabilities(), err, func(sess *session.Session) error {
+		launchOpts, err := json.Marshal(opts.LaunchOpts)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal launch options")
+		}
+		proxyBrowserSession(c, sess, p.transport, p.now, p.l, func(r *http.Request) {
+			r.Host = sess.Browser().GetURL().Host
+			r.URL = sess.Browser().GetURL()
+			r.URL.RawQuery = url.Values{PWLaunchOptionsParamQ: []string{string(launchOpts)}}.Encode()
+		})
+		return nil
+	})
+}
+
+func (p *PuppeteerController) ValidateSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return p.sessions.validateSession(next)
+}
+
+func (p *PuppeteerController) parseOptions(c echo.Context) (*puppeteerOptions, error) {
+	opts := &puppeteerOptions{browserServerOptions: newBrowserServerOptions(c)}
+
+	if launchOptsVal := c.QueryParam(PWLaunchOptionsParamQ); launchOptsVal != "" {
+		if err := json.Unmarshal([]byte(launchOptsVal), &opts.LaunchOpts); err != nil {
+			return opts, errors.Wrap(err, "malformed launch-options parameter")
+		}
+		for k, v := range opts.LaunchOpts.Env {
+			opts.Env = append(opts.Env, fmt.Sprintf("%s=%s", k, v))
+		}
+		opts.LaunchOpts.Env = nil // container env is enough
+	}
+
+	if args := c.QueryParams()[PWArgParamQ]; len(args) > 0 {
+		opts.LaunchOpts.Args = append(opts.LaunchOpts.Args, args...)
+	}
+
+	if ignoreDefaultArgs := c.QueryParams()[PWIgnoreDefaultArgParamQ]; len(ignoreDefaultArgs) > 0 {
+		opts.LaunchOpts.IgnoreDefaultArgs = append(opts.LaunchOpts.IgnoreDefaultArgs, ignoreDefaultArgs...)
+	}
+
+	if err := opts.parseHeadless(c, &opts.LaunchOpts.Headless); err != nil {
+		return opts, err
+	}
+
+	if err := opts.parseContainer(c); err != nil {
+		return opts, err
+	}
+
+	if len(p.proxyArgs) > 0 && !hasArg(opts.LaunchOpts.Args, puppeteerProxyServerArg) {
+		opts.LaunchOpts.Args = append(opts.LaunchOpts.Args, p.proxyArgs...)
+	}
+
+	return opts, nil
+}
+
+func hasArg(args []string, name string) bool {
+	for _, a := range args {
+		if a == name || strings.HasPrefix(a, name+"=") {
+			return true
+		}
+	}
+	return false
+}
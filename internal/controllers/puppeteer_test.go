@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/config"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestPuppeteerController_CreateSession(t *testing.T) {
+	tests := []struct {
+		name       string
+		params     url.Values
+		launchOpts string
+	}{
+		{
+			name: "Proxy args added",
+			params: url.Values{
+				"arg":              []string{"aaa"},
+				"ignoreDefaultArg": []string{"ccc"},
+				"headless":         []string{"false"},
+				"launch-options":   []string{`{"args": ["bbb"], "env": {"env1": "val1"}}`},
+			},
+			launchOpts: `{"args":["bbb","aaa","--proxy-server=proxy:1234","--proxy-bypass-list=1.1.1.1;.local"],` +
+				`"headless":false,"ignoreDefaultArgs":["ccc"]}`,
+		},
+		{
+			name: "User proxy preserved",
+			params: url.Values{
+				"arg":      []string{"--proxy-server=other:4321"},
+				"headless": []string{"false"},
+				"env":      []string{"env1=val1"},
+			},
+			launchOpts: `{"args":["--proxy-server=other:4321"],"headless":false}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			rt := new(mocks.RoundTripper)
+			s := new(mocks.SessionService)
+			eb := new(mocks.EventBroker)
+			now := setupNow(g, 111, 144)
+			pOpts := &config.ProxyOpts{
+				ProxyHost: "proxy:1234",
+				NoProxy:   "1.1.1.1,.local",
+			}
+			cntr := NewPuppeteerController(s, rt, eb, now, pOpts, zaptest.NewLogger(t))
+			br := new(mocks.Browser)
+			genRequestID = func() string { return "r1" }
+
+			u, err := url.Parse("http://host:1234/qqq")
+			g.Expect(err).ToNot(HaveOccurred())
+
+			ctx, rec := getPWContext("chrome", "", "v1", tt.params)
+			caps := &models.PWCapabilities{
+				Browser:    "chrome",
+				Version:    "v1",
+				VNCEnabled: true,
+				Env:        []string{"env1=val1"},
+			}
+			sess := createPWSession(br, caps, 122)
+			s.EXPECT().CreateSession(mock.Anything, caps).Return(sess, nil).Once()
+			s.EXPECT().DeleteSession(sess).Once()
+			br.EXPECT().GetURL().RunAndReturn(func() *url.URL {
+				uCopy := *u
+				return &uCopy
+			})
+
+			mockResp := &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`testdata`)),
+			}
+			rt.EXPECT().RoundTrip(mock.Anything).Run(func(req *http.Request) {
+				g.Expect(req.Host).To(Equal(u.Host))
+				g.Expect(req.URL.Path).To(Equal(u.Path))
+				g.Expect(req.URL.Query()).To(Equal(url.Values{"launch-options": []string{tt.launchOpts}}))
+			}).Return(mockResp, nil)
+
+			eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
+				g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes).To(Equal(evmodels.SessionRequested{
+					Protocol:       "puppeteer",
+					ID:             "12345",
+					RequestID:      "r1",
+					BrowserName:    "chrome",
+					BrowserVersion: "v1",
+					StartDuration:  11 * time.Millisecond,
+				}))
+			}).Once()
+			eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
+				g.Expect(e.(*evmodels.Event[evmodels.SessionReleased]).Attributes).To(Equal(evmodels.SessionReleased{
+					Protocol:        "puppeteer",
+					ID:              "12345",
+					BrowserName:     "chrome",
+					BrowserVersion:  "v1",
+					SessionDuration: 22 * time.Millisecond,
+				}))
+			}).Once()
+
+			err = cntr.CreateSession(ctx)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(rec.Code).To(Equal(http.StatusOK))
+			g.Expect(rec.Body.String()).To(Equal(`testdata`))
+			br.AssertExpectations(t)
+			s.AssertExpectations(t)
+			eb.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPuppeteerController_CreateSession_BadParameters(t *testing.T) {
+	tests := []struct {
+		name      string
+		params    url.Values
+		errRegexp string
+	}{
+		{
+			name:      "Bad headless",
+			params:    url.Values{"headless": []string{"aaa"}},
+			errRegexp: `.*bad headless.*`,
+		},
+		{
+			name:      "Bad resolution",
+			params:    url.Values{"resolution": []string{"3x5"}},
+			errRegexp: `.*incorrect resolution.*`,
+		},
+		{
+			name:      "Bad launch options",
+			params:    url.Values{"launch-options": []string{"qqqq"}},
+			errRegexp: `.*malformed launch-options.*`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			eb := new(mocks.EventBroker)
+			now := func() time.Time { return time.Time{} }
+			cntr := NewPuppeteerController(nil, nil, eb, now, nil, zaptest.NewLogger(t))
+			ctx, _ := getPWContext("chrome", "", "v1", tt.params)
+
+			eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
+				g.Expect(e.EventType()).To(Equal(evmodels.SessionRequestedEventType))
+				g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes).
+					To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+						"Protocol":  Equal(models.PuppeteerProtocol),
+						"Error":     MatchRegexp(tt.errRegexp),
+						"ErrorCode": Equal(http.StatusBadRequest),
+					}))
+			}).Once()
+
+			err := cntr.CreateSession(ctx)
+			g.Expect(err).To(MatchError(MatchRegexp(tt.errRegexp)))
+		})
+	}
+}
+
+func TestPuppeteerController_ValidateSession(t *testing.T) {
+	g := NewWithT(t)
+	srv := new(mocks.SessionService)
+	c := NewPuppeteerController(srv, nil, nil, nil, nil, zaptest.NewLogger(t))
+
+	s := &session.Session{}
+	srv.EXPECT().FindSession("s1").Return(s, nil).Once()
+	ctx, rec := getSessionContext(router.SessRoute("/sess/:%s"), "/sess/s1", "s1")
+	err := c.ValidateSession(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+	g.Expect(ctx.Get(SessionKey)).To(BeIdenticalTo(s))
+	srv.AssertExpectations(t)
+}
@@ -1,22 +1,24 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"maps"
 	"net/http"
-	"net/http/httputil"
 	"net/url"
+	"path"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/selebrow/selebrow/internal/common/clock"
 	"github.com/selebrow/selebrow/internal/router"
 	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/browsers"
 	"github.com/selebrow/selebrow/pkg/config"
 	"github.com/selebrow/selebrow/pkg/event"
-	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 
 	"github.com/labstack/echo/v4"
@@ -37,21 +39,30 @@ const (
 	PWLabelParamQ            = "label"
 	PWFirefoxUserPrefParamQ  = "firefoxUserPref"
 	PWIgnoreDefaultArgParamQ = "ignoreDefaultArg"
+	PWSessionTimeoutParamQ   = "sessionTimeout"
 
 	PWLaunchOptionsParamQ   = "launch-options"
 	PWCcontextOptionsParamQ = "context-options"
+
+	// DevTools server rejects requests with Host header other than IP address or localhost
+	devtoolsHost = "localhost"
+
+	PWVersionHeader = "x-playwright-version"
 )
 
 var (
 	//nolint:gocritic // gocritic suggests wrong regex
 	resolutionRegex = regexp.MustCompile(`^(|\d+x\d+x\d+)$`)
 	pwEnvRegex      = regexp.MustCompile(`(?i)^[0-9A-Z_\-.]*$`)
+	// Playwright clients send user agent like "Playwright/1.48.1 (x64; ubuntu 22.04) node/20.11"
+	pwUserAgentRegex  = regexp.MustCompile(`\bPlaywright/(\d+\.\d+\S*)`)
+	pwMajorMinorRegex = regexp.MustCompile(`^\d+\.\d+`)
 )
 
 type PWController struct {
-	svc       session.SessionService
+	sessions  *browserServerSessions
+	cat       browsers.BrowsersCatalog
 	transport http.RoundTripper
-	eb        event.EventBroker
 	now       clock.NowFunc
 	proxyOpts *pwProxyOptions
 	l         *zap.SugaredLogger
@@ -74,23 +85,21 @@ type pwProxyOptions struct {
 
 type pwContextOptions map[string]any
 
+type cdpVersion struct {
+	Browser              string `json:"Browser,omitempty"`
+	WebSocketDebuggerURL string `json:"webSocketDebuggerUrl"`
+}
+
 type pwOptions struct {
-	Flavor      string
-	Name        string
-	Version     string
+	browserServerOptions
 	LaunchOpts  pwLaunchOptions
 	ContextOpts pwContextOptions
-	VNCEnabled  bool
-	Resolution  string
-	Env         []string
-	Links       []string
-	Hosts       []string
-	Networks    []string
-	Labels      map[string]string
+	CDP         bool
 }
 
 func NewPWController(
 	svc session.SessionService,
+	cat browsers.BrowsersCatalog,
 	transport http.RoundTripper,
 	eb event.EventBroker,
 	now clock.NowFunc,
@@ -105,9 +114,15 @@ func NewPWController(
 		}
 	}
 	return &PWController{
-		svc:       svc,
+		sessions: &browserServerSessions{
+			protocol: models.PlaywrightProtocol,
+			svc:      svc,
+			eb:       eb,
+			now:      now,
+			l:        l.Sugar(),
+		},
+		cat:       cat,
 		transport: transport,
-		eb:        eb,
 		now:       now,
 		proxyOpts: proxyOpts,
 		l:         l.Sugar(),
@@ -115,137 +130,141 @@ func NewPWController(
 }
 
 func (p *PWController) CreateSession(c echo.Context) error {
-	opts, err := p.parsePWOptions(c)
-	ev := evmodels.SessionRequested{
-		Protocol:       models.PlaywrightProtocol,
-		BrowserName:    opts.Name,
-		BrowserVersion: opts.Version,
-	}
-	if err != nil {
-		ev.Error = models.NewBadRequestError(err)
-		defer p.eb.Publish(evmodels.NewSessionRequestedEvent(ev))
-		return ev.Error
+	return p.serveSession(c, false, func(sess *session.Session, opts *pwOptions) error {
+		p.proxyBrowserServer(c, sess, opts)
+		return nil
+	})
+}
+
+// CDP allocates Chromium browser exposing DevTools port and proxies raw Chrome DevTools Protocol websocket to it
+func (p *PWController) CDP(c echo.Context) error {
+	if !c.IsWebSocket() {
+		return models.NewBadRequestError(errors.Errorf("websocket connection is expected, use %s to discover endpoint",
+			path.Join(c.Request().URL.Path, router.CDPVersionPath)))
 	}
+	return p.serveSession(c, true, func(sess *session.Session, _ *pwOptions) error {
+		u, err := p.getDebuggerURL(c.Request().Context(), sess)
+		if err != nil {
+			p.l.Errorw("failed to get devtools websocket url", zap.String("session_id", sess.ID()), zap.Error(err))
+			return models.NewErrorMessage(http.StatusBadGateway, err)
+		}
+		proxyBrowserSession(c, sess, p.transport, p.now, p.l, func(r *http.Request) {
+			r.Host = devtoolsHost
+			r.URL = u
+		})
+		return nil
+	})
+}
 
-	sess, err := p.createSession(c, ev, opts)
-	if err != nil {
-		return err
+// CDPVersion mimics /json/version DevTools endpoint for clients discovering websocket url by http endpoint,
+// browser is allocated only when websocket is connected
+func (p *PWController) CDPVersion(c echo.Context) error {
+	scheme := "ws"
+	if c.Scheme() == "https" {
+		scheme = "wss"
+	}
+	wsURL := url.URL{
+		Scheme:   scheme,
+		Host:     c.Request().Host,
+		Path:     strings.TrimSuffix(strings.TrimSuffix(c.Request().URL.Path, "/"), router.CDPVersionPath),
+		RawQuery: c.QueryString(),
 	}
 
-	defer func() {
-		ev := evmodels.SessionReleased{
-			Protocol:        models.PlaywrightProtocol,
-			BrowserName:     opts.Name,
-			BrowserVersion:  opts.Version,
-			SessionDuration: p.now().Sub(sess.Created()),
-		}
-		p.eb.Publish(evmodels.NewSessionReleasedEvent(ev))
-		p.svc.DeleteSession(sess)
-	}()
-
-	// XXX We need to use wrapped context here to allow resetting connections from UI
-	// that's a bit awkward, need to think about resetting connections from Browser.Close()
-	c.SetRequest(c.Request().Clone(sess.Context()))
-	(&httputil.ReverseProxy{
-		Transport: p.transport,
-		Director: func(r *http.Request) {
-			r.Host = sess.Browser().GetURL().Host
-			r.URL = sess.Browser().GetURL()
-			q := make(url.Values)
-			if len(opts.LaunchOpts.Args) > 0 {
-				q[PWArgParamQ] = opts.LaunchOpts.Args
-			}
-			if opts.LaunchOpts.Headless != nil {
-				q.Set(PWHeadlessParamQ, strconv.FormatBool(*opts.LaunchOpts.Headless))
-			}
-			launchOptsVal, err := json.Marshal(opts.LaunchOpts)
-			if err == nil { // actually always true
-				q.Set(PWLaunchOptionsParamQ, string(launchOptsVal))
-			}
-			if len(opts.ContextOpts) > 0 {
-				contextOptsVal, err := json.Marshal(opts.ContextOpts)
-				if err == nil { // actually always true
-					q.Set(PWCcontextOptionsParamQ, string(contextOptsVal))
-				}
-			}
-			r.URL.RawQuery = q.Encode()
-		},
-		ErrorHandler: p.defaultErrorHandler(c.RealIP()),
-	}).ServeHTTP(c.Response(), c.Request())
+	browser := c.Param(router.NameParam)
+	if v := c.Param(router.VersionParam); v != "" {
+		browser += "/" + v
+	}
+	return c.JSON(http.StatusOK, cdpVersion{
+		Browser:              browser,
+		WebSocketDebuggerURL: wsURL.String(),
+	})
+}
 
-	return nil
+func (p *PWController) serveSession(
+	c echo.Context,
+	cdp bool,
+	serve func(sess *session.Session, opts *pwOptions) error,
+) error {
+	opts, err := p.parsePWOptions(c)
+	opts.CDP = cdp
+	if err == nil && !cdp {
+		err = p.resolveClientVersion(c, opts)
+	}
+	caps := opts.capabilities()
+	caps.CDP = opts.CDP
+	return p.sessions.serve(c, &opts.browserServerOptions, caps, err, func(sess *session.Session) error {
+		return serve(sess, opts)
+	})
 }
 
-func (s *PWController) ValidateSession(next echo.HandlerFunc) echo.HandlerFunc {
-	return func(c echo.Context) error {
-		id := c.Param(router.SessionParam)
-		i := strings.Index(c.Path(), fmt.Sprintf("/:%s", router.SessionParam))
-		if i < 0 {
-			s.l.Panicf("Middleware applied to the wrong route: %s", c.Path())
+func (p *PWController) proxyBrowserServer(c echo.Context, sess *session.Session, opts *pwOptions) {
+	proxyBrowserSession(c, sess, p.transport, p.now, p.l, func(r *http.Request) {
+		r.Host = sess.Browser().GetURL().Host
+		r.URL = sess.Browser().GetURL()
+		q := make(url.Values)
+		if len(opts.LaunchOpts.Args) > 0 {
+			q[PWArgParamQ] = opts.LaunchOpts.Args
 		}
-
-		sess, err := s.svc.FindSession(id)
-		if err != nil {
-			return models.NewNotFoundError(err)
+		if opts.LaunchOpts.Headless != nil {
+			q.Set(PWHeadlessParamQ, strconv.FormatBool(*opts.LaunchOpts.Headless))
+		}
+		launchOptsVal, err := json.Marshal(opts.LaunchOpts)
+		if err == nil { // actually always true
+			q.Set(PWLaunchOptionsParamQ, string(launchOptsVal))
+		}
+		if len(opts.ContextOpts) > 0 {
+			contextOptsVal, err := json.Marshal(opts.ContextOpts)
+			if err == nil { // actually always true
+				q.Set(PWCcontextOptionsParamQ, string(contextOptsVal))
+			}
 		}
+		r.URL.RawQuery = q.Encode()
+	})
+}
 
-		c.Set(SessionKey, sess)
-		return next(c)
+// getDebuggerURL resolves browser websocket url of DevTools server running in the container
+func (p *PWController) getDebuggerURL(ctx context.Context, sess *session.Session) (*url.URL, error) {
+	hp := sess.Browser().GetHostPort(models.DevtoolsPort)
+	u := &url.URL{Scheme: "http", Host: hp, Path: router.CDPVersionPath}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create request")
 	}
-}
+	req.Host = devtoolsHost
 
-func (p *PWController) createSession(c echo.Context, ev evmodels.SessionRequested, opts *pwOptions) (*session.Session, error) {
-	defer func() {
-		if r := recover(); r != nil {
-			ev.Error = models.NewInternalServerError(errors.Errorf("panic: %v", r))
-			p.eb.Publish(evmodels.NewSessionRequestedEvent(ev))
-			panic(r)
-		}
-		p.eb.Publish(evmodels.NewSessionRequestedEvent(ev))
-	}()
-
-	caps := &models.PWCapabilities{
-		Flavor:           opts.Flavor,
-		Browser:          opts.Name,
-		Version:          opts.Version,
-		VNCEnabled:       opts.VNCEnabled,
-		ScreenResolution: opts.Resolution,
-		Env:              opts.Env,
-		Links:            opts.Links,
-		Hosts:            opts.Hosts,
-		Networks:         opts.Networks,
-		Labels:           opts.Labels,
-	}
-
-	start := p.now()
-	sess, err := p.svc.CreateSession(c.Request().Context(), caps)
+	resp, err := p.transport.RoundTrip(req)
 	if err != nil {
-		p.l.Errorw("failed to create playwright session", zap.Error(err))
-		ev.Error = models.WrapCancelledErr(err)
-		return nil, ev.Error
+		return nil, errors.Wrap(err, "devtools version request failed")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("devtools version request failed with code %d", resp.StatusCode)
 	}
-	ev.StartDuration = sess.Created().Sub(start)
-	return sess, nil
-}
 
-func (p *PWController) defaultErrorHandler(remote string) func(http.ResponseWriter, *http.Request, error) {
-	return func(w http.ResponseWriter, r *http.Request, err error) {
-		msg := fmt.Sprintf("proxy error %s->%v", remote, r.URL)
-		p.l.Errorw(msg, zap.Error(err))
-		w.WriteHeader(http.StatusBadGateway)
-		//nolint:gosec // plain text error response, no user-controlled HTML
-		if _, respErr := fmt.Fprintf(w, "%s: %v", msg, err); respErr != nil {
-			p.l.Errorw("write error", zap.Error(respErr))
-		}
+	var v cdpVersion
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return nil, errors.Wrap(err, "failed to decode devtools version response")
 	}
+	wsURL, err := url.Parse(v.WebSocketDebuggerURL)
+	if err != nil || wsURL.Path == "" {
+		return nil, errors.Errorf("malformed webSocketDebuggerUrl: %s", v.WebSocketDebuggerURL)
+	}
+	// DevTools reports its own listen address which is not reachable from outside the container
+	return &url.URL{Scheme: "http", Host: hp, Path: wsURL.Path}, nil
+}
+
+func (p *PWController) ValidateSession(next echo.HandlerFunc) echo.HandlerFunc {
+	return p.sessions.validateSession(next)
 }
 
 //nolint:gocyclo,gocognit,funlen // does not make sense to split
 func (p *PWController) parsePWOptions(c echo.Context) (*pwOptions, error) {
-	opts := &pwOptions{
-		Flavor:  c.QueryParam(router.FlavorQParam),
-		Name:    c.Param(router.NameParam),
-		Version: c.Param(router.VersionParam),
+	opts := &pwOptions{browserServerOptions: newBrowserServerOptions(c)}
+
+	if p.cat != nil {
+		if err := validateBrowser(p.cat, models.PlaywrightProtocol, opts.Name, opts.Version, opts.Flavor); err != nil {
+			return opts, err
+		}
 	}
 
 	if launchOptsVal := c.QueryParam(PWLaunchOptionsParamQ); launchOptsVal != "" {
@@ -281,77 +300,108 @@ func (p *PWController) parsePWOptions(c echo.Context) (*pwOptions, error) {
 		opts.LaunchOpts.Channel = channel
 	}
 
-	if vnc := c.QueryParam(PWVncParamQ); vnc != "" {
-		v, err := strconv.ParseBool(vnc)
-		if err != nil {
-			return opts, errors.Wrap(err, "bad vnc parameter")
-		}
-		opts.VNCEnabled = v
-		opts.LaunchOpts.Headless = ref(!v)
+	if err := opts.parseHeadless(c, &opts.LaunchOpts.Headless); err != nil {
+		return opts, err
+	}
+
+	if err := opts.parseContainer(c); err != nil {
+		return opts, err
 	}
 
-	if headless := c.QueryParam(PWHeadlessParamQ); headless != "" {
-		h, err := strconv.ParseBool(headless)
+	if prefs := c.QueryParams()[PWFirefoxUserPrefParamQ]; len(prefs) > 0 {
+		ffUserPrefs, err := parseFirefoxUserPrefs(prefs)
 		if err != nil {
-			return opts, errors.Wrap(err, "bad headless parameter")
+			return opts, errors.Wrap(err, "bad firefoxUserPrefs")
+		}
+		if opts.LaunchOpts.FirefoxUserPrefs == nil {
+			opts.LaunchOpts.FirefoxUserPrefs = make(map[string]any)
 		}
-		opts.LaunchOpts.Headless = ref(h)
+		maps.Copy(opts.LaunchOpts.FirefoxUserPrefs, ffUserPrefs)
 	}
 
-	if opts.LaunchOpts.Headless != nil && !*opts.LaunchOpts.Headless {
-		opts.VNCEnabled = true
+	if p.cat != nil {
+		ic, ok := p.cat.LookupBrowserImage(models.PlaywrightProtocol, opts.Name, opts.Flavor)
+		if ok && len(ic.DefaultCapabilities) > 0 {
+			if err := opts.LaunchOpts.mergeDefaults(ic.DefaultCapabilities); err != nil {
+				return opts, errors.Wrap(err, "bad default launch options")
+			}
+		}
 	}
 
-	if res := c.QueryParam(PWResolutionParamQ); res != "" {
-		if !resolutionRegex.MatchString(res) {
-			return opts, errors.New("incorrect resolution parameter format (expected WIDTHxHEIGHTxBPP)")
-		}
-		opts.Resolution = res
+	if p.proxyOpts != nil && (opts.LaunchOpts.Proxy == nil || opts.LaunchOpts.Proxy.Server == "") {
+		opts.LaunchOpts.Proxy = p.proxyOpts
 	}
 
-	if env := c.QueryParams()[PWEnvParamQ]; len(env) > 0 {
-		if err := validatePlaywrightEnv(env); err != nil {
-			return opts, errors.Wrap(err, "bad environment")
+	return opts, nil
+}
+
+// resolveClientVersion picks browser server version matching Playwright client when version is omitted in the path
+// and rejects requested versions which client won't be able to talk to
+func (p *PWController) resolveClientVersion(c echo.Context, opts *pwOptions) error {
+	clientVersion := pwClientVersion(c.Request())
+	clientMajorMinor := pwMajorMinorRegex.FindString(clientVersion)
+	if clientMajorMinor == "" || p.cat == nil {
+		return nil
+	}
+	if opts.Version != "" {
+		if mm := pwMajorMinorRegex.FindString(opts.Version); mm == "" || mm == clientMajorMinor {
+			return nil
 		}
-		opts.Env = append(opts.Env, env...)
 	}
 
-	if links := c.QueryParams()[PWLinkParamQ]; len(links) > 0 {
-		opts.Links = links
+	ic, ok := p.cat.LookupBrowserImage(models.PlaywrightProtocol, opts.Name, opts.Flavor)
+	if !ok {
+		// unknown browser is reported by session service
+		return nil
 	}
+	versions := slices.SortedFunc(maps.Keys(ic.VersionTags), func(a, b string) int {
+		return compareVersions(b, a)
+	})
 
-	if hosts := c.QueryParams()[PWHostParamQ]; len(hosts) > 0 {
-		opts.Hosts = hosts
+	if opts.Version != "" {
+		return errors.Errorf("playwright client version %s does not match requested server version %s, available versions: %s",
+			clientVersion, opts.Version, strings.Join(versions, ", "))
 	}
-
-	if networks := c.QueryParams()[PWNetworkParamQ]; len(networks) > 0 {
-		opts.Networks = networks
+	if slices.Contains(versions, clientVersion) {
+		opts.Version = clientVersion
+		return nil
 	}
-
-	if labels := c.QueryParams()[PWLabelParamQ]; len(labels) > 0 {
-		labelsMap, err := parsePlaywrightLabels(labels)
-		if err != nil {
-			return opts, errors.Wrap(err, "bad label")
+	for _, v := range versions {
+		if pwMajorMinorRegex.FindString(v) == clientMajorMinor {
+			opts.Version = v
+			return nil
 		}
-		opts.Labels = labelsMap
 	}
+	return errors.Errorf("no %s server matching playwright client version %s, available versions: %s",
+		opts.Name, clientVersion, strings.Join(versions, ", "))
+}
 
-	if prefs := c.QueryParams()[PWFirefoxUserPrefParamQ]; len(prefs) > 0 {
-		ffUserPrefs, err := parseFirefoxUserPrefs(prefs)
-		if err != nil {
-			return opts, errors.Wrap(err, "bad firefoxUserPrefs")
-		}
-		if opts.LaunchOpts.FirefoxUserPrefs == nil {
-			opts.LaunchOpts.FirefoxUserPrefs = make(map[string]any)
-		}
-		maps.Copy(opts.LaunchOpts.FirefoxUserPrefs, ffUserPrefs)
+func pwClientVersion(r *http.Request) string {
+	if v := r.Header.Get(PWVersionHeader); v != "" {
+		return v
 	}
-
-	if p.proxyOpts != nil && (opts.LaunchOpts.Proxy == nil || opts.LaunchOpts.Proxy.Server == "") {
-		opts.LaunchOpts.Proxy = p.proxyOpts
+	if m := pwUserAgentRegex.FindStringSubmatch(r.UserAgent()); m != nil {
+		return m[1]
 	}
+	return ""
+}
 
-	return opts, nil
+// mergeDefaults applies launch options defaults configured in the catalog, requested options take precedence
+// (see models.MergeDefaults: maps are merged while requested lists like args replace the default ones)
+func (o *pwLaunchOptions) mergeDefaults(defaults map[string]interface{}) error {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return err
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(b, &merged); err != nil {
+		return err
+	}
+	models.MergeDefaults(merged, defaults)
+	if b, err = json.Marshal(merged); err != nil {
+		return err
+	}
+	return json.Unmarshal(b, o)
 }
 
 func validateLaunchOpts(opts pwLaunchOptions) error {
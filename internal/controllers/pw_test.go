@@ -3,6 +3,7 @@ package controllers
 import (
 	"context"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -13,7 +14,6 @@ import (
 	"github.com/labstack/echo/v4"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gstruct"
-	"github.com/onsi/gomega/types"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap/zaptest"
@@ -23,75 +23,82 @@ import (
 	"github.com/selebrow/selebrow/mocks"
 	"github.com/selebrow/selebrow/pkg/capabilities"
 	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/dto"
+	"github.com/selebrow/selebrow/pkg/event"
 	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
 func TestPWController_CreateSession_BadParameters(t *testing.T) {
 	tests := []struct {
-		name     string
-		params   url.Values
-		errMatch types.GomegaMatcher
+		name      string
+		params    url.Values
+		errRegexp string
 	}{
 		{
-			name:     "Bad headless",
-			params:   url.Values{"headless": []string{"aaa"}},
-			errMatch: MatchError(MatchRegexp(`.*bad headless.*`)),
+			name:      "Bad headless",
+			params:    url.Values{"headless": []string{"aaa"}},
+			errRegexp: `.*bad headless.*`,
 		},
 		{
-			name:     "Bad vnc",
-			params:   url.Values{"vnc": []string{"111"}},
-			errMatch: MatchError(MatchRegexp(`.*bad vnc.*`)),
+			name:      "Bad vnc",
+			params:    url.Values{"vnc": []string{"111"}},
+			errRegexp: `.*bad vnc.*`,
 		},
 		{
-			name:     "Bad resolution",
-			params:   url.Values{"resolution": []string{"3x5"}},
-			errMatch: MatchError(MatchRegexp(`.*incorrect resolution.*`)),
+			name:      "Bad resolution",
+			params:    url.Values{"resolution": []string{"3x5"}},
+			errRegexp: `.*incorrect resolution.*`,
 		},
 		{
-			name:     "Bad env",
-			params:   url.Values{"env": []string{"qqqq"}},
-			errMatch: MatchError(MatchRegexp(`.*malformed env param.*`)),
+			name:      "Bad env",
+			params:    url.Values{"env": []string{"qqqq"}},
+			errRegexp: `.*malformed env param.*`,
 		},
 		{
-			name:     "Bad env name",
-			params:   url.Values{"env": []string{"qqq/q=wwww"}},
-			errMatch: MatchError(MatchRegexp(`.*invalid env name.*`)),
+			name:      "Bad env name",
+			params:    url.Values{"env": []string{"qqq/q=wwww"}},
+			errRegexp: `.*invalid env name.*`,
 		},
 		{
-			name:     "Bad label",
-			params:   url.Values{"label": []string{"qqqq<wwww"}},
-			errMatch: MatchError(MatchRegexp(`.*bad label.*`)),
+			name:      "Bad label",
+			params:    url.Values{"label": []string{"qqqq<wwww"}},
+			errRegexp: `.*bad label.*`,
 		},
 		{
-			name:     "Bad firefoxUserPref",
-			params:   url.Values{"firefoxUserPref": []string{"qqqq"}},
-			errMatch: MatchError(MatchRegexp(`.*bad firefoxUserPrefs.*`)),
+			name:      "Bad firefoxUserPref",
+			params:    url.Values{"firefoxUserPref": []string{"qqqq"}},
+			errRegexp: `.*bad firefoxUserPrefs.*`,
 		},
 		{
-			name:     "Bad launch options",
-			params:   url.Values{"launch-options": []string{"qqqq"}},
-			errMatch: MatchError(MatchRegexp(`.*malformed launch-options.*`)),
+			name:      "Bad sessionTimeout",
+			params:    url.Values{"sessionTimeout": []string{"5"}},
+			errRegexp: `.*bad sessionTimeout.*`,
 		},
 		{
-			name:     "Bad context options",
-			params:   url.Values{"context-options": []string{"qqqq"}},
-			errMatch: MatchError(MatchRegexp(`.*malformed context-options.*`)),
+			name:      "Bad launch options",
+			params:    url.Values{"launch-options": []string{"qqqq"}},
+			errRegexp: `.*malformed launch-options.*`,
 		},
 		{
-			name:     "Bad launch options firefoxUserPrefs (object)",
-			params:   url.Values{"launch-options": []string{`{"firefoxUserPrefs": {"test": {"key": 1234}}}`}},
-			errMatch: MatchError(MatchRegexp(`.*bad launch options.*invalid firefoxUserPref.*`)),
+			name:      "Bad context options",
+			params:    url.Values{"context-options": []string{"qqqq"}},
+			errRegexp: `.*malformed context-options.*`,
 		},
 		{
-			name:     "Bad launch options firefoxUserPrefs (array)",
-			params:   url.Values{"launch-options": []string{`{"firefoxUserPrefs": {"test": [1234]}}`}},
-			errMatch: MatchError(MatchRegexp(`.*bad launch options.*invalid firefoxUserPref.*`)),
+			name:      "Bad launch options firefoxUserPrefs (object)",
+			params:    url.Values{"launch-options": []string{`{"firefoxUserPrefs": {"test": {"key": 1234}}}`}},
+			errRegexp: `.*bad launch options.*invalid firefoxUserPref.*`,
 		},
 		{
-			name:     "Bad launch options firefoxUserPrefs (null)",
-			params:   url.Values{"launch-options": []string{`{"firefoxUserPrefs": {"test": null}}`}},
-			errMatch: MatchError(MatchRegexp(`.*bad launch options.*invalid firefoxUserPref.*`)),
+			name:      "Bad launch options firefoxUserPrefs (array)",
+			params:    url.Values{"launch-options": []string{`{"firefoxUserPrefs": {"test": [1234]}}`}},
+			errRegexp: `.*bad launch options.*invalid firefoxUserPref.*`,
+		},
+		{
+			name:      "Bad launch options firefoxUserPrefs (null)",
+			params:    url.Values{"launch-options": []string{`{"firefoxUserPrefs": {"test": null}}`}},
+			errRegexp: `.*bad launch options.*invalid firefoxUserPref.*`,
 		},
 	}
 	for _, tt := range tests {
@@ -99,7 +106,7 @@ func TestPWController_CreateSession_BadParameters(t *testing.T) {
 			g := NewWithT(t)
 			eb := new(mocks.EventBroker)
 			now := func() time.Time { return time.Time{} }
-			cntr := NewPWController(nil, nil, eb, now, nil, zaptest.NewLogger(t))
+			cntr := NewPWController(nil, nil, nil, eb, now, nil, zaptest.NewLogger(t))
 			ctx, _ := getPWContext("chrome", "def", "v1", tt.params)
 
 			eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
@@ -109,12 +116,13 @@ func TestPWController_CreateSession_BadParameters(t *testing.T) {
 						"Protocol":       Equal(models.BrowserProtocol("playwright")),
 						"BrowserName":    Equal("chrome"),
 						"BrowserVersion": Equal("v1"),
-						"Error":          tt.errMatch,
+						"Error":          MatchRegexp(tt.errRegexp),
+						"ErrorCode":      Equal(http.StatusBadRequest),
 					}))
 			}).Once()
 
 			err := cntr.CreateSession(ctx)
-			g.Expect(err).To(tt.errMatch)
+			g.Expect(err).To(MatchError(MatchRegexp(tt.errRegexp)))
 		})
 	}
 }
@@ -129,7 +137,7 @@ func TestPWController_CreateSession(t *testing.T) {
 		ProxyHost: "proxy:1234",
 		NoProxy:   "1.1.1.1",
 	}
-	cntr := NewPWController(s, rt, eb, now, pOpts, zaptest.NewLogger(t))
+	cntr := NewPWController(s, nil, rt, eb, now, pOpts, zaptest.NewLogger(t))
 	br := new(mocks.Browser)
 
 	u, err := url.Parse("http://host:1234/qqq")
@@ -147,6 +155,7 @@ func TestPWController_CreateSession(t *testing.T) {
 		"host":             []string{"h1", "h2"},
 		"network":          []string{"n1", "n2"},
 		"channel":          []string{"test"},
+		"sessionTimeout":   []string{"5m"},
 		"firefoxUserPref":  []string{"k1=true", "k2=123", "k3=false", "k4=abc"},
 		"launch-options":   []string{`{"args": ["ccc"], "ignoreDefaultArgs": ["ddd"], "env": {"env1": "val1"}}`},
 		"context-options":  []string{`{"userAgent": "test"}`},
@@ -163,9 +172,15 @@ func TestPWController_CreateSession(t *testing.T) {
 		Hosts:            []string{"h1", "h2"},
 		Networks:         []string{"n1", "n2"},
 		Labels:           map[string]string{"l1": "v1", "l2": "v2"},
+		Timeout:          5 * time.Minute,
 	}
 	sess := createPWSession(br, caps, 122)
-	s.EXPECT().CreateSession(context.Background(), caps).Return(sess, nil).Once()
+	genRequestID = func() string { return "r1" }
+	s.EXPECT().CreateSession(mock.Anything, caps).RunAndReturn(
+		func(ctx context.Context, _ capabilities.Capabilities) (*session.Session, error) {
+			g.Expect(event.ContextRequestID(ctx)).To(Equal("r1"))
+			return sess, nil
+		}).Once()
 	s.EXPECT().DeleteSession(sess).Once()
 	br.EXPECT().GetURL().RunAndReturn(func() *url.URL {
 		uCopy := *u
@@ -195,18 +210,22 @@ func TestPWController_CreateSession(t *testing.T) {
 		g.Expect(e.EventType()).To(Equal(evmodels.SessionRequestedEventType))
 		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes).To(Equal(evmodels.SessionRequested{
 			Protocol:       "playwright",
+			ID:             "12345",
+			RequestID:      "r1",
 			BrowserName:    "test",
 			BrowserVersion: "v1",
+			Labels:         map[string]string{"l1": "v1", "l2": "v2"},
 			StartDuration:  11 * time.Millisecond,
-			Error:          nil,
 		}))
 	}).Once()
 
 	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
 		g.Expect(e.(*evmodels.Event[evmodels.SessionReleased]).Attributes).To(Equal(evmodels.SessionReleased{
 			Protocol:        "playwright",
+			ID:              "12345",
 			BrowserName:     "test",
 			BrowserVersion:  "v1",
+			Labels:          map[string]string{"l1": "v1", "l2": "v2"},
 			SessionDuration: 22 * time.Millisecond,
 		}))
 	}).Once()
@@ -221,6 +240,292 @@ func TestPWController_CreateSession(t *testing.T) {
 	eb.AssertExpectations(t)
 }
 
+func TestPWController_CDP(t *testing.T) {
+	g := NewWithT(t)
+	rt := new(mocks.RoundTripper)
+	s := new(mocks.SessionService)
+	eb := new(mocks.EventBroker)
+	now := setupNow(g, 111, 144)
+	cntr := NewPWController(s, nil, rt, eb, now, nil, zaptest.NewLogger(t))
+	br := new(mocks.Browser)
+
+	ctx, rec := getPWContext("chromium", "", "120.0", url.Values{"vnc": []string{"true"}})
+	ctx.Request().Header.Set(echo.HeaderUpgrade, "websocket")
+	caps := &models.PWCapabilities{Browser: "chromium", Version: "120.0", VNCEnabled: true, CDP: true}
+	sess := createPWSession(br, caps, 122)
+	s.EXPECT().CreateSession(mock.Anything, caps).Return(sess, nil).Once()
+	s.EXPECT().DeleteSession(sess).Once()
+	br.EXPECT().GetHostPort(models.DevtoolsPort).Return("host:9222")
+
+	rt.EXPECT().RoundTrip(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.URL.String()).To(Equal("http://host:9222/json/version"))
+		g.Expect(req.Host).To(Equal("localhost"))
+	}).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"webSocketDebuggerUrl":"ws://127.0.0.1:9222/devtools/browser/abc"}`)),
+	}, nil).Once()
+	rt.EXPECT().RoundTrip(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.URL.String()).To(Equal("http://host:9222/devtools/browser/abc"))
+		g.Expect(req.Host).To(Equal("localhost"))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`testdata`))}, nil).Once()
+
+	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
+		g.Expect(e.EventType()).To(Equal(evmodels.SessionRequestedEventType))
+	}).Once()
+	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
+		g.Expect(e.EventType()).To(Equal(evmodels.SessionReleasedEventType))
+	}).Once()
+
+	g.Expect(cntr.CDP(ctx)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Body.String()).To(Equal(`testdata`))
+	rt.AssertExpectations(t)
+	br.AssertExpectations(t)
+	s.AssertExpectations(t)
+	eb.AssertExpectations(t)
+}
+
+func TestPWController_CDP_Errors(t *testing.T) {
+	g := NewWithT(t)
+	rt := new(mocks.RoundTripper)
+	s := new(mocks.SessionService)
+	eb := new(mocks.EventBroker)
+	cntr := NewPWController(s, nil, rt, eb, func() time.Time { return time.UnixMilli(111) }, nil, zaptest.NewLogger(t))
+
+	ctx, _ := getPWContext("chromium", "", "", nil)
+	err := cntr.CDP(ctx)
+	var e models.ErrorWithCode
+	g.Expect(errors.As(err, &e)).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusBadRequest))
+
+	ctx, _ = getPWContext("chromium", "", "", nil)
+	ctx.Request().Header.Set(echo.HeaderUpgrade, "websocket")
+	br := new(mocks.Browser)
+	caps := &models.PWCapabilities{Browser: "chromium", CDP: true}
+	sess := createPWSession(br, caps, 111)
+	s.EXPECT().CreateSession(mock.Anything, caps).Return(sess, nil).Once()
+	s.EXPECT().DeleteSession(sess).Once()
+	br.EXPECT().GetHostPort(models.DevtoolsPort).Return("host:9222")
+	rt.EXPECT().RoundTrip(mock.Anything).
+		Return(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(``))}, nil).Once()
+	eb.EXPECT().Publish(mock.Anything).Twice()
+
+	err = cntr.CDP(ctx)
+	g.Expect(err).To(MatchError("devtools version request failed with code 404"))
+	g.Expect(errors.As(err, &e)).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusBadGateway))
+
+	s.AssertExpectations(t)
+	eb.AssertExpectations(t)
+}
+
+func TestPWController_CDPVersion(t *testing.T) {
+	g := NewWithT(t)
+	cntr := NewPWController(nil, nil, nil, nil, nil, nil, zaptest.NewLogger(t))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "http://selebrow:4444/cdp/chromium/120.0/json/version/?vnc=true", http.NoBody)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames(router.NameParam, router.VersionParam)
+	c.SetParamValues("chromium", "120.0")
+
+	g.Expect(cntr.CDPVersion(c)).To(Succeed())
+	g.Expect(rec.Code).To(Equal(http.StatusOK))
+	g.Expect(rec.Body.String()).To(MatchJSON(
+		`{"Browser":"chromium/120.0","webSocketDebuggerUrl":"ws://selebrow:4444/cdp/chromium/120.0?vnc=true"}`))
+}
+
+func TestPWController_ResolveClientVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		version   string
+		header    string
+		userAgent string
+		expected  string
+		errMsg    string
+	}{
+		{
+			name:     "No client version",
+			expected: "",
+		},
+		{
+			name:     "Exact version from header",
+			header:   "1.47.0",
+			expected: "1.47.0",
+		},
+		{
+			name:      "Latest patch version from user agent",
+			userAgent: "Playwright/1.48.0 (x64; ubuntu 22.04) node/20.11",
+			expected:  "1.48.2",
+		},
+		{
+			name:     "Requested version matches",
+			version:  "1.48.1",
+			header:   "1.48.0-beta-1",
+			expected: "1.48.1",
+		},
+		{
+			name:    "Requested version mismatch",
+			version: "1.47.0",
+			header:  "1.48.0",
+			errMsg: "playwright client version 1.48.0 does not match requested server version 1.47.0, " +
+				"available versions: 1.48.2, 1.48.1, 1.47.0",
+		},
+		{
+			name:   "No matching version",
+			header: "1.49.1",
+			errMsg: "no chromium server matching playwright client version 1.49.1, available versions: 1.48.2, 1.48.1, 1.47.0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			cat := mocks.NewBrowsersCatalog(t)
+			cat.EXPECT().LookupBrowserImage(models.PlaywrightProtocol, "chromium", "custom").Return(models.BrowserImageConfig{
+				VersionTags: map[string]string{"1.47.0": "v1.47.0", "1.48.1": "v1.48.1", "1.48.2": "v1.48.2"},
+			}, true).Maybe()
+			cat.EXPECT().GetFlavors(models.PlaywrightProtocol).Return([]string{"custom", "default"}).Once()
+			cntr := NewPWController(nil, cat, nil, nil, nil, nil, zaptest.NewLogger(t))
+
+			c, _ := getPWContext("chromium", "custom", tc.version, nil)
+			c.Request().Header.Set(PWVersionHeader, tc.header)
+			c.Request().Header.Set("User-Agent", tc.userAgent)
+			opts, err := cntr.parsePWOptions(c)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			err = cntr.resolveClientVersion(c, opts)
+			if tc.errMsg != "" {
+				g.Expect(err).To(MatchError(tc.errMsg))
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(opts.Version).To(Equal(tc.expected))
+		})
+	}
+}
+
+func TestPWController_ResolveClientVersion_NoCatalog(t *testing.T) {
+	g := NewWithT(t)
+	cntr := NewPWController(nil, nil, nil, nil, nil, nil, zaptest.NewLogger(t))
+
+	c, _ := getPWContext("chromium", "default", "", nil)
+	c.Request().Header.Set(PWVersionHeader, "1.48.0")
+	opts, err := cntr.parsePWOptions(c)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(cntr.resolveClientVersion(c, opts)).To(Succeed())
+	g.Expect(opts.Version).To(BeEmpty())
+}
+
+func TestPWController_CreateSession_VersionMismatch(t *testing.T) {
+	g := NewWithT(t)
+	eb := new(mocks.EventBroker)
+	cat := mocks.NewBrowsersCatalog(t)
+	cat.EXPECT().LookupBrowserImage(models.PlaywrightProtocol, "chromium", "").
+		Return(models.BrowserImageConfig{VersionTags: map[string]string{"1.47.0": "v1.47.0"}}, true).Times(3)
+	cntr := NewPWController(nil, cat, nil, eb, nil, nil, zaptest.NewLogger(t))
+
+	ctx, _ := getPWContext("chromium", "", "", nil)
+	ctx.Request().Header.Set("User-Agent", "Playwright/1.48.0 (x64; ubuntu 22.04) python/3.12")
+	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
+		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.Error).
+			To(ContainSubstring("available versions: 1.47.0"))
+	}).Once()
+
+	err := cntr.CreateSession(ctx)
+	g.Expect(err).To(MatchError("no chromium server matching playwright client version 1.48.0, available versions: 1.47.0"))
+	var e models.ErrorWithCode
+	g.Expect(errors.As(err, &e)).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusBadRequest))
+	eb.AssertExpectations(t)
+}
+
+func TestPWController_CreateSession_UnsupportedBrowser(t *testing.T) {
+	g := NewWithT(t)
+	eb := new(mocks.EventBroker)
+	cat := mocks.NewBrowsersCatalog(t)
+	cat.EXPECT().LookupBrowserImage(models.PlaywrightProtocol, "chromum", "").
+		Return(models.BrowserImageConfig{}, false).Once()
+	cat.EXPECT().GetBrowsers(models.PlaywrightProtocol, "").
+		Return([]dto.Browser{{Name: "firefox"}, {Name: "chromium"}}).Once()
+	cntr := NewPWController(nil, cat, nil, eb, nil, nil, zaptest.NewLogger(t))
+
+	ctx, _ := getPWContext("chromum", "", "", nil)
+	expMsg := `playwright browser "chromum" is not supported, did you mean "chromium"? available: chromium, firefox`
+	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
+		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.Error).To(Equal(expMsg))
+	}).Once()
+
+	err := cntr.CreateSession(ctx)
+	g.Expect(err).To(MatchError(expMsg))
+	g.Expect(err.(models.ErrorWithCode).Code()).To(Equal(http.StatusBadRequest))
+	eb.AssertExpectations(t)
+}
+
+func TestPWController_DefaultLaunchOptions(t *testing.T) {
+	g := NewWithT(t)
+	cat := mocks.NewBrowsersCatalog(t)
+	cat.EXPECT().LookupBrowserImage(models.PlaywrightProtocol, "firefox", "").Return(models.BrowserImageConfig{
+		DefaultVersion: "1.48.0",
+		VersionTags:    map[string]string{"1.48.0": "v1.48.0"},
+		DefaultCapabilities: map[string]interface{}{
+			"args":             []interface{}{"--no-remote", "aaa"},
+			"headless":         true,
+			"firefoxUserPrefs": map[string]interface{}{"k1": 1, "k2": "default"},
+		},
+	}, true).Times(4)
+	cntr := NewPWController(nil, cat, nil, nil, nil, nil, zaptest.NewLogger(t))
+
+	c, _ := getPWContext("firefox", "", "", url.Values{
+		"arg":             []string{"aaa"},
+		"headless":        []string{"false"},
+		"firefoxUserPref": []string{"k2=true"},
+	})
+	opts, err := cntr.parsePWOptions(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(opts.LaunchOpts.Args).To(Equal([]string{"aaa"}))
+	g.Expect(opts.LaunchOpts.Headless).To(Equal(ref(false)))
+	g.Expect(opts.LaunchOpts.FirefoxUserPrefs).To(Equal(map[string]any{"k1": float64(1), "k2": true}))
+
+	// default list is used only when it's not requested
+	c, _ = getPWContext("firefox", "", "", nil)
+	opts, err = cntr.parsePWOptions(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(opts.LaunchOpts.Args).To(Equal([]string{"--no-remote", "aaa"}))
+}
+
+func TestActivityConn(t *testing.T) {
+	g := NewWithT(t)
+	c1, c2 := net.Pipe()
+	defer c2.Close()
+
+	touched := 0
+	c := &activityConn{ReadWriteCloser: c1, touch: func() { touched++ }}
+
+	go func() {
+		buf := make([]byte, 4)
+		_, _ = io.ReadFull(c2, buf)
+		_, _ = c2.Write([]byte("pong"))
+	}()
+
+	_, err := c.Write([]byte("ping"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(touched).To(Equal(1))
+
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(c, buf)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(buf)).To(Equal("pong"))
+	g.Expect(touched).To(BeNumerically(">=", 2))
+
+	g.Expect(c.Close()).To(Succeed())
+	_, err = c.Read(buf)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(touched).To(BeNumerically(">=", 2))
+}
+
 func setupNow(g *WithT, time1, time2 int64) func() time.Time {
 	nowCounter := 0
 	now := func() time.Time {
@@ -243,11 +548,11 @@ func TestPWController_CreateSession_Error(t *testing.T) {
 	s := new(mocks.SessionService)
 	eb := new(mocks.EventBroker)
 	now := func() time.Time { return time.UnixMilli(111) }
-	cntr := NewPWController(s, nil, eb, now, nil, zaptest.NewLogger(t))
+	cntr := NewPWController(s, nil, nil, eb, now, nil, zaptest.NewLogger(t))
 
 	ctx, _ := getPWContext("test", "custom", "v2", nil)
 	s.EXPECT().
-		CreateSession(context.Background(), &models.PWCapabilities{Flavor: "custom", Browser: "test", Version: "v2"}).
+		CreateSession(mock.Anything, &models.PWCapabilities{Flavor: "custom", Browser: "test", Version: "v2"}).
 		Return(nil, errors.New("test error")).
 		Once()
 	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
@@ -256,7 +561,7 @@ func TestPWController_CreateSession_Error(t *testing.T) {
 			"Protocol":       Equal(models.BrowserProtocol("playwright")),
 			"BrowserName":    Equal("test"),
 			"BrowserVersion": Equal("v2"),
-			"Error":          MatchError("test error"),
+			"Error":          ContainSubstring("test error"),
 		}))
 	}).Once()
 
@@ -273,12 +578,12 @@ func TestPWController_CreateSession_Cancelled(t *testing.T) {
 	s := new(mocks.SessionService)
 	eb := new(mocks.EventBroker)
 	now := func() time.Time { return time.UnixMilli(111) }
-	cntr := NewPWController(s, nil, eb, now, nil, zaptest.NewLogger(t))
+	cntr := NewPWController(s, nil, nil, eb, now, nil, zaptest.NewLogger(t))
 
 	ctx, _ := getPWContext("test", "custom", "v1", nil)
 	expErr := errors.Wrap(context.Canceled, "error")
 	s.EXPECT().
-		CreateSession(context.Background(), &models.PWCapabilities{Flavor: "custom", Browser: "test", Version: "v1"}).
+		CreateSession(mock.Anything, &models.PWCapabilities{Flavor: "custom", Browser: "test", Version: "v1"}).
 		Return(nil, expErr).
 		Once()
 	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
@@ -287,9 +592,9 @@ func TestPWController_CreateSession_Cancelled(t *testing.T) {
 			"Protocol":       Equal(models.BrowserProtocol("playwright")),
 			"BrowserName":    Equal("test"),
 			"BrowserVersion": Equal("v1"),
-			"Error":          MatchError(expErr),
+			"Error":          ContainSubstring(expErr.Error()),
 		}))
-		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.Error.(models.ErrorWithCode).Code()).To(Equal(499))
+		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.ErrorCode).To(Equal(499))
 	}).Once()
 
 	err := cntr.CreateSession(ctx)
@@ -305,10 +610,10 @@ func TestPWController_CreateSession_Panic(t *testing.T) {
 	s := new(mocks.SessionService)
 	eb := new(mocks.EventBroker)
 	now := func() time.Time { return time.UnixMilli(111) }
-	cntr := NewPWController(s, nil, eb, now, nil, zaptest.NewLogger(t))
+	cntr := NewPWController(s, nil, nil, eb, now, nil, zaptest.NewLogger(t))
 
 	ctx, _ := getPWContext("test", "custom", "v1", nil)
-	s.EXPECT().CreateSession(context.Background(), &models.PWCapabilities{Flavor: "custom", Browser: "test", Version: "v1"}).
+	s.EXPECT().CreateSession(mock.Anything, &models.PWCapabilities{Flavor: "custom", Browser: "test", Version: "v1"}).
 		Run(func(_ context.Context, _ capabilities.Capabilities) {
 			panic("test")
 		}).Once()
@@ -318,9 +623,9 @@ func TestPWController_CreateSession_Panic(t *testing.T) {
 			"Protocol":       Equal(models.BrowserProtocol("playwright")),
 			"BrowserName":    Equal("test"),
 			"BrowserVersion": Equal("v1"),
-			"Error":          MatchError("panic: test"),
+			"Error":          ContainSubstring("panic: test"),
 		}))
-		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.Error.(models.ErrorWithCode).Code()).
+		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.ErrorCode).
 			To(Equal(http.StatusInternalServerError))
 	}).Once()
 
@@ -337,16 +642,17 @@ func TestPWController_CreateSession_Proxy_Error(t *testing.T) {
 	s := new(mocks.SessionService)
 	eb := new(mocks.EventBroker)
 	now := setupNow(g, 111, 144)
-	cntr := NewPWController(s, rt, eb, now, nil, zaptest.NewLogger(t))
+	cntr := NewPWController(s, nil, rt, eb, now, nil, zaptest.NewLogger(t))
 	br := new(mocks.Browser)
 
 	u, err := url.Parse("http://host:1234/qqq")
 	g.Expect(err).ToNot(HaveOccurred())
 
+	genRequestID = func() string { return "r1" }
 	ctx, rec := getPWContext("test", "custom", "v1", nil)
 	caps := &models.PWCapabilities{Flavor: "custom", Browser: "test", Version: "v1"}
 	sess := createPWSession(br, caps, 122)
-	s.EXPECT().CreateSession(context.Background(), caps).Return(sess, nil).Once()
+	s.EXPECT().CreateSession(mock.Anything, caps).Return(sess, nil).Once()
 	s.EXPECT().DeleteSession(sess)
 	br.EXPECT().GetURL().Return(u)
 
@@ -361,16 +667,18 @@ func TestPWController_CreateSession_Proxy_Error(t *testing.T) {
 		g.Expect(e.EventType()).To(Equal(evmodels.SessionRequestedEventType))
 		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes).To(Equal(evmodels.SessionRequested{
 			Protocol:       "playwright",
+			ID:             "12345",
+			RequestID:      "r1",
 			BrowserName:    "test",
 			BrowserVersion: "v1",
 			StartDuration:  11 * time.Millisecond,
-			Error:          nil,
 		}))
 	}).Once()
 
 	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
 		g.Expect(e.(*evmodels.Event[evmodels.SessionReleased]).Attributes).To(Equal(evmodels.SessionReleased{
 			Protocol:        "playwright",
+			ID:              "12345",
 			BrowserName:     "test",
 			BrowserVersion:  "v1",
 			SessionDuration: 22 * time.Millisecond,
@@ -389,7 +697,7 @@ func TestPWController_CreateSession_Proxy_Error(t *testing.T) {
 func TestPWController_ValidateSession(t *testing.T) {
 	g := NewWithT(t)
 	srv := new(mocks.SessionService)
-	c := NewPWController(srv, nil, nil, nil, nil, zaptest.NewLogger(t))
+	c := NewPWController(srv, nil, nil, nil, nil, nil, zaptest.NewLogger(t))
 
 	s := &session.Session{}
 	srv.EXPECT().FindSession("s1").Return(s, nil).Once()
@@ -407,7 +715,7 @@ func TestPWController_ValidateSession(t *testing.T) {
 func TestPWController_ValidateSessionNotFound(t *testing.T) {
 	g := NewWithT(t)
 	srv := new(mocks.SessionService)
-	c := NewPWController(srv, nil, nil, nil, nil, zaptest.NewLogger(t))
+	c := NewPWController(srv, nil, nil, nil, nil, nil, zaptest.NewLogger(t))
 
 	srv.EXPECT().FindSession("s2").Return(nil, errors.New("test session not found")).Once()
 	ctx, _ := getSessionContext(router.SessRoute("/sess/:%s"), "/sess/s1", "s2")
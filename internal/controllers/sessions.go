@@ -0,0 +1,172 @@
+package controllers
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/dto"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+const (
+	browserQParam = "browser"
+	labelQParam   = "label"
+)
+
+var sessionPorts = []models.ContainerPort{
+	models.VNCPort,
+	models.DevtoolsPort,
+	models.FileserverPort,
+	models.ClipboardPort,
+}
+
+type SessionsController struct {
+	services map[models.BrowserProtocol]session.SessionService
+	backend  config.BackendType
+	now      func() time.Time
+}
+
+func NewSessionsController(
+	services map[models.BrowserProtocol]session.SessionService,
+	backend config.BackendType,
+	now func() time.Time,
+) *SessionsController {
+	return &SessionsController{
+		services: services,
+		backend:  backend,
+		now:      now,
+	}
+}
+
+// ListSessions supports filtering by protocol, browser name and labels (label=key=value or label=key for presence)
+func (s *SessionsController) ListSessions(c echo.Context) error {
+	protocol := models.BrowserProtocol(c.QueryParam(router.ProtoQParam))
+	if protocol != "" {
+		if _, ok := s.services[protocol]; !ok {
+			return models.NewBadRequestError(errors.Errorf("unsupported protocol %s", protocol))
+		}
+	}
+	browserName := c.QueryParam(browserQParam)
+	labels := c.QueryParams()[labelQParam]
+
+	now := s.now()
+	res := make([]dto.Session, 0)
+	for _, p := range s.protocols() {
+		if protocol != "" && p != protocol {
+			continue
+		}
+		for _, sess := range s.services[p].ListSessions() {
+			if browserName != "" && sess.ReqCaps().GetName() != browserName {
+				continue
+			}
+			if !matchLabels(sess.ReqCaps().GetLabels(), labels) {
+				continue
+			}
+			res = append(res, newSessionDTO(p, sess, s.backend, now))
+		}
+	}
+	slices.SortFunc(res, func(a, b dto.Session) int {
+		return a.Created.Compare(b.Created)
+	})
+	return c.JSON(http.StatusOK, res)
+}
+
+func (s *SessionsController) GetSession(c echo.Context) error {
+	p, sess, err := s.findSession(c.Param(router.SessionParam))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newSessionDTO(p, sess, s.backend, s.now()))
+}
+
+func (s *SessionsController) DeleteSession(c echo.Context) error {
+	p, sess, err := s.findSession(c.Param(router.SessionParam))
+	if err != nil {
+		return err
+	}
+	s.services[p].DeleteSession(sess)
+	return c.NoContent(http.StatusNoContent)
+}
+
+func (s *SessionsController) findSession(id string) (models.BrowserProtocol, *session.Session, error) {
+	for _, p := range s.protocols() {
+		if sess, err := s.services[p].FindSession(id); err == nil {
+			return p, sess, nil
+		}
+	}
+	return "", nil, models.NewNotFoundError(errors.Errorf("session %s not found", id))
+}
+
+func (s *SessionsController) protocols() []models.BrowserProtocol {
+	res := make([]models.BrowserProtocol, 0, len(s.services))
+	for p := range s.services {
+		res = append(res, p)
+	}
+	slices.Sort(res)
+	return res
+}
+
+func newSessionDTO(
+	protocol models.BrowserProtocol,
+	sess *session.Session,
+	backend config.BackendType,
+	now time.Time,
+) dto.Session {
+	caps := sess.ReqCaps()
+	br := sess.Browser()
+
+	lastUsed := sess.LastUsed()
+	idleSince := lastUsed
+	if idleSince.IsZero() {
+		idleSince = sess.Created()
+	}
+
+	var timeout string
+	if t := sess.Timeout(); t > 0 {
+		timeout = t.String()
+	}
+
+	ports := make(map[string]bool, len(sessionPorts))
+	for _, port := range sessionPorts {
+		ports[string(port)] = br.GetHostPort(port) != ""
+	}
+
+	return dto.Session{
+		ID:           sess.ID(),
+		Protocol:     string(protocol),
+		Platform:     sess.Platform(),
+		Browser:      caps.GetName(),
+		Version:      caps.GetVersion(),
+		Flavor:       caps.GetFlavor(),
+		TestName:     caps.GetTestName(),
+		Labels:       caps.GetLabels(),
+		Capabilities: caps.GetRawCapabilities(),
+		Created:      sess.Created(),
+		LastUsed:     lastUsed,
+		Idle:         now.Sub(idleSince).Truncate(time.Second).String(),
+		Timeout:      timeout,
+		Backend:      string(backend),
+		BackendID:    br.GetBackendID(),
+		URL:          br.GetURL().String(),
+		Ports:        ports,
+	}
+}
+
+func matchLabels(labels map[string]string, filters []string) bool {
+	for _, f := range filters {
+		k, v, hasValue := strings.Cut(f, "=")
+		lv, ok := labels[k]
+		if !ok || (hasValue && lv != v) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/dto"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestSessionsController_ListSessions(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   string
+		wantIDs []string
+	}{
+		{name: "all", query: "", wantIDs: []string{"wd1", "pw1", "wd2"}},
+		{name: "protocol", query: "protocol=playwright", wantIDs: []string{"pw1"}},
+		{name: "browser", query: "browser=chrome", wantIDs: []string{"wd1", "pw1"}},
+		{name: "label value", query: "label=team=qa", wantIDs: []string{"wd1"}},
+		{name: "label presence", query: "label=team&label=env", wantIDs: []string{"wd2"}},
+		{name: "no match", query: "browser=chrome&label=env=prod", wantIDs: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			wd1 := createAPISession(t, "wd1", "chrome", map[string]string{"team": "qa"}, time.UnixMilli(1000))
+			wd2 := createAPISession(t, "wd2", "firefox", map[string]string{"team": "dev", "env": "prod"}, time.UnixMilli(3000))
+			pw1 := createAPISession(t, "pw1", "chrome", nil, time.UnixMilli(2000))
+
+			wdSvc := new(mocks.SessionService)
+			pwSvc := new(mocks.SessionService)
+			wdSvc.EXPECT().ListSessions().Return([]*session.Session{wd2, wd1}).Maybe()
+			pwSvc.EXPECT().ListSessions().Return([]*session.Session{pw1}).Maybe()
+			sc := createSessionsController(wdSvc, pwSvc)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/sessions?"+tt.query, http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := sc.ListSessions(c)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+
+			var resp []dto.Session
+			err = json.NewDecoder(rec.Body).Decode(&resp)
+			g.Expect(err).ToNot(HaveOccurred())
+			ids := make([]string, len(resp))
+			for i, s := range resp {
+				ids[i] = s.ID
+			}
+			g.Expect(ids).To(Equal(tt.wantIDs))
+		})
+	}
+}
+
+func TestSessionsController_ListSessions_BadProtocol(t *testing.T) {
+	g := NewWithT(t)
+
+	sc := createSessionsController(new(mocks.SessionService), new(mocks.SessionService))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/sessions?protocol=telnet", http.NoBody)
+	c := e.NewContext(req, httptest.NewRecorder())
+
+	err := sc.ListSessions(c)
+	g.Expect(err).To(MatchError("unsupported protocol telnet"))
+	g.Expect(err.(models.ErrorWithCode).Code()).To(Equal(http.StatusBadRequest))
+}
+
+func TestSessionsController_GetSession(t *testing.T) {
+	g := NewWithT(t)
+
+	sess := createAPISession(t, "pw1", "chrome", map[string]string{"team": "qa"}, time.UnixMilli(2000))
+	sess.SetLastUsed(time.UnixMilli(5000))
+	sess.SetTimeout(time.Minute)
+
+	wdSvc := new(mocks.SessionService)
+	pwSvc := new(mocks.SessionService)
+	pwSvc.EXPECT().FindSession("pw1").Return(sess, nil).Once()
+	sc := createSessionsController(wdSvc, pwSvc)
+
+	c, rec := getSessionContext("/api/v1/sessions/:sess", "/api/v1/sessions/"+"pw1", "pw1")
+	err := sc.GetSession(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+
+	expResp, err := json.Marshal(dto.Session{
+		ID:           "pw1",
+		Protocol:     "playwright",
+		Platform:     "LINUX",
+		Browser:      "chrome",
+		Version:      "123.0",
+		Flavor:       "default",
+		TestName:     "test pw1",
+		Labels:       map[string]string{"team": "qa"},
+		Capabilities: json.RawMessage(`{"browserName":"chrome"}`),
+		Created:      time.UnixMilli(2000),
+		LastUsed:     time.UnixMilli(5000),
+		Idle:         "5s",
+		Timeout:      "1m0s",
+		Backend:      "docker",
+		BackendID:    "container-pw1",
+		URL:          "http://host:4444/wd/hub",
+		Ports: map[string]bool{
+			"vnc":        true,
+			"devtools":   false,
+			"fileserver": false,
+			"clipboard":  false,
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec.Body.String()).To(MatchJSON(expResp))
+
+	wdSvc.AssertExpectations(t)
+	pwSvc.AssertExpectations(t)
+}
+
+func TestSessionsController_GetSession_NotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	wdSvc := new(mocks.SessionService)
+	pwSvc := new(mocks.SessionService)
+	wdSvc.EXPECT().FindSession("123").Return(nil, errors.New("not found")).Once()
+	pwSvc.EXPECT().FindSession("123").Return(nil, errors.New("not found")).Once()
+	sc := createSessionsController(wdSvc, pwSvc)
+
+	c, _ := getSessionContext("/api/v1/sessions/:sess", "/api/v1/sessions/"+"123", "123")
+	err := sc.GetSession(c)
+	g.Expect(err).To(MatchError("session 123 not found"))
+	g.Expect(err.(models.ErrorWithCode).Code()).To(Equal(http.StatusNotFound))
+}
+
+func TestSessionsController_DeleteSession(t *testing.T) {
+	g := NewWithT(t)
+
+	sess := session.NewSession("123", "", nil, nil, nil, time.Time{}, nil, nil)
+	wdSvc := new(mocks.SessionService)
+	pwSvc := new(mocks.SessionService)
+	pwSvc.EXPECT().FindSession("123").Return(nil, errors.New("not found")).Once()
+	wdSvc.EXPECT().FindSession("123").Return(sess, nil).Once()
+	wdSvc.EXPECT().DeleteSession(sess).Once()
+	sc := createSessionsController(wdSvc, pwSvc)
+
+	c, rec := getSessionContext("/api/v1/sessions/:sess", "/api/v1/sessions/"+"123", "123")
+	err := sc.DeleteSession(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusNoContent))
+
+	wdSvc.AssertExpectations(t)
+	pwSvc.AssertExpectations(t)
+}
+
+func createSessionsController(wdSvc, pwSvc session.SessionService) *SessionsController {
+	return NewSessionsController(
+		map[models.BrowserProtocol]session.SessionService{
+			models.WebdriverProtocol:  wdSvc,
+			models.PlaywrightProtocol: pwSvc,
+		},
+		config.BackendDocker,
+		func() time.Time { return time.UnixMilli(10000) },
+	)
+}
+
+func createAPISession(
+	t *testing.T,
+	id, browserName string,
+	labels map[string]string,
+	created time.Time,
+) *session.Session {
+	caps := mocks.NewCapabilities(t)
+	caps.EXPECT().GetName().Return(browserName).Maybe()
+	caps.EXPECT().GetVersion().Return("123.0").Maybe()
+	caps.EXPECT().GetFlavor().Return("default").Maybe()
+	caps.EXPECT().GetTestName().Return("test " + id).Maybe()
+	caps.EXPECT().GetLabels().Return(labels).Maybe()
+	caps.EXPECT().GetRawCapabilities().Return([]byte(`{"browserName":"chrome"}`)).Maybe()
+
+	br := mocks.NewBrowser(t)
+	br.EXPECT().GetBackendID().Return("container-" + id).Maybe()
+	br.EXPECT().GetURL().Return(&url.URL{Scheme: "http", Host: "host:4444", Path: "/wd/hub"}).Maybe()
+	br.EXPECT().GetHostPort(models.VNCPort).Return("host:5900").Maybe()
+	br.EXPECT().GetHostPort(models.DevtoolsPort).Return("").Maybe()
+	br.EXPECT().GetHostPort(models.FileserverPort).Return("").Maybe()
+	br.EXPECT().GetHostPort(models.ClipboardPort).Return("").Maybe()
+
+	return session.NewSession(id, "LINUX", br, caps, nil, created, nil, nil)
+}
@@ -1,33 +1,80 @@
 package controllers
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net"
 	"net/http"
 	"net/url"
 	"path"
 	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
 	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/artifacts"
+	"github.com/selebrow/selebrow/internal/services/cmdlog"
 	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/browsers"
+	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/dto"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 	"github.com/selebrow/selebrow/pkg/quota"
 )
 
 const (
 	StaticRoot = "/static/"
+
+	uiEventsKeepAlive = 15 * time.Second
+
+	// ManualSessionLabel marks sessions launched interactively from the UI
+	ManualSessionLabel = "selebrow/manual"
+
+	manualSessionName = "Manual session"
+
+	uiSessionCreatedEvent = "session-created"
+	uiSessionDeletedEvent = "session-deleted"
+	uiQuotaChangedEvent   = "quota"
 )
 
+var (
+	uiRoots = map[models.BrowserProtocol]string{
+		models.WebdriverProtocol:  router.UIWDRoot,
+		models.PlaywrightProtocol: router.UIPWRoot,
+		models.PuppeteerProtocol:  router.UIPuppeteerRoot,
+	}
+
+	manualResolutions = []string{"1920x1080x24", "1600x900x24", "1366x768x24", "1280x1024x24"}
+)
+
+// WDSessionCreator creates webdriver session from W3C new session request body
+type WDSessionCreator interface {
+	NewSession(ctx context.Context, body io.Reader) (*session.Session, error)
+}
+
 type UIController struct {
 	services    map[models.BrowserProtocol]session.SessionService
+	wdCreator   WDSessionCreator
+	cat         browsers.BrowsersCatalog
 	qa          quota.QuotaAuthorizer
+	eb          event.EventBroker
+	cmdLog      cmdlog.CommandLog
+	artifacts   artifacts.ArtifactStore
+	backend     config.BackendType
+	now         func() time.Time
 	url         string
 	vncPassword string
+	// manual sessions request maximum timeout allowed by --max-session-timeout
+	manualTimeout time.Duration
 }
 
 type queueData struct {
@@ -42,28 +89,104 @@ type quotaData struct {
 }
 
 type indexData struct {
-	WDLink  string
-	PWLink  string
-	WDCount int
-	PWCount int
-	Quota   *quotaData
+	WDLink         string
+	PWLink         string
+	PuppeteerLink  string
+	EventsLink     string
+	WDCount        int
+	PWCount        int
+	PuppeteerCount int
+	Quota          *quotaData
 }
 
 type sessionData struct {
-	Root     string
-	Protocol string
-	Sessions []sessionItem
+	Root            string
+	EventsLink      string
+	LaunchLink      string
+	ScreenshotsLink string
+	Protocol        string
+	Sessions        []sessionItem
+}
+
+type screenshotsData struct {
+	Root        string
+	ListLink    string
+	Screenshots []screenshotItem
+}
+
+type screenshotItem struct {
+	SessionID string
+	TestName  string
+	Created   string
+	URL       string
+}
+
+type launchData struct {
+	Root        string
+	ListLink    string
+	Flavor      string
+	Flavors     []string
+	Browsers    []launchBrowser
+	Resolutions []string
+	Timeout     string
+	Error       string
+}
+
+type launchBrowser struct {
+	Name     string
+	Default  string
+	Versions []string
 }
 
 type sessionItem struct {
-	ID             string
-	CreatedAt      string
-	Browser        string
-	BrowserVersion string
-	Name           string
-	VNC            bool
-	VNCLink        string
-	ResetLink      string
+	ID             string `json:"id"`
+	CreatedAt      string `json:"createdAt"`
+	Browser        string `json:"browser"`
+	BrowserVersion string `json:"browserVersion"`
+	Name           string `json:"name"`
+	Manual         bool   `json:"manual"`
+	VNC            bool   `json:"vnc"`
+	DetailsLink    string `json:"detailsLink"`
+	VNCLink        string `json:"vncLink"`
+	ResetLink      string `json:"resetLink"`
+}
+
+type detailsData struct {
+	Root         string
+	ListLink     string
+	Session      dto.Session
+	Capabilities string
+	Response     string
+	VNCLink      string
+	ResetLink    string
+	CommandsLink string
+	Links        []linkItem
+}
+
+type commandsData struct {
+	Root         string
+	DetailsLink  string
+	DownloadLink string
+	Log          *cmdlog.Log
+}
+
+type linkItem struct {
+	Name string
+	URL  string
+}
+
+type uiSessionEvent struct {
+	Protocol string       `json:"protocol"`
+	ID       string       `json:"id"`
+	Count    int          `json:"count"`
+	Session  *sessionItem `json:"session,omitempty"`
+}
+
+type uiQuotaEvent struct {
+	Allocated  int `json:"allocated"`
+	Limit      int `json:"limit"`
+	QueueSize  int `json:"queueSize"`
+	QueueLimit int `json:"queueLimit"`
 }
 
 type vncData struct {
@@ -74,16 +197,33 @@ type vncData struct {
 
 func NewUIController(
 	services map[models.BrowserProtocol]session.SessionService,
+	wdCreator WDSessionCreator,
+	cat browsers.BrowsersCatalog,
 	qa quota.QuotaAuthorizer,
+	eb event.EventBroker,
+	cmdLog cmdlog.CommandLog,
+	artifactStore artifacts.ArtifactStore,
+	backend config.BackendType,
+	now func() time.Time,
 	listen, vncPassword string,
+	manualTimeout time.Duration,
 ) *UIController {
 	u := getURL(listen)
 
 	return &UIController{
 		services:    services,
+		wdCreator:   wdCreator,
+		cat:         cat,
 		qa:          qa,
+		eb:          eb,
+		cmdLog:      cmdLog,
+		artifacts:   artifactStore,
+		backend:     backend,
+		now:         now,
 		url:         u,
 		vncPassword: vncPassword,
+
+		manualTimeout: manualTimeout,
 	}
 }
 
@@ -105,11 +245,14 @@ func (u *UIController) Index(c echo.Context) error {
 	}
 
 	data := &indexData{
-		WDLink:  path.Join(router.UIRoot, router.UIWDRoot),
-		PWLink:  path.Join(router.UIRoot, router.UIPWRoot),
-		WDCount: len(u.services[models.WebdriverProtocol].ListSessions()),
-		PWCount: len(u.services[models.PlaywrightProtocol].ListSessions()),
-		Quota:   qData,
+		WDLink:         path.Join(router.UIRoot, router.UIWDRoot),
+		PWLink:         path.Join(router.UIRoot, router.UIPWRoot),
+		PuppeteerLink:  path.Join(router.UIRoot, router.UIPuppeteerRoot),
+		EventsLink:     path.Join(router.UIRoot, router.UIEventsPath),
+		WDCount:        len(u.services[models.WebdriverProtocol].ListSessions()),
+		PWCount:        len(u.services[models.PlaywrightProtocol].ListSessions()),
+		PuppeteerCount: len(u.services[models.PuppeteerProtocol].ListSessions()),
+		Quota:          qData,
 	}
 	return c.Render(http.StatusOK, "index.tmpl", data)
 }
@@ -122,15 +265,178 @@ func (u *UIController) PWSessions(c echo.Context) error {
 	return u.sessions(c, models.PlaywrightProtocol, router.UIPWRoot)
 }
 
+func (u *UIController) PuppeteerSessions(c echo.Context) error {
+	return u.sessions(c, models.PuppeteerProtocol, router.UIPuppeteerRoot)
+}
+
 func (u *UIController) sessions(c echo.Context, protocol models.BrowserProtocol, basePath string) error {
 	data := &sessionData{
-		Root:     router.UIRoot,
-		Protocol: string(protocol),
-		Sessions: u.getSessions(protocol, basePath),
+		Root:       router.UIRoot,
+		EventsLink: path.Join(router.UIRoot, router.UIEventsPath),
+		Protocol:   string(protocol),
+		Sessions:   u.getSessions(protocol, basePath),
+	}
+	// manual sessions can be launched for webdriver protocol only
+	if protocol == models.WebdriverProtocol {
+		data.LaunchLink = path.Join(router.UIRoot, basePath, router.UILaunchPath)
+		if u.artifacts != nil {
+			data.ScreenshotsLink = path.Join(router.UIRoot, basePath, router.UIScreenshotsPath)
+		}
 	}
 	return c.Render(http.StatusOK, "sessions.tmpl", data)
 }
 
+// WDLaunchForm renders form for launching manual webdriver session
+func (u *UIController) WDLaunchForm(c echo.Context) error {
+	return c.Render(http.StatusOK, "launch.tmpl", u.getLaunchData(c.QueryParam(router.FlavorQParam)))
+}
+
+// WDLaunch creates manual webdriver session with VNC enabled and redirects to its VNC page
+func (u *UIController) WDLaunch(c echo.Context) error {
+	flavor := c.FormValue(router.FlavorQParam)
+	name, version, _ := strings.Cut(c.FormValue("browser"), ":")
+	resolution := c.FormValue("resolution")
+
+	renderErr := func(code int, err error) error {
+		data := u.getLaunchData(flavor)
+		data.Error = err.Error()
+		return c.Render(code, "launch.tmpl", data)
+	}
+
+	body, err := newManualSessionRequest(name, version, flavor, resolution, u.manualTimeout)
+	if err != nil {
+		return renderErr(http.StatusInternalServerError, err)
+	}
+
+	sess, err := u.wdCreator.NewSession(c.Request().Context(), bytes.NewReader(body))
+	if err != nil {
+		code := http.StatusInternalServerError
+		var e models.ErrorWithCode
+		if errors.As(err, &e) {
+			code = e.Code()
+		}
+		return renderErr(code, errors.Wrap(err, "failed to create session"))
+	}
+	return c.Redirect(http.StatusSeeOther, path.Join(router.UIRoot, router.UIWDRoot, sess.ID(), router.UIVNCPath))
+}
+
+// WDScreenshots renders final screenshots of deleted webdriver sessions
+func (u *UIController) WDScreenshots(c echo.Context) error {
+	if u.artifacts == nil {
+		return models.NewNotFoundError(errors.New("artifacts are not enabled"))
+	}
+	screenshots, err := u.artifacts.ListScreenshots()
+	if err != nil {
+		return err
+	}
+
+	data := &screenshotsData{
+		Root:        router.UIRoot,
+		ListLink:    path.Join(router.UIRoot, router.UIWDRoot),
+		Screenshots: make([]screenshotItem, len(screenshots)),
+	}
+	for i, sc := range screenshots {
+		data.Screenshots[i] = screenshotItem{
+			SessionID: sc.SessionID,
+			TestName:  sc.TestName,
+			Created:   sc.Created.Format(time.DateTime),
+			URL:       path.Join(router.ScreenshotsPath, sc.SessionID),
+		}
+	}
+	return c.Render(http.StatusOK, "screenshots.tmpl", data)
+}
+
+func (u *UIController) getLaunchData(flavor string) *launchData {
+	data := &launchData{
+		Root:        router.UIRoot,
+		ListLink:    path.Join(router.UIRoot, router.UIWDRoot),
+		Flavor:      flavor,
+		Flavors:     u.cat.GetFlavors(models.WebdriverProtocol),
+		Resolutions: manualResolutions,
+		Timeout:     formatTimeout(u.manualTimeout),
+	}
+	for _, br := range u.cat.GetBrowsers(models.WebdriverProtocol, flavor) {
+		lb := launchBrowser{
+			Name:    br.Name,
+			Default: br.DefaultVersion,
+		}
+		for _, v := range br.Versions {
+			lb.Versions = append(lb.Versions, v.Number)
+		}
+		slices.SortFunc(lb.Versions, func(a, b string) int {
+			return compareVersions(b, a)
+		})
+		data.Browsers = append(data.Browsers, lb)
+	}
+	slices.SortFunc(data.Browsers, func(a, b launchBrowser) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return data
+}
+
+func newManualSessionRequest(name, version, flavor, resolution string, timeout time.Duration) ([]byte, error) {
+	req := map[string]interface{}{
+		"capabilities": models.W3CCapabilities{
+			AlwaysMatch: map[string]interface{}{
+				"browserName":    name,
+				"browserVersion": version,
+				"selenoid:options": &models.SelenoidOptions{
+					TestName:         manualSessionName,
+					SessionTimeout:   models.Duration{Duration: timeout},
+					ScreenResolution: resolution,
+					EnableVNC:        true,
+					Flavor:           flavor,
+					Labels:           map[string]string{ManualSessionLabel: "true"},
+				},
+			},
+		},
+	}
+	return json.Marshal(req)
+}
+
+// formatTimeout formats duration omitting zero minutes and seconds, e.g. 1h instead of 1h0m0s
+func formatTimeout(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = s[:len(s)-2]
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = s[:len(s)-2]
+	}
+	return s
+}
+
+// compareVersions compares dot separated versions numerically where possible
+func compareVersions(a, b string) int {
+	ap, bp := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(ap) && i < len(bp); i++ {
+		an, aErr := strconv.Atoi(ap[i])
+		bn, bErr := strconv.Atoi(bp[i])
+		var c int
+		if aErr == nil && bErr == nil {
+			c = an - bn
+		} else {
+			c = strings.Compare(ap[i], bp[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return len(ap) - len(bp)
+}
+
+func (u *UIController) WDSession(c echo.Context) error {
+	return u.details(c, models.WebdriverProtocol, router.UIWDRoot)
+}
+
+func (u *UIController) PWSession(c echo.Context) error {
+	return u.details(c, models.PlaywrightProtocol, router.UIPWRoot)
+}
+
+func (u *UIController) PuppeteerSession(c echo.Context) error {
+	return u.details(c, models.PuppeteerProtocol, router.UIPuppeteerRoot)
+}
+
 func (u *UIController) WDVNC(c echo.Context) error {
 	return u.vnc(c, models.WebdriverProtocol, router.VNCPath)
 }
@@ -139,6 +445,10 @@ func (u *UIController) PWVNC(c echo.Context) error {
 	return u.vnc(c, models.PlaywrightProtocol, path.Join(router.PWPath, router.VNCPath))
 }
 
+func (u *UIController) PuppeteerVNC(c echo.Context) error {
+	return u.vnc(c, models.PuppeteerProtocol, path.Join(router.PuppeteerPath, router.VNCPath))
+}
+
 func (u *UIController) WDReset(c echo.Context) error {
 	return u.reset(c, models.WebdriverProtocol, router.UIWDRoot)
 }
@@ -147,6 +457,10 @@ func (u *UIController) PWReset(c echo.Context) error {
 	return u.reset(c, models.PlaywrightProtocol, router.UIPWRoot)
 }
 
+func (u *UIController) PuppeteerReset(c echo.Context) error {
+	return u.reset(c, models.PuppeteerProtocol, router.UIPuppeteerRoot)
+}
+
 func (u *UIController) URL() string {
 	return u.url
 }
@@ -171,6 +485,87 @@ func (u *UIController) vnc(c echo.Context, protocol models.BrowserProtocol, root
 	return c.Render(http.StatusOK, "vnc.tmpl", data)
 }
 
+func (u *UIController) details(c echo.Context, protocol models.BrowserProtocol, basePath string) error {
+	id := c.Param(router.SessionParam)
+	s, err := u.services[protocol].FindSession(id)
+	if err != nil {
+		return models.NewNotFoundError(err)
+	}
+
+	data := &detailsData{
+		Root:         router.UIRoot,
+		ListLink:     path.Join(router.UIRoot, basePath),
+		Session:      newSessionDTO(protocol, s, u.backend, u.now()),
+		Capabilities: formatCapabilities(s),
+		Response:     formatJSON(s.Resp()),
+		ResetLink:    path.Join(router.UIRoot, basePath, id, router.UIResetPath),
+	}
+	if s.ReqCaps().IsVNCEnabled() {
+		data.VNCLink = path.Join(router.UIRoot, basePath, id, router.UIVNCPath)
+	}
+	// port endpoints are routed for webdriver sessions only
+	if protocol == models.WebdriverProtocol {
+		data.Links = sessionLinks(s)
+		if u.cmdLog != nil && u.cmdLog.Enabled(s) {
+			data.CommandsLink = path.Join(router.UIRoot, basePath, id, router.UICommandsPath)
+		}
+	}
+	return c.Render(http.StatusOK, "details.tmpl", data)
+}
+
+// WDCommands renders command log of running or deleted webdriver session
+func (u *UIController) WDCommands(c echo.Context) error {
+	id := c.Param(router.SessionParam)
+	log, err := getCommandLog(u.services[models.WebdriverProtocol], u.cmdLog, id)
+	if err != nil {
+		return err
+	}
+
+	data := &commandsData{
+		Root:         router.UIRoot,
+		DetailsLink:  path.Join(router.UIRoot, router.UIWDRoot, id),
+		DownloadLink: path.Join(router.CommandsPath, id) + "?" + downloadQParam + "=true",
+		Log:          log,
+	}
+	return c.Render(http.StatusOK, "commands.tmpl", data)
+}
+
+func sessionLinks(s *session.Session) []linkItem {
+	var res []linkItem
+	br := s.Browser()
+	if br.GetHostPort(models.DevtoolsPort) != "" {
+		res = append(res, linkItem{Name: "Devtools", URL: path.Join(router.DevtoolsPath, s.ID(), "json")})
+	}
+	if br.GetHostPort(models.ClipboardPort) != "" {
+		res = append(res, linkItem{Name: "Clipboard", URL: path.Join(router.ClipboardPath, s.ID())})
+	}
+	if br.GetHostPort(models.FileserverPort) != "" {
+		res = append(res, linkItem{Name: "Downloads", URL: path.Join(router.DownloadPath, s.ID())})
+	}
+	return res
+}
+
+// formatCapabilities returns indented requested capabilities, raw JSON is used when available
+func formatCapabilities(s *session.Session) string {
+	caps := s.ReqCaps()
+	if raw := caps.GetRawCapabilities(); len(raw) > 0 {
+		var buf bytes.Buffer
+		if err := json.Indent(&buf, raw, "", "  "); err == nil {
+			return buf.String()
+		}
+		return string(raw)
+	}
+	return formatJSON(caps)
+}
+
+func formatJSON(v any) string {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("%+v", v)
+	}
+	return string(b)
+}
+
 func (u *UIController) reset(c echo.Context, protocol models.BrowserProtocol, root string) error {
 	id := c.Param(router.SessionParam)
 
@@ -192,20 +587,113 @@ func (u *UIController) getSessions(protocol models.BrowserProtocol, basePath str
 	})
 	res := make([]sessionItem, len(sessions))
 	for i, s := range sessions {
-		res[i] = sessionItem{
-			ID:             s.ID(),
-			CreatedAt:      s.Created().Format(time.DateTime),
-			Browser:        s.ReqCaps().GetName(),
-			BrowserVersion: s.ReqCaps().GetVersion(),
-			Name:           s.ReqCaps().GetTestName(),
-			VNC:            s.ReqCaps().IsVNCEnabled(),
-			VNCLink:        path.Join(router.UIRoot, basePath, s.ID(), router.UIVNCPath),
-			ResetLink:      path.Join(router.UIRoot, basePath, s.ID(), router.UIResetPath),
-		}
+		res[i] = newSessionItem(s, basePath)
 	}
 	return res
 }
 
+// Events streams session and quota changes to the UI pages as server-sent events
+func (u *UIController) Events(c echo.Context) error {
+	ch := u.eb.Subscribe(
+		evmodels.SessionCreatedEventType,
+		evmodels.SessionDeletedEventType,
+		evmodels.QuotaChangedEventType,
+	)
+	defer u.eb.Unsubscribe(ch)
+
+	resp := c.Response()
+	resp.Header().Set(echo.HeaderContentType, "text/event-stream")
+	resp.Header().Set(echo.HeaderCacheControl, "no-cache")
+	resp.Header().Set(echo.HeaderConnection, "keep-alive")
+	resp.WriteHeader(http.StatusOK)
+	resp.Flush()
+
+	keepAlive := time.NewTicker(uiEventsKeepAlive)
+	defer keepAlive.Stop()
+
+	ctx := c.Request().Context()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-keepAlive.C:
+			if _, err := io.WriteString(resp, ": ping\n\n"); err != nil {
+				return nil
+			}
+		case ev, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			name, data := u.toUIEvent(ev)
+			if data == nil {
+				continue
+			}
+			b, err := json.Marshal(data)
+			if err != nil {
+				return errors.Wrap(err, "failed to marshal UI event")
+			}
+			if _, err := fmt.Fprintf(resp, "event: %s\ndata: %s\n\n", name, b); err != nil {
+				return nil
+			}
+		}
+		resp.Flush()
+	}
+}
+
+func (u *UIController) toUIEvent(ev evmodels.IEvent) (string, any) {
+	switch e := ev.(type) {
+	case *evmodels.Event[evmodels.SessionCreated]:
+		ps, ok := u.services[e.Attributes.Protocol]
+		if !ok {
+			return "", nil
+		}
+		data := &uiSessionEvent{
+			Protocol: string(e.Attributes.Protocol),
+			ID:       e.Attributes.ID,
+			Count:    len(ps.ListSessions()),
+		}
+		if s, err := ps.FindSession(e.Attributes.ID); err == nil {
+			item := newSessionItem(s, uiRoots[e.Attributes.Protocol])
+			data.Session = &item
+		}
+		return uiSessionCreatedEvent, data
+	case *evmodels.Event[evmodels.SessionDeleted]:
+		ps, ok := u.services[e.Attributes.Protocol]
+		if !ok {
+			return "", nil
+		}
+		return uiSessionDeletedEvent, &uiSessionEvent{
+			Protocol: string(e.Attributes.Protocol),
+			ID:       e.Attributes.ID,
+			Count:    len(ps.ListSessions()),
+		}
+	case *evmodels.Event[evmodels.QuotaChanged]:
+		return uiQuotaChangedEvent, &uiQuotaEvent{
+			Allocated:  e.Attributes.Allocated,
+			Limit:      e.Attributes.Limit,
+			QueueSize:  e.Attributes.QueueSize,
+			QueueLimit: e.Attributes.QueueLimit,
+		}
+	default:
+		return "", nil
+	}
+}
+
+func newSessionItem(s *session.Session, basePath string) sessionItem {
+	return sessionItem{
+		ID:             s.ID(),
+		CreatedAt:      s.Created().Format(time.DateTime),
+		Browser:        s.ReqCaps().GetName(),
+		BrowserVersion: s.ReqCaps().GetVersion(),
+		Name:           s.ReqCaps().GetTestName(),
+		Manual:         s.ReqCaps().GetLabels()[ManualSessionLabel] == "true",
+		VNC:            s.ReqCaps().IsVNCEnabled(),
+		DetailsLink:    path.Join(router.UIRoot, basePath, s.ID()),
+		VNCLink:        path.Join(router.UIRoot, basePath, s.ID(), router.UIVNCPath),
+		ResetLink:      path.Join(router.UIRoot, basePath, s.ID(), router.UIResetPath),
+	}
+}
+
 func getURL(listen string) string {
 	host, port, err := net.SplitHostPort(listen)
 	if err != nil {
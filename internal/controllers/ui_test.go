@@ -1,8 +1,12 @@
 package controllers
 
 import (
+	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -11,8 +15,14 @@ import (
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/selebrow/selebrow/internal/services/artifacts"
+	"github.com/selebrow/selebrow/internal/services/cmdlog"
 	"github.com/selebrow/selebrow/internal/services/session"
 	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/dto"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
@@ -28,12 +38,14 @@ func TestUIController_Index(t *testing.T) {
 
 	wdSvc := new(mocks.SessionService)
 	pwSvc := new(mocks.SessionService)
+	ppSvc := new(mocks.SessionService)
 	qa := new(quotaAuthorizerQueueMock)
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.WebdriverProtocol:  wdSvc,
 		models.PlaywrightProtocol: pwSvc,
-	}, qa, "", "")
+		models.PuppeteerProtocol:  ppSvc,
+	}, nil, nil, qa, nil, nil, nil, "", nil, "", "", 0)
 
 	qa.QuotaAuthorizer.EXPECT().Enabled().Return(true).Once()
 	qa.QuotaAuthorizer.EXPECT().Allocated().Return(123).Once()
@@ -44,12 +56,16 @@ func TestUIController_Index(t *testing.T) {
 
 	wdSvc.EXPECT().ListSessions().Return([]*session.Session{{}, {}}).Once()
 	pwSvc.EXPECT().ListSessions().Return([]*session.Session{{}, {}, {}}).Once()
+	ppSvc.EXPECT().ListSessions().Return([]*session.Session{{}}).Once()
 
 	expData := &indexData{
-		WDLink:  "/ui/wd",
-		PWLink:  "/ui/pw",
-		WDCount: 2,
-		PWCount: 3,
+		WDLink:         "/ui/wd",
+		PWLink:         "/ui/pw",
+		PuppeteerLink:  "/ui/puppeteer",
+		EventsLink:     "/ui/events",
+		WDCount:        2,
+		PWCount:        3,
+		PuppeteerCount: 1,
 		Quota: &quotaData{
 			Allocated: 123,
 			Limit:     456,
@@ -80,14 +96,16 @@ func TestUIController_WDSessions(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.WebdriverProtocol: wdSvc,
-	}, nil, "", "")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "", 0)
 
 	testSessions := createTestSessions()
 	wdSvc.EXPECT().ListSessions().Return(testSessions).Once()
 
 	expData := &sessionData{
-		Root:     "/ui",
-		Protocol: "webdriver",
+		Root:       "/ui",
+		EventsLink: "/ui/events",
+		LaunchLink: "/ui/wd/new",
+		Protocol:   "webdriver",
 		Sessions: []sessionItem{
 			{
 				ID:             "1111",
@@ -96,6 +114,7 @@ func TestUIController_WDSessions(t *testing.T) {
 				BrowserVersion: "3.0",
 				Name:           "test1",
 				VNC:            false,
+				DetailsLink:    "/ui/wd/1111",
 				VNCLink:        "/ui/wd/1111/vnc",
 				ResetLink:      "/ui/wd/1111/reset",
 			}, {
@@ -104,7 +123,9 @@ func TestUIController_WDSessions(t *testing.T) {
 				Browser:        "netscape",
 				BrowserVersion: "6.0",
 				Name:           "test2",
+				Manual:         true,
 				VNC:            true,
+				DetailsLink:    "/ui/wd/2222",
 				VNCLink:        "/ui/wd/2222/vnc",
 				ResetLink:      "/ui/wd/2222/reset",
 			},
@@ -129,14 +150,15 @@ func TestUIController_PWSessions(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.PlaywrightProtocol: pwSvc,
-	}, nil, "", "")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "", 0)
 
 	testSessions := createTestSessions()
 	pwSvc.EXPECT().ListSessions().Return(testSessions).Once()
 
 	expData := &sessionData{
-		Root:     "/ui",
-		Protocol: "playwright",
+		Root:       "/ui",
+		EventsLink: "/ui/events",
+		Protocol:   "playwright",
 		Sessions: []sessionItem{
 			{
 				ID:             "1111",
@@ -145,6 +167,7 @@ func TestUIController_PWSessions(t *testing.T) {
 				BrowserVersion: "3.0",
 				Name:           "test1",
 				VNC:            false,
+				DetailsLink:    "/ui/pw/1111",
 				VNCLink:        "/ui/pw/1111/vnc",
 				ResetLink:      "/ui/pw/1111/reset",
 			}, {
@@ -153,7 +176,9 @@ func TestUIController_PWSessions(t *testing.T) {
 				Browser:        "netscape",
 				BrowserVersion: "6.0",
 				Name:           "test2",
+				Manual:         true,
 				VNC:            true,
+				DetailsLink:    "/ui/pw/2222",
 				VNCLink:        "/ui/pw/2222/vnc",
 				ResetLink:      "/ui/pw/2222/reset",
 			},
@@ -180,7 +205,7 @@ func TestUIController_WDVNC(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.WebdriverProtocol: wdSvc,
-	}, nil, "", "qwerty")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "qwerty", 0)
 
 	sess := createTestSession("123", true)
 	wdSvc.EXPECT().FindSession("123").Return(sess, nil).Once()
@@ -206,7 +231,7 @@ func TestUIController_WDVNC_NotEnabled(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.WebdriverProtocol: wdSvc,
-	}, nil, "", "qwerty")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "qwerty", 0)
 
 	sess := createTestSession("321", false)
 	wdSvc.EXPECT().FindSession("321").Return(sess, nil).Once()
@@ -228,7 +253,7 @@ func TestUIController_WDVNC_NotFound(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.WebdriverProtocol: wdSvc,
-	}, nil, "", "qwerty")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "qwerty", 0)
 
 	wdSvc.EXPECT().FindSession("123").Return(nil, errors.New("not found")).Once()
 
@@ -250,7 +275,7 @@ func TestUIController_PWVNC(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.PlaywrightProtocol: pwSvc,
-	}, nil, "", "qwerty")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "qwerty", 0)
 
 	sess := createTestSession("123", true)
 	pwSvc.EXPECT().FindSession("123").Return(sess, nil).Once()
@@ -276,7 +301,7 @@ func TestUIController_PWVNC_NotEnabled(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.PlaywrightProtocol: pwSvc,
-	}, nil, "", "qwerty")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "qwerty", 0)
 
 	sess := createTestSession("123", false)
 	pwSvc.EXPECT().FindSession("123").Return(sess, nil).Once()
@@ -298,7 +323,7 @@ func TestUIController_PWVNC_NotFound(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.PlaywrightProtocol: pwSvc,
-	}, nil, "", "qwerty")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "qwerty", 0)
 
 	pwSvc.EXPECT().FindSession("123").Return(nil, errors.New("not found")).Once()
 
@@ -319,7 +344,7 @@ func TestUIController_WDReset(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.WebdriverProtocol: wdSvc,
-	}, nil, "", "")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "", 0)
 
 	sess := createTestSession("123", true)
 	wdSvc.EXPECT().FindSession("123").Return(sess, nil).Once()
@@ -343,7 +368,7 @@ func TestUIController_WDReset_NotFound(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.WebdriverProtocol: wdSvc,
-	}, nil, "", "qwerty")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "qwerty", 0)
 
 	wdSvc.EXPECT().FindSession("123").Return(nil, errors.New("not found")).Once()
 
@@ -364,7 +389,7 @@ func TestUIController_PWReset(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.PlaywrightProtocol: pwSvc,
-	}, nil, "", "")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "", 0)
 
 	sess := createTestSession("123", true)
 	pwSvc.EXPECT().FindSession("123").Return(sess, nil).Once()
@@ -388,7 +413,7 @@ func TestUIController_PWReset_NotFound(t *testing.T) {
 
 	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
 		models.PlaywrightProtocol: pwSvc,
-	}, nil, "", "qwerty")
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "qwerty", 0)
 
 	pwSvc.EXPECT().FindSession("123").Return(nil, errors.New("not found")).Once()
 
@@ -399,6 +424,293 @@ func TestUIController_PWReset_NotFound(t *testing.T) {
 	pwSvc.AssertExpectations(t)
 }
 
+func TestUIController_WDSession(t *testing.T) {
+	g := NewWithT(t)
+	r := new(mocks.Renderer)
+	c, rec := getUIContext("/ui/wd/123", r)
+	c.SetParamNames("sess")
+	c.SetParamValues("123")
+
+	wdSvc := new(mocks.SessionService)
+	now := func() time.Time { return time.UnixMilli(10000) }
+	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
+		models.WebdriverProtocol: wdSvc,
+	}, nil, nil, nil, nil, nil, nil, config.BackendKubernetes, now, "", "", 0)
+
+	caps := mocks.NewCapabilities(t)
+	caps.EXPECT().GetName().Return("chrome")
+	caps.EXPECT().GetVersion().Return("")
+	caps.EXPECT().GetFlavor().Return("")
+	caps.EXPECT().GetTestName().Return("test1")
+	caps.EXPECT().GetLabels().Return(map[string]string{"team": "qa"})
+	caps.EXPECT().GetRawCapabilities().Return([]byte(`{"capabilities":{"alwaysMatch":{"browserName":"chrome"}}}`))
+	caps.EXPECT().IsVNCEnabled().Return(true)
+
+	br := mocks.NewBrowser(t)
+	br.EXPECT().GetBackendID().Return("pod-123")
+	br.EXPECT().GetURL().Return(&url.URL{Scheme: "http", Host: "1.2.3.4:4444"})
+	br.EXPECT().GetHostPort(models.VNCPort).Return("1.2.3.4:5900")
+	br.EXPECT().GetHostPort(models.DevtoolsPort).Return("1.2.3.4:7070")
+	br.EXPECT().GetHostPort(models.FileserverPort).Return("")
+	br.EXPECT().GetHostPort(models.ClipboardPort).Return("1.2.3.4:9090")
+
+	resp := map[string]interface{}{"value": map[string]interface{}{"sessionId": "123"}}
+	sess := session.NewSession("123", "LINUX", br, caps, resp, time.UnixMilli(2000), nil, nil)
+	wdSvc.EXPECT().FindSession("123").Return(sess, nil).Once()
+
+	r.EXPECT().Render(mock.Anything, "details.tmpl", mock.Anything, c).
+		Run(func(_ io.Writer, _ string, data interface{}, _ echo.Context) {
+			d := data.(*detailsData)
+			g.Expect(d.Root).To(Equal("/ui"))
+			g.Expect(d.ListLink).To(Equal("/ui/wd"))
+			g.Expect(d.VNCLink).To(Equal("/ui/wd/123/vnc"))
+			g.Expect(d.ResetLink).To(Equal("/ui/wd/123/reset"))
+			g.Expect(d.Links).To(Equal([]linkItem{
+				{Name: "Devtools", URL: "/devtools/123/json"},
+				{Name: "Clipboard", URL: "/clipboard/123"},
+			}))
+			g.Expect(d.Session.ID).To(Equal("123"))
+			g.Expect(d.Session.Backend).To(Equal("kubernetes"))
+			g.Expect(d.Session.BackendID).To(Equal("pod-123"))
+			g.Expect(d.Session.Idle).To(Equal("8s"))
+			g.Expect(d.Session.Labels).To(Equal(map[string]string{"team": "qa"}))
+			g.Expect(d.Capabilities).To(Equal(`{
+  "capabilities": {
+    "alwaysMatch": {
+      "browserName": "chrome"
+    }
+  }
+}`))
+			g.Expect(d.Response).To(Equal(`{
+  "value": {
+    "sessionId": "123"
+  }
+}`))
+		}).
+		Return(nil).Once()
+
+	err := ui.WDSession(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+
+	r.AssertExpectations(t)
+	wdSvc.AssertExpectations(t)
+}
+
+func TestUIController_WDCommands(t *testing.T) {
+	g := NewWithT(t)
+	r := mocks.NewRenderer(t)
+	c, rec := getUIContext("/ui/wd/123/commands", r)
+	c.SetParamNames("sess")
+	c.SetParamValues("123")
+
+	wdSvc := mocks.NewSessionService(t)
+	cmdLog := mocks.NewCommandLog(t)
+	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
+		models.WebdriverProtocol: wdSvc,
+	}, nil, nil, nil, nil, cmdLog, nil, "", nil, "", "", 0)
+
+	log := &cmdlog.Log{SessionID: "123", Entries: []cmdlog.Entry{{Method: http.MethodGet, Path: "/title"}}}
+	cmdLog.EXPECT().Get("123").Return(log, nil).Once()
+
+	r.EXPECT().Render(mock.Anything, "commands.tmpl", &commandsData{
+		Root:         "/ui",
+		DetailsLink:  "/ui/wd/123",
+		DownloadLink: "/commands/123?download=true",
+		Log:          log,
+	}, c).Return(nil).Once()
+
+	g.Expect(ui.WDCommands(c)).To(Succeed())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+}
+
+func TestUIController_PWSession_NotFound(t *testing.T) {
+	g := NewWithT(t)
+	c, _ := getUIContext("/ui/pw/123", nil)
+	c.SetParamNames("sess")
+	c.SetParamValues("123")
+
+	pwSvc := new(mocks.SessionService)
+	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
+		models.PlaywrightProtocol: pwSvc,
+	}, nil, nil, nil, nil, nil, nil, "", nil, "", "", 0)
+
+	pwSvc.EXPECT().FindSession("123").Return(nil, errors.New("not found")).Once()
+
+	err := ui.PWSession(c)
+	g.Expect(err).To(MatchError("not found"))
+	g.Expect(err.(models.ErrorWithCode).Code()).To(Equal(http.StatusNotFound))
+
+	pwSvc.AssertExpectations(t)
+}
+
+func TestUIController_WDLaunchForm(t *testing.T) {
+	g := NewWithT(t)
+	r := new(mocks.Renderer)
+	c, rec := getUIContext("/ui/wd/new?flavor=cp", r)
+
+	cat := mocks.NewBrowsersCatalog(t)
+	ui := NewUIController(nil, nil, cat, nil, nil, nil, nil, "", nil, "", "", 90*time.Minute)
+
+	cat.EXPECT().GetFlavors(models.WebdriverProtocol).Return([]string{"cp", "default"}).Once()
+	cat.EXPECT().GetBrowsers(models.WebdriverProtocol, "cp").Return([]dto.Browser{
+		{
+			Name:           "firefox",
+			DefaultVersion: "100.0",
+			Versions:       []dto.BrowserVersion{{Number: "99.0"}, {Number: "100.0"}},
+		},
+		{
+			Name:           "chrome",
+			DefaultVersion: "9.0",
+			Versions:       []dto.BrowserVersion{{Number: "9.0"}, {Number: "10.0"}, {Number: "9.1"}},
+		},
+	}).Once()
+
+	expData := &launchData{
+		Root:     "/ui",
+		ListLink: "/ui/wd",
+		Flavor:   "cp",
+		Flavors:  []string{"cp", "default"},
+		Browsers: []launchBrowser{
+			{Name: "chrome", Default: "9.0", Versions: []string{"10.0", "9.1", "9.0"}},
+			{Name: "firefox", Default: "100.0", Versions: []string{"100.0", "99.0"}},
+		},
+		Resolutions: manualResolutions,
+		Timeout:     "1h30m",
+	}
+	r.EXPECT().Render(mock.Anything, "launch.tmpl", expData, c).Return(nil).Once()
+
+	err := ui.WDLaunchForm(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+
+	r.AssertExpectations(t)
+}
+
+func TestUIController_WDLaunch(t *testing.T) {
+	g := NewWithT(t)
+	c, rec := getUILaunchContext("browser=chrome:120.0&flavor=cp&resolution=1920x1080x24", nil)
+
+	creator := mocks.NewWDSessionCreator(t)
+	ui := NewUIController(nil, creator, nil, nil, nil, nil, nil, "", nil, "", "", 2*time.Hour)
+
+	creator.EXPECT().NewSession(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, body io.Reader) (*session.Session, error) {
+			caps, err := capabilities.NewCapabilities(body, nil, nil, nil)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(caps.GetName()).To(Equal("chrome"))
+			g.Expect(caps.GetVersion()).To(Equal("120.0"))
+			g.Expect(caps.GetFlavor()).To(Equal("cp"))
+			g.Expect(caps.GetResolution()).To(Equal("1920x1080x24"))
+			g.Expect(caps.IsVNCEnabled()).To(BeTrue())
+			g.Expect(caps.GetTimeout()).To(Equal(2 * time.Hour))
+			g.Expect(caps.GetTestName()).To(Equal(manualSessionName))
+			g.Expect(caps.GetLabels()).To(Equal(map[string]string{ManualSessionLabel: "true"}))
+			return session.NewSession("123", "", nil, caps, nil, time.Time{}, nil, nil), nil
+		}).Once()
+
+	err := ui.WDLaunch(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusSeeOther))
+	g.Expect(rec).To(HaveHTTPHeaderWithValue("Location", "/ui/wd/123/vnc"))
+}
+
+func TestUIController_WDLaunch_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		createErr error
+		wantCode  int
+		wantErr   string
+	}{
+		{
+			name:      "bad request",
+			createErr: models.WDSessionNotCreatedError(models.NewBadRequestError(errors.New("unsupported browser"))),
+			wantCode:  http.StatusBadRequest,
+			wantErr:   "failed to create session: unsupported browser",
+		},
+		{
+			name:      "create failed",
+			createErr: errors.New("test error"),
+			wantCode:  http.StatusInternalServerError,
+			wantErr:   "failed to create session: test error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := new(mocks.Renderer)
+			c, _ := getUILaunchContext("browser=chrome:1.0", r)
+
+			creator := mocks.NewWDSessionCreator(t)
+			cat := mocks.NewBrowsersCatalog(t)
+			ui := NewUIController(nil, creator, cat, nil, nil, nil, nil, "", nil, "", "", time.Hour)
+
+			creator.EXPECT().NewSession(mock.Anything, mock.Anything).Return(nil, tt.createErr).Once()
+			cat.EXPECT().GetFlavors(models.WebdriverProtocol).Return(nil).Once()
+			cat.EXPECT().GetBrowsers(models.WebdriverProtocol, "").Return(nil).Once()
+
+			r.EXPECT().Render(mock.Anything, "launch.tmpl", mock.Anything, c).
+				Run(func(_ io.Writer, _ string, data interface{}, _ echo.Context) {
+					g.Expect(data.(*launchData).Error).To(Equal(tt.wantErr))
+					g.Expect(data.(*launchData).Timeout).To(Equal("1h"))
+				}).
+				Return(nil).Once()
+
+			err := ui.WDLaunch(c)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(c.Response().Status).To(Equal(tt.wantCode))
+
+			r.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUIController_Events(t *testing.T) {
+	g := NewWithT(t)
+	c, rec := getUIContext("/ui/events", nil)
+
+	wdSvc := new(mocks.SessionService)
+	eb := mocks.NewEventBroker(t)
+
+	ui := NewUIController(map[models.BrowserProtocol]session.SessionService{
+		models.WebdriverProtocol: wdSvc,
+	}, nil, nil, nil, eb, nil, nil, "", nil, "", "", 0)
+
+	sess := createTestSessions()[1]
+	wdSvc.EXPECT().ListSessions().Return([]*session.Session{sess}).Once()
+	wdSvc.EXPECT().FindSession("2222").Return(sess, nil).Once()
+	wdSvc.EXPECT().ListSessions().Return(nil).Once()
+
+	ch := make(chan evmodels.IEvent, 4)
+	ch <- evmodels.NewSessionCreatedEvent(evmodels.SessionCreated{Protocol: models.WebdriverProtocol, ID: "2222"})
+	ch <- evmodels.NewSessionDeletedEvent(evmodels.SessionDeleted{Protocol: models.WebdriverProtocol, ID: "2222"})
+	ch <- evmodels.NewSessionDeletedEvent(evmodels.SessionDeleted{Protocol: "unknown", ID: "2222"})
+	ch <- evmodels.NewQuotaChangedEvent(evmodels.QuotaChanged{Allocated: 1, Limit: 2, QueueSize: 3, QueueLimit: 4})
+	close(ch)
+
+	eb.EXPECT().Subscribe([]string{
+		evmodels.SessionCreatedEventType,
+		evmodels.SessionDeletedEventType,
+		evmodels.QuotaChangedEventType,
+	}).Return(ch).Once()
+	eb.EXPECT().Unsubscribe((<-chan evmodels.IEvent)(ch)).Once()
+
+	err := ui.Events(c)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+	g.Expect(rec).To(HaveHTTPHeaderWithValue("Content-Type", "text/event-stream"))
+	g.Expect(rec.Body.String()).To(Equal("event: session-created\n" +
+		`data: {"protocol":"webdriver","id":"2222","count":1,"session":{"id":"2222","createdAt":"1970-01-01 00:00:13",` +
+		`"browser":"netscape","browserVersion":"6.0","name":"test2","manual":true,"vnc":true,"detailsLink":"/ui/wd/2222","vncLink":"/ui/wd/2222/vnc","resetLink":"/ui/wd/2222/reset"}}` +
+		"\n\n" +
+		"event: session-deleted\n" +
+		`data: {"protocol":"webdriver","id":"2222","count":0}` + "\n\n" +
+		"event: quota\n" +
+		`data: {"allocated":1,"limit":2,"queueSize":3,"queueLimit":4}` + "\n\n"))
+
+	wdSvc.AssertExpectations(t)
+}
+
 func TestUIController_URL(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -444,7 +756,7 @@ func TestUIController_URL(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
-			u := NewUIController(nil, nil, tt.listen, "")
+			u := NewUIController(nil, nil, nil, nil, nil, nil, nil, "", nil, tt.listen, "", 0)
 			got := u.URL()
 			g.Expect(got).To(Equal(tt.want))
 		})
@@ -464,6 +776,7 @@ func createTestSessions() []*session.Session {
 	caps1.EXPECT().GetVersion().Return("3.0").Once()
 	caps1.EXPECT().GetTestName().Return("test1").Once()
 	caps1.EXPECT().IsVNCEnabled().Return(false).Once()
+	caps1.EXPECT().GetLabels().Return(nil).Once()
 
 	s1 := session.NewSession("1111", "", nil, caps1, nil, time.UnixMilli(12345).UTC(), nil, nil)
 
@@ -472,11 +785,23 @@ func createTestSessions() []*session.Session {
 	caps2.EXPECT().GetVersion().Return("6.0").Once()
 	caps2.EXPECT().GetTestName().Return("test2").Once()
 	caps2.EXPECT().IsVNCEnabled().Return(true).Once()
+	caps2.EXPECT().GetLabels().Return(map[string]string{ManualSessionLabel: "true"}).Once()
 	s2 := session.NewSession("2222", "", nil, caps2, nil, time.UnixMilli(13345).UTC(), nil, nil)
 	testSessions := []*session.Session{s1, s2}
 	return testSessions
 }
 
+func getUILaunchContext(form string, r *mocks.Renderer) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	e.Renderer = r
+
+	req := httptest.NewRequest(http.MethodPost, "/ui/wd/new", strings.NewReader(form))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	return c, rec
+}
+
 func getUIContext(target string, r *mocks.Renderer) (echo.Context, *httptest.ResponseRecorder) {
 	e := echo.New()
 	e.Renderer = r
@@ -486,3 +811,27 @@ func getUIContext(target string, r *mocks.Renderer) (echo.Context, *httptest.Res
 	c := e.NewContext(req, rec)
 	return c, rec
 }
+
+func TestUIController_WDScreenshots(t *testing.T) {
+	g := NewWithT(t)
+	r := mocks.NewRenderer(t)
+	c, rec := getUIContext("/ui/wd/screenshots", r)
+
+	store := mocks.NewArtifactStore(t)
+	ui := NewUIController(nil, nil, nil, nil, nil, nil, store, "", nil, "", "", 0)
+
+	store.EXPECT().ListScreenshots().Return([]artifacts.Screenshot{
+		{SessionID: "s1", TestName: "test1", Created: time.Date(2024, 5, 1, 10, 20, 30, 0, time.UTC), Path: "/tmp/s1/test1.png"},
+	}, nil).Once()
+
+	r.EXPECT().Render(mock.Anything, "screenshots.tmpl", &screenshotsData{
+		Root:     "/ui",
+		ListLink: "/ui/wd",
+		Screenshots: []screenshotItem{
+			{SessionID: "s1", TestName: "test1", Created: "2024-05-01 10:20:30", URL: "/screenshots/s1"},
+		},
+	}, c).Return(nil).Once()
+
+	g.Expect(ui.WDScreenshots(c)).To(Succeed())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+}
@@ -1,8 +1,10 @@
 package controllers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strings"
 
@@ -13,26 +15,32 @@ import (
 	"github.com/selebrow/selebrow/internal/common/clock"
 	"github.com/selebrow/selebrow/internal/router"
 	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/browsers"
 	"github.com/selebrow/selebrow/pkg/capabilities"
 	"github.com/selebrow/selebrow/pkg/config"
 	"github.com/selebrow/selebrow/pkg/dto"
 	"github.com/selebrow/selebrow/pkg/event"
 	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
+	"github.com/selebrow/selebrow/pkg/tracing"
 )
 
 const SessionKey = "session"
 
 type WDSessionController struct {
-	srv   session.SessionService
-	eb    event.EventBroker
-	now   clock.NowFunc
-	proxy *models.ProxyOptions
-	l     *zap.SugaredLogger
+	srv      session.SessionService
+	cat      browsers.BrowsersCatalog
+	eb       event.EventBroker
+	now      clock.NowFunc
+	proxy    *models.ProxyOptions
+	match    capabilities.MatchFunc
+	defaults capabilities.DefaultsFunc
+	l        *zap.SugaredLogger
 }
 
 func NewWDSessionController(
 	srv session.SessionService,
+	cat browsers.BrowsersCatalog,
 	eb event.EventBroker,
 	now clock.NowFunc,
 	proxyOpts *config.ProxyOpts,
@@ -42,37 +50,65 @@ func NewWDSessionController(
 	if proxyOpts != nil {
 		proxy = models.NewHTTPProxy(proxyOpts.ProxyHost, proxyOpts.NoProxy)
 	}
+	var (
+		match    capabilities.MatchFunc
+		defaults capabilities.DefaultsFunc
+	)
+	if cat != nil {
+		match = browsers.NewCapabilitiesMatcher(cat, models.WebdriverProtocol)
+		defaults = browsers.NewCapabilitiesDefaults(cat, models.WebdriverProtocol)
+	}
 	return &WDSessionController{
-		srv:   srv,
-		eb:    eb,
-		now:   now,
-		proxy: proxy,
-		l:     l.Sugar(),
+		srv:      srv,
+		cat:      cat,
+		eb:       eb,
+		now:      now,
+		proxy:    proxy,
+		match:    match,
+		defaults: defaults,
+		l:        l.Sugar(),
 	}
 }
 
 func (s *WDSessionController) CreateSession(ctx echo.Context) error {
+	sess, err := s.NewSession(ctx.Request().Context(), ctx.Request().Body)
+	if err != nil {
+		return err
+	}
+	return ctx.JSON(http.StatusOK, sess.Resp())
+}
+
+// NewSession creates webdriver session from W3C new session request body
+func (s *WDSessionController) NewSession(ctx context.Context, body io.Reader) (*session.Session, error) {
 	ev := evmodels.SessionRequested{
-		Protocol: models.WebdriverProtocol,
+		Protocol:  models.WebdriverProtocol,
+		RequestID: genRequestID(),
 	}
 
 	defer func() {
 		if r := recover(); r != nil {
-			ev.Error = models.NewInternalServerError(errors.Errorf("panic: %v", r))
+			_ = ev.SetError(models.NewInternalServerError(errors.Errorf("panic: %v", r)))
 			s.eb.Publish(evmodels.NewSessionRequestedEvent(ev))
 			panic(r)
 		}
 		s.eb.Publish(evmodels.NewSessionRequestedEvent(ev))
 	}()
 
-	caps, err := capabilities.NewCapabilities(ctx.Request().Body, s.proxy)
+	caps, err := capabilities.NewCapabilities(body, s.proxy, s.match, s.defaults)
 	if err != nil {
-		ev.Error = models.BadWDSessionParameters(err)
-		return ev.Error
+		return nil, ev.SetError(models.BadWDSessionParameters(err))
 	}
 
 	ev.BrowserName = caps.GetName()
 	ev.BrowserVersion = caps.GetVersion()
+	ev.Labels = caps.GetLabels()
+	if s.cat != nil {
+		if err := validateBrowser(
+			s.cat, models.WebdriverProtocol, caps.GetName(), caps.GetVersion(), caps.GetFlavor(),
+		); err != nil {
+			return nil, ev.SetError(models.WDSessionNotCreatedError(models.NewBadRequestError(err)))
+		}
+	}
 	if s.l.Desugar().Core().Enabled(zap.DebugLevel) {
 		var c map[string]interface{}
 		// error can't happen (already checked in capabilities.NewCapabilities above)
@@ -81,14 +117,23 @@ func (s *WDSessionController) CreateSession(ctx echo.Context) error {
 	}
 
 	start := s.now()
-	sess, err := s.srv.CreateSession(ctx.Request().Context(), caps)
+	reqCtx, span := tracing.StartServer(
+		event.WithRequestID(ctx, ev.RequestID),
+		"webdriver.createSession",
+		tracing.BrowserAttributes(models.WebdriverProtocol, caps)...,
+	)
+	sess, err := s.srv.CreateSession(reqCtx, caps)
+	if err == nil {
+		span.SetAttributes(tracing.SessionIDKey.String(sess.ID()))
+	}
+	tracing.End(span, err)
 	if err != nil {
 		s.l.Errorw("failed to create session", zap.Error(err))
-		ev.Error = models.WDSessionNotCreatedError(models.WrapCancelledErr(err))
-		return ev.Error
+		return nil, ev.SetError(models.WDSessionNotCreatedError(models.WrapCancelledErr(err)))
 	}
+	ev.ID = sess.ID()
 	ev.StartDuration = sess.Created().Sub(start)
-	return ctx.JSON(http.StatusOK, sess.Resp())
+	return sess, nil
 }
 
 func (s *WDSessionController) ValidateSession(next echo.HandlerFunc) echo.HandlerFunc {
@@ -101,6 +146,9 @@ func (s *WDSessionController) ValidateSession(next echo.HandlerFunc) echo.Handle
 
 		sess, err := s.srv.FindSession(id)
 		if err != nil {
+			if errors.Is(err, session.ErrSessionTerminated) {
+				return models.NewW3CErr(http.StatusNotFound, models.InvalidSessionIDErr, err)
+			}
 			return models.NewW3CErr(http.StatusNotFound, "unknown session", err)
 		}
 
@@ -114,8 +162,10 @@ func (s *WDSessionController) DeleteSession(ctx echo.Context) error {
 	sess, _ := ctx.Get(SessionKey).(*session.Session)
 	ev := evmodels.SessionReleased{
 		Protocol:        models.WebdriverProtocol,
+		ID:              sess.ID(),
 		BrowserName:     sess.ReqCaps().GetName(),
 		BrowserVersion:  sess.ReqCaps().GetVersion(),
+		Labels:          sess.ReqCaps().GetLabels(),
 		SessionDuration: s.now().Sub(sess.Created()),
 	}
 	s.srv.DeleteSession(sess)
@@ -23,6 +23,7 @@ import (
 	"github.com/selebrow/selebrow/pkg/capabilities"
 	"github.com/selebrow/selebrow/pkg/config"
 	"github.com/selebrow/selebrow/pkg/dto"
+	"github.com/selebrow/selebrow/pkg/event"
 	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 )
@@ -69,7 +70,7 @@ func TestWDSessionController_CreateSession(t *testing.T) {
 		ProxyHost: "proxy:1234",
 		NoProxy:   "1.1.1.1 ",
 	}
-	sc := NewWDSessionController(srv, eb, now, pOpts, zaptest.NewLogger(t))
+	sc := NewWDSessionController(srv, nil, eb, now, pOpts, zaptest.NewLogger(t))
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/sess", strings.NewReader(caps1))
@@ -82,8 +83,10 @@ func TestWDSessionController_CreateSession(t *testing.T) {
 		},
 	}
 
-	srv.EXPECT().CreateSession(ctx.Request().Context(), mock.Anything).RunAndReturn(
-		func(_ context.Context, caps capabilities.Capabilities) (*session.Session, error) {
+	genRequestID = func() string { return "r1" }
+	srv.EXPECT().CreateSession(mock.Anything, mock.Anything).RunAndReturn(
+		func(ctx context.Context, caps capabilities.Capabilities) (*session.Session, error) {
+			g.Expect(event.ContextRequestID(ctx)).To(Equal("r1"))
 			g.Expect(caps.GetRawCapabilities()).To(MatchJSON([]byte(expCaps)))
 			sess := session.NewSession("123", "", nil, caps, expResp, time.UnixMilli(456), nil, nil)
 			return sess, nil
@@ -92,10 +95,11 @@ func TestWDSessionController_CreateSession(t *testing.T) {
 		g.Expect(e.EventType()).To(Equal(evmodels.SessionRequestedEventType))
 		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes).To(Equal(evmodels.SessionRequested{
 			Protocol:       "webdriver",
+			ID:             "123",
+			RequestID:      "r1",
 			BrowserName:    "chrome",
 			BrowserVersion: "102.0",
 			StartDuration:  333 * time.Millisecond,
-			Error:          nil,
 		}))
 	}).Once()
 
@@ -112,13 +116,129 @@ func TestWDSessionController_CreateSession(t *testing.T) {
 	eb.AssertExpectations(t)
 }
 
+func TestWDSessionController_CreateSessionFirstMatch(t *testing.T) {
+	g := NewWithT(t)
+	srv := new(mocks.SessionService)
+	eb := new(mocks.EventBroker)
+	cat := mocks.NewBrowsersCatalog(t)
+	now := func() time.Time { return time.UnixMilli(123) }
+	sc := NewWDSessionController(srv, cat, eb, now, nil, zaptest.NewLogger(t))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/sess", strings.NewReader(`{"capabilities": {
+		"alwaysMatch": {"acceptInsecureCerts": true},
+		"firstMatch": [{"browserName": "safari"}, {"browserName": "firefox"}, {"browserName": "chrome"}]
+	}}`))
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	cat.EXPECT().LookupBrowserImage(models.WebdriverProtocol, "safari", "").
+		Return(models.BrowserImageConfig{}, false).Once()
+	cat.EXPECT().LookupBrowserImage(models.WebdriverProtocol, "firefox", "").
+		Return(models.BrowserImageConfig{DefaultVersion: "100.0", VersionTags: map[string]string{"100.0": "100.0"}}, true).
+		Times(3)
+
+	srv.EXPECT().CreateSession(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, caps capabilities.Capabilities) (*session.Session, error) {
+			g.Expect(caps.GetName()).To(Equal("firefox"))
+			g.Expect(caps.GetRawCapabilities()).To(MatchJSON(`{"capabilities": {
+				"alwaysMatch": {"acceptInsecureCerts": true},
+				"firstMatch": [{"browserName": "firefox"}]
+			}}`))
+			return session.NewSession("123", "", nil, caps, nil, time.UnixMilli(456), nil, nil), nil
+		}).Once()
+	eb.EXPECT().Publish(mock.Anything).Once()
+
+	err := sc.CreateSession(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+
+	srv.AssertExpectations(t)
+	eb.AssertExpectations(t)
+}
+
+func TestWDSessionController_CreateSessionUnsupportedBrowser(t *testing.T) {
+	g := NewWithT(t)
+	srv := new(mocks.SessionService)
+	eb := new(mocks.EventBroker)
+	cat := mocks.NewBrowsersCatalog(t)
+	sc := NewWDSessionController(srv, cat, eb, nil, nil, zaptest.NewLogger(t))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/sess", strings.NewReader(caps1))
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	cat.EXPECT().LookupBrowserImage(models.WebdriverProtocol, "chrome", "").
+		Return(models.BrowserImageConfig{VersionTags: map[string]string{"103.0": "103.0", "104.0": "104.0"}}, true)
+	expMsg := `chrome version "102.0" is not supported, did you mean "104.0" or "103.0"? available: 104.0, 103.0`
+	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
+		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes).
+			To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+				"BrowserName": Equal("chrome"),
+				"Error":       Equal(expMsg),
+				"ErrorCode":   Equal(http.StatusBadRequest),
+			}))
+	}).Once()
+
+	err := sc.CreateSession(ctx)
+	g.Expect(err).To(MatchError(expMsg))
+	g.Expect(err.(*models.W3CError).Code()).To(Equal(http.StatusBadRequest))
+
+	srv.AssertExpectations(t)
+	eb.AssertExpectations(t)
+}
+
+func TestWDSessionController_CreateSessionDefaultCapabilities(t *testing.T) {
+	g := NewWithT(t)
+	srv := new(mocks.SessionService)
+	eb := new(mocks.EventBroker)
+	cat := mocks.NewBrowsersCatalog(t)
+	now := func() time.Time { return time.UnixMilli(123) }
+	sc := NewWDSessionController(srv, cat, eb, now, nil, zaptest.NewLogger(t))
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/sess", strings.NewReader(`{"capabilities": {
+		"alwaysMatch": {"browserName": "chrome", "goog:chromeOptions": {"args": ["--headless"]}}
+	}}`))
+	rec := httptest.NewRecorder()
+	ctx := e.NewContext(req, rec)
+
+	cat.EXPECT().LookupBrowserImage(models.WebdriverProtocol, "chrome", "").Return(models.BrowserImageConfig{
+		DefaultVersion: "120.0",
+		VersionTags:    map[string]string{"120.0": "120.0"},
+		DefaultCapabilities: map[string]interface{}{
+			"goog:chromeOptions": map[string]interface{}{
+				"args": []interface{}{"--no-sandbox", "--disable-dev-shm-usage"},
+			},
+		},
+	}, true)
+
+	srv.EXPECT().CreateSession(mock.Anything, mock.Anything).RunAndReturn(
+		func(_ context.Context, caps capabilities.Capabilities) (*session.Session, error) {
+			g.Expect(caps.GetRawCapabilities()).To(MatchJSON(`{"capabilities": {"alwaysMatch": {
+				"browserName": "chrome",
+				"goog:chromeOptions": {"args": ["--headless"]}
+			}}}`))
+			return session.NewSession("123", "", nil, caps, nil, time.UnixMilli(456), nil, nil), nil
+		}).Once()
+	eb.EXPECT().Publish(mock.Anything).Once()
+
+	err := sc.CreateSession(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
+
+	srv.AssertExpectations(t)
+	eb.AssertExpectations(t)
+}
+
 var caps2 = `{notAJsonAtAll}`
 
 func TestWDSessionController_CreateSessionInvalidCaps(t *testing.T) {
 	g := NewWithT(t)
 	srv := new(mocks.SessionService)
 	eb := new(mocks.EventBroker)
-	sc := NewWDSessionController(srv, eb, nil, nil, zaptest.NewLogger(t))
+	sc := NewWDSessionController(srv, nil, eb, nil, nil, zaptest.NewLogger(t))
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/sess", strings.NewReader(caps2))
@@ -127,8 +247,9 @@ func TestWDSessionController_CreateSessionInvalidCaps(t *testing.T) {
 	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
 		g.Expect(e.EventType()).To(Equal(evmodels.SessionRequestedEventType))
 		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes).To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
-			"Protocol": Equal(models.BrowserProtocol("webdriver")),
-			"Error":    HaveOccurred(),
+			"Protocol":  Equal(models.BrowserProtocol("webdriver")),
+			"Error":     Not(BeEmpty()),
+			"ErrorCode": Equal(http.StatusBadRequest),
 		}))
 	}).Once()
 
@@ -145,7 +266,7 @@ func TestWDSessionController_CreateSessionFailed(t *testing.T) {
 	srv := new(mocks.SessionService)
 	eb := new(mocks.EventBroker)
 	now := func() time.Time { return time.Time{} }
-	sc := NewWDSessionController(srv, eb, now, nil, zaptest.NewLogger(t))
+	sc := NewWDSessionController(srv, nil, eb, now, nil, zaptest.NewLogger(t))
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/sess", strings.NewReader(caps1))
@@ -153,16 +274,16 @@ func TestWDSessionController_CreateSessionFailed(t *testing.T) {
 	ctx := e.NewContext(req, rec)
 
 	sessErr := errors.New("test session failed")
-	srv.EXPECT().CreateSession(ctx.Request().Context(), mock.Anything).Return(nil, sessErr).Once()
+	srv.EXPECT().CreateSession(mock.Anything, mock.Anything).Return(nil, sessErr).Once()
 	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
 		g.Expect(e.EventType()).To(Equal(evmodels.SessionRequestedEventType))
 		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes).To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
 			"Protocol":       Equal(models.BrowserProtocol("webdriver")),
 			"BrowserName":    Equal("chrome"),
 			"BrowserVersion": Equal("102.0"),
-			"Error":          MatchError("test session failed"),
+			"Error":          ContainSubstring("test session failed"),
 		}))
-		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.Error.(models.ErrorWithCode).Code()).
+		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.ErrorCode).
 			To(Equal(http.StatusInternalServerError))
 	}).Once()
 
@@ -182,7 +303,7 @@ func TestWDSessionController_CreateSessionCancelled(t *testing.T) {
 	srv := new(mocks.SessionService)
 	eb := new(mocks.EventBroker)
 	now := func() time.Time { return time.Time{} }
-	sc := NewWDSessionController(srv, eb, now, nil, zaptest.NewLogger(t))
+	sc := NewWDSessionController(srv, nil, eb, now, nil, zaptest.NewLogger(t))
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/sess", strings.NewReader(caps1))
@@ -190,16 +311,16 @@ func TestWDSessionController_CreateSessionCancelled(t *testing.T) {
 	ctx := e.NewContext(req, rec)
 
 	sessErr := errors.Wrap(context.Canceled, "error")
-	srv.EXPECT().CreateSession(ctx.Request().Context(), mock.Anything).Return(nil, sessErr).Once()
+	srv.EXPECT().CreateSession(mock.Anything, mock.Anything).Return(nil, sessErr).Once()
 	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
 		g.Expect(e.EventType()).To(Equal(evmodels.SessionRequestedEventType))
 		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes).To(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
 			"Protocol":       Equal(models.BrowserProtocol("webdriver")),
 			"BrowserName":    Equal("chrome"),
 			"BrowserVersion": Equal("102.0"),
-			"Error":          MatchError(sessErr),
+			"Error":          ContainSubstring(sessErr.Error()),
 		}))
-		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.Error.(models.ErrorWithCode).Code()).To(Equal(499))
+		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.ErrorCode).To(Equal(499))
 	}).Once()
 
 	err := sc.CreateSession(ctx)
@@ -218,14 +339,14 @@ func TestWDSessionController_CreateSessionPanic(t *testing.T) {
 	srv := new(mocks.SessionService)
 	eb := new(mocks.EventBroker)
 	now := func() time.Time { return time.Time{} }
-	sc := NewWDSessionController(srv, eb, now, nil, zaptest.NewLogger(t))
+	sc := NewWDSessionController(srv, nil, eb, now, nil, zaptest.NewLogger(t))
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodPost, "/sess", strings.NewReader(caps1))
 	rec := httptest.NewRecorder()
 	ctx := e.NewContext(req, rec)
 
-	srv.EXPECT().CreateSession(ctx.Request().Context(), mock.Anything).Run(func(_ context.Context, _ capabilities.Capabilities) {
+	srv.EXPECT().CreateSession(mock.Anything, mock.Anything).Run(func(_ context.Context, _ capabilities.Capabilities) {
 		panic("test")
 	}).Once()
 	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
@@ -234,9 +355,9 @@ func TestWDSessionController_CreateSessionPanic(t *testing.T) {
 			"Protocol":       Equal(models.BrowserProtocol("webdriver")),
 			"BrowserName":    Equal("chrome"),
 			"BrowserVersion": Equal("102.0"),
-			"Error":          MatchError("panic: test"),
+			"Error":          ContainSubstring("panic: test"),
 		}))
-		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.Error.(models.ErrorWithCode).Code()).
+		g.Expect(e.(*evmodels.Event[evmodels.SessionRequested]).Attributes.ErrorCode).
 			To(Equal(http.StatusInternalServerError))
 	}).Once()
 
@@ -253,7 +374,7 @@ func TestWDSessionController_ValidateSession(t *testing.T) {
 	srv := new(mocks.SessionService)
 	lastUsed := time.UnixMilli(123)
 	now := func() time.Time { return lastUsed }
-	sc := NewWDSessionController(srv, nil, now, nil, zaptest.NewLogger(t))
+	sc := NewWDSessionController(srv, nil, nil, now, nil, zaptest.NewLogger(t))
 
 	s := &session.Session{}
 	srv.EXPECT().FindSession("s1").Return(s, nil).Once()
@@ -272,7 +393,7 @@ func TestWDSessionController_ValidateSession(t *testing.T) {
 func TestWDSessionController_ValidateSessionNotFound(t *testing.T) {
 	g := NewWithT(t)
 	srv := new(mocks.SessionService)
-	sc := NewWDSessionController(srv, nil, nil, nil, zaptest.NewLogger(t))
+	sc := NewWDSessionController(srv, nil, nil, nil, nil, zaptest.NewLogger(t))
 
 	srv.EXPECT().FindSession("s2").Return(nil, errors.New("test session not found")).Once()
 	ctx, _ := getSessionContext(router.SessRoute("/sess/:%s"), "/sess/s1", "s2")
@@ -286,23 +407,43 @@ func TestWDSessionController_ValidateSessionNotFound(t *testing.T) {
 	srv.AssertExpectations(t)
 }
 
+func TestWDSessionController_ValidateSessionTerminated(t *testing.T) {
+	g := NewWithT(t)
+	srv := mocks.NewSessionService(t)
+	sc := NewWDSessionController(srv, nil, nil, nil, nil, zaptest.NewLogger(t))
+
+	srv.EXPECT().FindSession("s2").
+		Return(nil, errors.Wrap(session.ErrSessionTerminated, "session s2: max session lifetime 1h0m0s is reached")).Once()
+	ctx, _ := getSessionContext(router.SessRoute("/sess/:%s"), "/sess/s2", "s2")
+	err := sc.ValidateSession(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})(ctx)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(err.(*models.W3CError).Code()).To(Equal(http.StatusNotFound))
+	g.Expect(err.(*models.W3CError).Value.Error).To(Equal(models.InvalidSessionIDErr))
+	g.Expect(err.(*models.W3CError).Value.Message).To(ContainSubstring("max session lifetime"))
+}
+
 func TestWDSessionController_DeleteSession(t *testing.T) {
 	g := NewWithT(t)
 	srv := new(mocks.SessionService)
 	eb := new(mocks.EventBroker)
 	caps := new(mocks.Capabilities)
 	now := func() time.Time { return time.UnixMilli(333) }
-	sc := NewWDSessionController(srv, eb, now, nil, zaptest.NewLogger(t))
+	sc := NewWDSessionController(srv, nil, eb, now, nil, zaptest.NewLogger(t))
 
-	s := session.NewSession("", "", nil, caps, nil, time.UnixMilli(111), nil, nil)
+	s := session.NewSession("s1", "", nil, caps, nil, time.UnixMilli(111), nil, nil)
 	caps.EXPECT().GetName().Return("Test")
 	caps.EXPECT().GetVersion().Return("dev")
+	caps.EXPECT().GetLabels().Return(map[string]string{"team": "qa"})
 	srv.EXPECT().DeleteSession(s).Once()
 	eb.EXPECT().Publish(mock.Anything).Run(func(e evmodels.IEvent) {
 		g.Expect(e.(*evmodels.Event[evmodels.SessionReleased]).Attributes).To(Equal(evmodels.SessionReleased{
 			Protocol:        "webdriver",
+			ID:              "s1",
 			BrowserName:     "Test",
 			BrowserVersion:  "dev",
+			Labels:          map[string]string{"team": "qa"},
 			SessionDuration: 222 * time.Millisecond,
 		}))
 	}).Once()
@@ -321,7 +462,7 @@ func TestWDSessionController_DeleteSession(t *testing.T) {
 func TestWDSessionController_Status(t *testing.T) {
 	g := NewWithT(t)
 	srv := new(mocks.SessionService)
-	sc := NewWDSessionController(srv, nil, nil, nil, zaptest.NewLogger(t))
+	sc := NewWDSessionController(srv, nil, nil, nil, nil, zaptest.NewLogger(t))
 
 	e := echo.New()
 	req := httptest.NewRequest(http.MethodGet, "/status", strings.NewReader(""))
@@ -5,15 +5,21 @@ import (
 
 	"github.com/labstack/echo/v4"
 
+	"github.com/selebrow/selebrow/internal/services/drain"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
-type WDStatusController struct{}
+type WDStatusController struct {
+	drain drain.DrainService
+}
 
-func NewWDStatusController() *WDStatusController {
-	return &WDStatusController{}
+func NewWDStatusController(drain drain.DrainService) *WDStatusController {
+	return &WDStatusController{drain: drain}
 }
 
-func (*WDStatusController) Status(c echo.Context) error {
+func (s *WDStatusController) Status(c echo.Context) error {
+	if s.drain.Draining() {
+		return c.JSON(http.StatusServiceUnavailable, models.NewWebDriverStatus(false))
+	}
 	return c.JSON(http.StatusOK, models.NewWebDriverStatus(true))
 }
@@ -9,29 +9,54 @@ import (
 	"github.com/labstack/echo/v4"
 	. "github.com/onsi/gomega"
 
+	"github.com/selebrow/selebrow/mocks"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
 func TestWDStatusController_Status(t *testing.T) {
-	g := NewWithT(t)
-
-	sc := NewWDStatusController()
-
-	e := echo.New()
-	req := httptest.NewRequest(http.MethodGet, "/stat", http.NoBody)
-	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
-
-	err := sc.Status(c)
-	g.Expect(err).ToNot(HaveOccurred())
-	g.Expect(rec).To(HaveHTTPStatus(http.StatusOK))
-
-	var gotResp models.WebDriverStatus
-	err = json.NewDecoder(rec.Body).Decode(&gotResp)
-	g.Expect(err).To(Not(HaveOccurred()))
-	g.Expect(gotResp).To(Equal(models.WebDriverStatus{
-		Value: models.WebDriverReadyStatus{
-			Ready: true,
+	tests := []struct {
+		name      string
+		draining  bool
+		wantCode  int
+		wantReady bool
+	}{
+		{
+			name:      "ready",
+			wantCode:  http.StatusOK,
+			wantReady: true,
+		},
+		{
+			name:     "draining",
+			draining: true,
+			wantCode: http.StatusServiceUnavailable,
 		},
-	}))
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			d := mocks.NewDrainService(t)
+			sc := NewWDStatusController(d)
+
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/stat", http.NoBody)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			d.EXPECT().Draining().Return(tt.draining).Once()
+
+			err := sc.Status(c)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(rec).To(HaveHTTPStatus(tt.wantCode))
+
+			var gotResp models.WebDriverStatus
+			err = json.NewDecoder(rec.Body).Decode(&gotResp)
+			g.Expect(err).To(Not(HaveOccurred()))
+			g.Expect(gotResp).To(Equal(models.WebDriverStatus{
+				Value: models.WebDriverReadyStatus{
+					Ready: tt.wantReady,
+				},
+			}))
+		})
+	}
 }
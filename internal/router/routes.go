@@ -6,21 +6,45 @@ const (
 	WDHUBPath    = "/wd/hub"
 	SessionPath  = "/session"
 	SessionParam = "sess"
-
-	PWPath       = "/pw"
-	NameParam    = "name"
-	VersionParam = "version"
-	FlavorQParam = "flavor"
-	ProtoQParam  = "protocol"
-
-	VNCPath = "/vnc"
-
-	UIRoot   = "/ui"
-	UIWDRoot = "/wd"
-	UIPWRoot = "/pw"
-
-	UIVNCPath   = "/vnc"
-	UIResetPath = "/reset"
+	SEFilesPath  = "/se/files"
+
+	PWPath  = "/pw"
+	CDPPath = "/cdp"
+
+	PuppeteerPath = "/puppeteer"
+	NameParam     = "name"
+	VersionParam  = "version"
+	FlavorQParam  = "flavor"
+	ProtoQParam   = "protocol"
+
+	CDPVersionPath = "/json/version"
+
+	VNCPath         = "/vnc"
+	DevtoolsPath    = "/devtools"
+	ClipboardPath   = "/clipboard"
+	DownloadPath    = "/download"
+	CommandsPath    = "/commands"
+	ScreenshotsPath = "/screenshots"
+	ArchivesPath    = "/archives"
+
+	AdminPath = "/admin"
+	PoolsPath = "/pools"
+	DrainPath = "/drain"
+
+	APIPath      = "/api/v1"
+	SessionsPath = "/sessions"
+
+	UIRoot          = "/ui"
+	UIWDRoot        = "/wd"
+	UIPWRoot        = "/pw"
+	UIPuppeteerRoot = "/puppeteer"
+
+	UIVNCPath         = "/vnc"
+	UIResetPath       = "/reset"
+	UIEventsPath      = "/events"
+	UILaunchPath      = "/new"
+	UICommandsPath    = "/commands"
+	UIScreenshotsPath = "/screenshots"
 )
 
 func SessRoute(s string) string {
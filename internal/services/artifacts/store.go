@@ -0,0 +1,245 @@
+package artifacts
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/selebrow/selebrow/internal/common/clock"
+)
+
+const (
+	screenshotExt         = ".png"
+	defaultScreenshotName = "screenshot"
+	downloadsArchiveName  = "downloads.zip"
+)
+
+var (
+	ErrNotFound = errors.New("artifact not found")
+
+	unsafeChars = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+)
+
+type Screenshot struct {
+	SessionID string    `json:"sessionId"`
+	TestName  string    `json:"testName,omitempty"`
+	Created   time.Time `json:"created"`
+	Path      string    `json:"-"`
+}
+
+type ArtifactStore interface {
+	// SaveScreenshot stores PNG screenshot of the session
+	SaveScreenshot(id, testName string, data []byte) error
+	// Screenshot returns stored screenshot of the session
+	Screenshot(id string) (*Screenshot, error)
+	// ListScreenshots returns all stored screenshots, the most recent first
+	ListScreenshots() ([]Screenshot, error)
+	// CreateDownloadsArchive returns writer for zip archive of files downloaded by the session
+	CreateDownloadsArchive(id string) (io.WriteCloser, error)
+	// DownloadsArchive returns path to stored archive of files downloaded by the session
+	DownloadsArchive(id string) (string, error)
+}
+
+// Store keeps session artifacts in per-session subdirectories of the configured directory,
+// artifacts older than retention period are periodically removed
+type Store struct {
+	dir       string
+	retention time.Duration
+	now       clock.NowFunc
+	cancel    context.CancelFunc
+	done      chan struct{}
+	l         *zap.SugaredLogger
+}
+
+func NewStore(dir string, retention time.Duration, now clock.NowFunc, cleanupInterval time.Duration, l *zap.Logger) *Store {
+	s := &Store{
+		dir:       dir,
+		retention: retention,
+		now:       now,
+		l:         l.Sugar(),
+	}
+
+	if cleanupInterval > 0 && retention > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		s.done = make(chan struct{})
+		go s.cleanup(ctx, cleanupInterval)
+	}
+	return s
+}
+
+func (s *Store) SaveScreenshot(id, testName string, data []byte) error {
+	dir, err := s.sessionDir(id)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return errors.Wrap(err, "failed to create artifacts directory")
+	}
+	return os.WriteFile(filepath.Join(dir, screenshotName(testName)), data, 0o644)
+}
+
+func (s *Store) Screenshot(id string) (*Screenshot, error) {
+	dir, err := s.sessionDir(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Wrap(err, "failed to read artifacts directory")
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != screenshotExt {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read screenshot info")
+		}
+		testName := strings.TrimSuffix(e.Name(), screenshotExt)
+		if testName == defaultScreenshotName {
+			testName = ""
+		}
+		return &Screenshot{
+			SessionID: id,
+			TestName:  testName,
+			Created:   info.ModTime(),
+			Path:      filepath.Join(dir, e.Name()),
+		}, nil
+	}
+	return nil, ErrNotFound
+}
+
+func (s *Store) CreateDownloadsArchive(id string) (io.WriteCloser, error) {
+	dir, err := s.sessionDir(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.Wrap(err, "failed to create artifacts directory")
+	}
+	return os.Create(filepath.Join(dir, downloadsArchiveName))
+}
+
+func (s *Store) DownloadsArchive(id string) (string, error) {
+	dir, err := s.sessionDir(id)
+	if err != nil {
+		return "", ErrNotFound
+	}
+	p := filepath.Join(dir, downloadsArchiveName)
+	if _, err := os.Stat(p); err != nil {
+		if os.IsNotExist(err) {
+			return "", ErrNotFound
+		}
+		return "", errors.Wrap(err, "failed to read downloads archive info")
+	}
+	return p, nil
+}
+
+func (s *Store) ListScreenshots() ([]Screenshot, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []Screenshot{}, nil
+		}
+		return nil, errors.Wrap(err, "failed to read artifacts directory")
+	}
+
+	res := make([]Screenshot, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		sc, err := s.Screenshot(e.Name())
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		res = append(res, *sc)
+	}
+	slices.SortFunc(res, func(a, b Screenshot) int {
+		return b.Created.Compare(a.Created)
+	})
+	return res, nil
+}
+
+func (s *Store) sessionDir(id string) (string, error) {
+	if s.dir == "" {
+		return "", errors.New("artifacts directory is not configured")
+	}
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return "", errors.Errorf("invalid session id %s", id)
+	}
+	return filepath.Join(s.dir, id), nil
+}
+
+// screenshotName derives file name from the test name, so it's preserved when artifacts are copied around
+func screenshotName(testName string) string {
+	name := strings.Trim(unsafeChars.ReplaceAllString(testName, "_"), "._")
+	if name == "" {
+		name = defaultScreenshotName
+	}
+	return name + screenshotExt
+}
+
+func (s *Store) cleanup(ctx context.Context, cleanupInterval time.Duration) {
+	t := time.NewTicker(cleanupInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			s.removeExpired()
+		case <-ctx.Done():
+			close(s.done)
+			return
+		}
+	}
+}
+
+func (s *Store) removeExpired() {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			s.l.Warnw("failed to read artifacts directory", zap.Error(err))
+		}
+		return
+	}
+	now := s.now()
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || now.Sub(info.ModTime()) <= s.retention {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(s.dir, e.Name())); err != nil {
+			s.l.Warnw("failed to remove expired artifacts", zap.String("session_id", e.Name()), zap.Error(err))
+			continue
+		}
+		s.l.Debugw("expired artifacts have been removed", zap.String("session_id", e.Name()))
+	}
+}
+
+func (s *Store) Shutdown(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.done:
+		return nil
+	}
+}
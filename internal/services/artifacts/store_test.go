@@ -0,0 +1,104 @@
+package artifacts
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestStore_SaveScreenshot(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := filepath.Join(t.TempDir(), "artifacts")
+	s := NewStore(dir, time.Hour, time.Now, 0, zaptest.NewLogger(t))
+
+	g.Expect(s.SaveScreenshot("s1", "My test: login/logout", []byte("png1"))).To(Succeed())
+	g.Expect(s.SaveScreenshot("s2", "", []byte("png2"))).To(Succeed())
+
+	sc, err := s.Screenshot("s1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sc.SessionID).To(Equal("s1"))
+	g.Expect(sc.TestName).To(Equal("My_test_login_logout"))
+	g.Expect(sc.Path).To(Equal(filepath.Join(dir, "s1", "My_test_login_logout.png")))
+	g.Expect(os.ReadFile(sc.Path)).To(Equal([]byte("png1")))
+
+	sc, err = s.Screenshot("s2")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sc.TestName).To(BeEmpty())
+	g.Expect(sc.Path).To(Equal(filepath.Join(dir, "s2", "screenshot.png")))
+
+	past := time.Now().Add(-time.Minute)
+	g.Expect(os.Chtimes(filepath.Join(dir, "s2", "screenshot.png"), past, past)).To(Succeed())
+	list, err := s.ListScreenshots()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(list).To(HaveLen(2))
+	g.Expect(list[0].SessionID).To(Equal("s1"))
+	g.Expect(list[1].SessionID).To(Equal("s2"))
+}
+
+func TestStore_ScreenshotNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	s := NewStore(t.TempDir(), time.Hour, time.Now, 0, zaptest.NewLogger(t))
+	_, err := s.Screenshot("s1")
+	g.Expect(err).To(MatchError(ErrNotFound))
+	_, err = s.Screenshot("../s1")
+	g.Expect(err).To(MatchError(ErrNotFound))
+	g.Expect(s.SaveScreenshot("../s1", "", []byte("png"))).ToNot(Succeed())
+
+	list, err := NewStore(filepath.Join(t.TempDir(), "missing"), time.Hour, time.Now, 0, zaptest.NewLogger(t)).ListScreenshots()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(list).To(BeEmpty())
+}
+
+func TestStore_DownloadsArchive(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	s := NewStore(dir, time.Hour, time.Now, 0, zaptest.NewLogger(t))
+
+	_, err := s.DownloadsArchive("s1")
+	g.Expect(err).To(MatchError(ErrNotFound))
+
+	w, err := s.CreateDownloadsArchive("s1")
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = w.Write([]byte("zip"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(w.Close()).To(Succeed())
+
+	p, err := s.DownloadsArchive("s1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(p).To(Equal(filepath.Join(dir, "s1", "downloads.zip")))
+	g.Expect(os.ReadFile(p)).To(Equal([]byte("zip")))
+
+	_, err = s.DownloadsArchive("../s1")
+	g.Expect(err).To(MatchError(ErrNotFound))
+	_, err = s.CreateDownloadsArchive("../s1")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestStore_Cleanup(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := t.TempDir()
+	now := time.Now()
+	s := NewStore(dir, time.Hour, func() time.Time { return now }, 10*time.Millisecond, zaptest.NewLogger(t))
+
+	g.Expect(s.SaveScreenshot("s1", "", []byte("png1"))).To(Succeed())
+	g.Expect(s.SaveScreenshot("s2", "", []byte("png2"))).To(Succeed())
+	expired := now.Add(-2 * time.Hour)
+	g.Expect(os.Chtimes(filepath.Join(dir, "s1"), expired, expired)).To(Succeed())
+
+	g.Eventually(func() error {
+		_, err := os.Stat(filepath.Join(dir, "s1"))
+		return err
+	}).Should(MatchError(os.ErrNotExist))
+	g.Expect(s.Screenshot("s2")).ToNot(BeNil())
+
+	g.Expect(s.Shutdown(context.Background())).To(Succeed())
+}
@@ -0,0 +1,131 @@
+package cmdlog
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/selebrow/selebrow/internal/services/session"
+)
+
+const elided = "<elided>"
+
+// Command captures single proxied Webdriver command
+type Command struct {
+	once  sync.Once
+	s     *Store
+	id    string
+	entry Entry
+	req   *limitedBuffer
+	resp  *limitedBuffer
+}
+
+func (s *Store) Begin(sess *session.Session, cmd string, r *http.Request) *Command {
+	c := &Command{
+		s:  s,
+		id: sess.ID(),
+		entry: Entry{
+			Time:   s.now(),
+			Method: r.Method,
+			Path:   "/" + cmd,
+		},
+		req: &limitedBuffer{limit: maxBodySize},
+	}
+	s.track(c.id)
+	if !isScreenshot(cmd) {
+		c.resp = &limitedBuffer{limit: maxBodySize}
+	}
+	if r.Body != nil && r.Body != http.NoBody {
+		r.Body = &teeReadCloser{ReadCloser: r.Body, w: c.req}
+	}
+	return c
+}
+
+// Response captures response body, the entry is recorded when the body is closed
+func (c *Command) Response(resp *http.Response) {
+	c.entry.Status = resp.StatusCode
+	if c.resp == nil {
+		resp.Body = &closeNotifier{ReadCloser: resp.Body, onClose: c.done}
+		return
+	}
+	resp.Body = &closeNotifier{
+		ReadCloser: &teeReadCloser{ReadCloser: resp.Body, w: c.resp},
+		onClose:    c.done,
+	}
+}
+
+func (c *Command) Error(err error) {
+	c.entry.Error = err.Error()
+	c.done()
+}
+
+func (c *Command) done() {
+	c.once.Do(func() {
+		c.entry.DurationMs = c.s.now().Sub(c.entry.Time).Milliseconds()
+		c.entry.Request = c.req.String()
+		if c.resp == nil {
+			c.entry.Response = elided
+		} else {
+			c.entry.Response = c.resp.String()
+		}
+		c.s.record(c.id, c.entry)
+	})
+}
+
+func isScreenshot(cmd string) bool {
+	return strings.HasSuffix(cmd, "screenshot") || strings.HasSuffix(cmd, "screenshot/full")
+}
+
+type limitedBuffer struct {
+	mtx       sync.Mutex
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if rest := b.limit - b.buf.Len(); rest < len(p) {
+		b.buf.Write(p[:max(rest, 0)])
+		b.truncated = true
+	} else {
+		b.buf.Write(p)
+	}
+	return len(p), nil
+}
+
+func (b *limitedBuffer) String() string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+	if b.truncated {
+		return b.buf.String() + "...<truncated>"
+	}
+	return b.buf.String()
+}
+
+type teeReadCloser struct {
+	io.ReadCloser
+	w io.Writer
+}
+
+func (t *teeReadCloser) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	if n > 0 {
+		_, _ = t.w.Write(p[:n])
+	}
+	return n, err
+}
+
+type closeNotifier struct {
+	io.ReadCloser
+	onClose func()
+}
+
+func (c *closeNotifier) Close() error {
+	err := c.ReadCloser.Close()
+	c.onClose()
+	return err
+}
@@ -0,0 +1,249 @@
+package cmdlog
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/selebrow/selebrow/internal/common/clock"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+const (
+	// maxEntries limits number of entries kept per session, the oldest entries are dropped
+	maxEntries = 10000
+	// maxBodySize limits size of the recorded request and response bodies
+	maxBodySize = 4096
+
+	// eventsBufferSize is large enough to absorb session deletion bursts while logs are persisted,
+	// publisher is never blocked by slow disk
+	eventsBufferSize = 1000
+)
+
+var ErrNotFound = errors.New("command log not found")
+
+type Entry struct {
+	Time       time.Time `json:"time"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Status     int       `json:"status,omitempty"`
+	Request    string    `json:"request,omitempty"`
+	Response   string    `json:"response,omitempty"`
+	DurationMs int64     `json:"durationMs"`
+	Error      string    `json:"error,omitempty"`
+}
+
+type Log struct {
+	SessionID string  `json:"sessionId"`
+	Dropped   int     `json:"dropped,omitempty"`
+	Entries   []Entry `json:"entries"`
+}
+
+// entries is a fixed size ring buffer of recorded commands, the oldest entries are overwritten
+type entries struct {
+	buf     []Entry
+	start   int
+	dropped int
+}
+
+func (r *entries) add(e Entry) {
+	if len(r.buf) < maxEntries {
+		r.buf = append(r.buf, e)
+		return
+	}
+	r.buf[r.start] = e
+	r.start = (r.start + 1) % len(r.buf)
+	r.dropped++
+}
+
+// log returns copy of recorded entries in chronological order
+func (r *entries) log(id string) *Log {
+	res := make([]Entry, 0, len(r.buf))
+	res = append(res, r.buf[r.start:]...)
+	res = append(res, r.buf[:r.start]...)
+	return &Log{SessionID: id, Dropped: r.dropped, Entries: res}
+}
+
+type CommandLog interface {
+	// Enabled returns true if commands of the session should be recorded
+	Enabled(sess *session.Session) bool
+	// Begin starts capturing of the proxied command, entry is recorded when the response body is closed
+	Begin(sess *session.Session, cmd string, r *http.Request) *Command
+	// Get returns command log of the running session or persisted log of the deleted session
+	Get(id string) (*Log, error)
+}
+
+// Store keeps command logs of running Webdriver sessions in memory and persists them to the directory
+// when session is deleted
+type Store struct {
+	mtx    sync.Mutex
+	logs   map[string]*entries
+	dir    string
+	global bool
+	now    clock.NowFunc
+	eb     event.EventBroker
+	ch     <-chan evmodels.IEvent
+	done   chan struct{}
+	l      *zap.SugaredLogger
+}
+
+func NewStore(dir string, global bool, now clock.NowFunc, l *zap.Logger) *Store {
+	return &Store{
+		logs:   make(map[string]*entries),
+		dir:    dir,
+		global: global,
+		now:    now,
+		l:      l.Sugar(),
+	}
+}
+
+func (s *Store) Enabled(sess *session.Session) bool {
+	if s.global {
+		return true
+	}
+	wc, ok := sess.ReqCaps().(capabilities.WDCapabilities)
+	return ok && wc.IsCommandLogEnabled()
+}
+
+func (s *Store) Get(id string) (*Log, error) {
+	s.mtx.Lock()
+	log, ok := s.logs[id]
+	if ok {
+		res := log.log(id)
+		s.mtx.Unlock()
+		return res, nil
+	}
+	s.mtx.Unlock()
+
+	return s.load(id)
+}
+
+func (s *Store) track(id string) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if _, ok := s.logs[id]; !ok {
+		s.logs[id] = &entries{}
+	}
+}
+
+// record appends entry to the log, entries of commands completed after session deletion are discarded
+func (s *Store) record(id string, e Entry) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	log, ok := s.logs[id]
+	if !ok {
+		return
+	}
+	log.add(e)
+}
+
+// Finish persists log of the deleted session and removes it from memory
+func (s *Store) Finish(id string) {
+	s.mtx.Lock()
+	log, ok := s.logs[id]
+	delete(s.logs, id)
+	s.mtx.Unlock()
+	if !ok {
+		return
+	}
+
+	if err := s.persist(log.log(id)); err != nil {
+		s.l.Errorw("failed to persist command log", zap.String("session_id", id), zap.Error(err))
+	}
+}
+
+func (s *Store) persist(log *Log) error {
+	fileName, err := s.fileName(log.SessionID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o755); err != nil {
+		return errors.Wrap(err, "failed to create command logs directory")
+	}
+	b, err := json.Marshal(log)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal command log")
+	}
+	return os.WriteFile(fileName, b, 0o644)
+}
+
+func (s *Store) load(id string) (*Log, error) {
+	fileName, err := s.fileName(id)
+	if err != nil {
+		return nil, ErrNotFound
+	}
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Wrap(err, "failed to read command log")
+	}
+	var log Log
+	if err := json.Unmarshal(b, &log); err != nil {
+		return nil, errors.Wrap(err, "failed to parse command log")
+	}
+	return &log, nil
+}
+
+func (s *Store) fileName(id string) (string, error) {
+	if s.dir == "" {
+		return "", errors.New("command logs directory is not configured")
+	}
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Contains(id, "..") {
+		return "", errors.Errorf("invalid session id %s", id)
+	}
+	return filepath.Join(s.dir, id+".json"), nil
+}
+
+// Start persists logs on deletion of Webdriver sessions
+func (s *Store) Start(eb event.EventBroker) {
+	s.eb = eb
+	s.ch = eb.SubscribeWithOptions(event.SubscriptionOptions{
+		BufferSize: eventsBufferSize,
+	}, evmodels.SessionDeletedEventType)
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		for ev := range s.ch {
+			if e, ok := ev.(*evmodels.Event[evmodels.SessionDeleted]); ok && e.Attributes.Protocol == models.WebdriverProtocol {
+				s.Finish(e.Attributes.ID)
+			}
+		}
+	}()
+}
+
+// Shutdown stops listening for events and persists logs of still running sessions
+func (s *Store) Shutdown(ctx context.Context) error {
+	if s.ch != nil {
+		s.eb.Unsubscribe(s.ch)
+		select {
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	s.mtx.Lock()
+	ids := slices.Collect(maps.Keys(s.logs))
+	s.mtx.Unlock()
+	for _, id := range ids {
+		s.Finish(id)
+	}
+	return nil
+}
@@ -0,0 +1,195 @@
+package cmdlog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestStore_Enabled(t *testing.T) {
+	g := NewWithT(t)
+
+	caps := &models.Capabilities{}
+	sess := session.NewSession("123", "", nil, caps, nil, time.Time{}, nil, nil)
+
+	s := NewStore("", true, time.Now, zaptest.NewLogger(t))
+	g.Expect(s.Enabled(sess)).To(BeTrue())
+
+	s = NewStore("", false, time.Now, zaptest.NewLogger(t))
+	g.Expect(s.Enabled(sess)).To(BeFalse())
+	caps.SelenoidOptions = &models.SelenoidOptions{CommandLog: true}
+	g.Expect(s.Enabled(sess)).To(BeTrue())
+}
+
+func TestStore_Record(t *testing.T) {
+	g := NewWithT(t)
+
+	now, tick := testClock()
+	s := NewStore(t.TempDir(), true, now, zaptest.NewLogger(t))
+	sess := session.NewSession("123", "", nil, nil, nil, time.Time{}, nil, nil)
+
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost/wd/hub/session/123/url", strings.NewReader(`{"url":"http://example.com"}`))
+	cmd := s.Begin(sess, "url", req)
+	_, _ = io.ReadAll(req.Body)
+
+	tick(150 * time.Millisecond)
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"value":null}`))}
+	cmd.Response(resp)
+	_, _ = io.ReadAll(resp.Body)
+
+	log, err := s.Get("123")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(log.Entries).To(BeEmpty())
+
+	g.Expect(resp.Body.Close()).To(Succeed())
+	log, err = s.Get("123")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(log).To(Equal(&Log{
+		SessionID: "123",
+		Entries: []Entry{{
+			Time:       time.UnixMilli(1000),
+			Method:     http.MethodPost,
+			Path:       "/url",
+			Status:     http.StatusOK,
+			Request:    `{"url":"http://example.com"}`,
+			Response:   `{"value":null}`,
+			DurationMs: 150,
+		}},
+	}))
+}
+
+func TestStore_RecordScreenshotAndError(t *testing.T) {
+	g := NewWithT(t)
+
+	now, _ := testClock()
+	s := NewStore(t.TempDir(), true, now, zaptest.NewLogger(t))
+	sess := session.NewSession("123", "", nil, nil, nil, time.Time{}, nil, nil)
+
+	req, _ := http.NewRequest(http.MethodGet, "http://localhost/wd/hub/session/123/screenshot", http.NoBody)
+	cmd := s.Begin(sess, "screenshot", req)
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"value":"iVBORw0KGgo="}`))}
+	cmd.Response(resp)
+	_, _ = io.ReadAll(resp.Body)
+	g.Expect(resp.Body.Close()).To(Succeed())
+
+	req, _ = http.NewRequest(http.MethodGet, "http://localhost/wd/hub/session/123/title", http.NoBody)
+	cmd = s.Begin(sess, "title", req)
+	cmd.Error(errors.New("connection refused"))
+
+	log, err := s.Get("123")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(log.Entries).To(HaveLen(2))
+	g.Expect(log.Entries[0].Response).To(Equal("<elided>"))
+	g.Expect(log.Entries[0].Status).To(Equal(http.StatusOK))
+	g.Expect(log.Entries[1].Path).To(Equal("/title"))
+	g.Expect(log.Entries[1].Error).To(Equal("connection refused"))
+}
+
+func TestStore_TruncateBody(t *testing.T) {
+	g := NewWithT(t)
+
+	s := NewStore(t.TempDir(), true, time.Now, zaptest.NewLogger(t))
+	sess := session.NewSession("123", "", nil, nil, nil, time.Time{}, nil, nil)
+
+	body := bytes.Repeat([]byte("a"), maxBodySize+10)
+	req, _ := http.NewRequest(http.MethodPost, "http://localhost/wd/hub/session/123/execute/sync", bytes.NewReader(body))
+	cmd := s.Begin(sess, "execute/sync", req)
+	_, _ = io.ReadAll(req.Body)
+	cmd.Error(errors.New("test"))
+
+	log, err := s.Get("123")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(log.Entries[0].Request).To(Equal(string(body[:maxBodySize]) + "...<truncated>"))
+}
+
+func TestStore_MaxEntries(t *testing.T) {
+	g := NewWithT(t)
+
+	s := NewStore(t.TempDir(), true, time.Now, zaptest.NewLogger(t))
+	s.track("123")
+	for i := 0; i < maxEntries+2; i++ {
+		s.record("123", Entry{DurationMs: int64(i)})
+	}
+
+	log, err := s.Get("123")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(log.Dropped).To(Equal(2))
+	g.Expect(log.Entries).To(HaveLen(maxEntries))
+	g.Expect(log.Entries[0].DurationMs).To(Equal(int64(2)))
+}
+
+func TestStore_Persist(t *testing.T) {
+	g := NewWithT(t)
+
+	dir := filepath.Join(t.TempDir(), "commands")
+	s := NewStore(dir, true, time.Now, zaptest.NewLogger(t))
+	eb := event.NewEventBrokerImpl(10, zaptest.NewLogger(t))
+	s.Start(eb)
+
+	s.track("123")
+	s.record("123", Entry{Method: http.MethodGet, Path: "/title", Status: http.StatusOK})
+	s.track("456")
+
+	eb.Publish(evmodels.NewEvent(evmodels.SessionDeletedEventType, time.Now(), evmodels.SessionDeleted{
+		Protocol: models.PlaywrightProtocol,
+		ID:       "456",
+	}))
+	eb.Publish(evmodels.NewEvent(evmodels.SessionDeletedEventType, time.Now(), evmodels.SessionDeleted{
+		Protocol: models.WebdriverProtocol,
+		ID:       "123",
+	}))
+
+	g.Eventually(func() error {
+		_, err := os.Stat(filepath.Join(dir, "123.json"))
+		return err
+	}).Should(Succeed())
+
+	// entries of the deleted session are discarded
+	s.record("123", Entry{Method: http.MethodGet, Path: "/url"})
+
+	log, err := s.Get("123")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(log).To(Equal(&Log{
+		SessionID: "123",
+		Entries:   []Entry{{Method: http.MethodGet, Path: "/title", Status: http.StatusOK}},
+	}))
+
+	_, err = os.Stat(filepath.Join(dir, "456.json"))
+	g.Expect(os.IsNotExist(err)).To(BeTrue())
+
+	g.Expect(s.Shutdown(context.Background())).To(Succeed())
+	log, err = s.Get("456")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(log.SessionID).To(Equal("456"))
+	g.Expect(log.Entries).To(BeEmpty())
+}
+
+func TestStore_GetNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	s := NewStore(t.TempDir(), true, time.Now, zaptest.NewLogger(t))
+	_, err := s.Get("123")
+	g.Expect(err).To(MatchError(ErrNotFound))
+	_, err = s.Get("../123")
+	g.Expect(err).To(MatchError(ErrNotFound))
+}
+
+func testClock() (func() time.Time, func(d time.Duration)) {
+	t := time.UnixMilli(1000)
+	return func() time.Time { return t }, func(d time.Duration) { t = t.Add(d) }
+}
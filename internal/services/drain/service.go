@@ -0,0 +1,106 @@
+package drain
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+var (
+	ErrShuttingDown = errors.New("server is shutting down")
+	ErrDraining     = errors.New("server is draining, new sessions are not accepted")
+)
+
+// DrainService rejects new sessions while letting the running ones to complete,
+// draining is triggered either manually via admin API or on shutdown
+type DrainService interface {
+	Drain() bool
+	Resume() error
+	Draining() bool
+	ActiveSessions() int
+}
+
+type DrainServiceImpl struct {
+	storage  session.SessionStorage
+	interval time.Duration
+	draining bool
+	shutdown bool
+	mtx      sync.RWMutex
+	l        *zap.SugaredLogger
+}
+
+func NewDrainService(storage session.SessionStorage, interval time.Duration, l *zap.Logger) *DrainServiceImpl {
+	return &DrainServiceImpl{
+		storage:  storage,
+		interval: interval,
+		l:        l.Sugar(),
+	}
+}
+
+// Drain switches service to drain mode, returns false if it's already draining
+func (d *DrainServiceImpl) Drain() bool {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.draining {
+		return false
+	}
+	d.draining = true
+	d.l.Infof("drain mode enabled, %d active sessions", d.ActiveSessions())
+	return true
+}
+
+// Resume switches service back to normal mode, it's not possible after shutdown was initiated
+func (d *DrainServiceImpl) Resume() error {
+	d.mtx.Lock()
+	defer d.mtx.Unlock()
+	if d.shutdown {
+		return ErrShuttingDown
+	}
+	if d.draining {
+		d.draining = false
+		d.l.Info("drain mode disabled")
+	}
+	return nil
+}
+
+func (d *DrainServiceImpl) Draining() bool {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	return d.draining
+}
+
+func (d *DrainServiceImpl) ActiveSessions() int {
+	n := 0
+	for _, p := range models.Protocols {
+		n += len(d.storage.List(p))
+	}
+	return n
+}
+
+// Shutdown enables drain mode permanently and waits until all the active sessions are completed
+func (d *DrainServiceImpl) Shutdown(ctx context.Context) error {
+	d.mtx.Lock()
+	d.shutdown = true
+	d.mtx.Unlock()
+	d.Drain()
+
+	t := time.NewTicker(d.interval)
+	defer t.Stop()
+	for {
+		n := d.ActiveSessions()
+		if n == 0 {
+			return nil
+		}
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			return errors.Wrapf(ctx.Err(), "%d sessions are still active", n)
+		}
+	}
+}
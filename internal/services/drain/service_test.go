@@ -0,0 +1,71 @@
+package drain
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestDrainService_DrainResume(t *testing.T) {
+	g := NewWithT(t)
+
+	storage := mocks.NewSessionStorage(t)
+	storage.EXPECT().List(models.WebdriverProtocol).Return([]*session.Session{{}})
+	storage.EXPECT().List(models.PlaywrightProtocol).Return(nil)
+	storage.EXPECT().List(models.PuppeteerProtocol).Return([]*session.Session{{}})
+
+	d := NewDrainService(storage, time.Millisecond, zap.NewNop())
+	g.Expect(d.Draining()).To(BeFalse())
+
+	g.Expect(d.Drain()).To(BeTrue())
+	g.Expect(d.Draining()).To(BeTrue())
+	g.Expect(d.Drain()).To(BeFalse())
+	g.Expect(d.ActiveSessions()).To(Equal(2))
+
+	g.Expect(d.Resume()).To(Succeed())
+	g.Expect(d.Draining()).To(BeFalse())
+	g.Expect(d.Resume()).To(Succeed())
+}
+
+func TestDrainService_Shutdown(t *testing.T) {
+	g := NewWithT(t)
+
+	storage := mocks.NewSessionStorage(t)
+	storage.EXPECT().List(models.WebdriverProtocol).Return([]*session.Session{{}, {}}).Times(3)
+	storage.EXPECT().List(models.WebdriverProtocol).Return(nil)
+	storage.EXPECT().List(models.PlaywrightProtocol).Return([]*session.Session{{}}).Twice()
+	storage.EXPECT().List(models.PlaywrightProtocol).Return(nil)
+	storage.EXPECT().List(models.PuppeteerProtocol).Return(nil)
+
+	d := NewDrainService(storage, time.Millisecond, zap.NewNop())
+
+	err := d.Shutdown(context.Background())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(d.Draining()).To(BeTrue())
+	g.Expect(d.Resume()).To(MatchError(ErrShuttingDown))
+	g.Expect(d.Draining()).To(BeTrue())
+}
+
+func TestDrainService_Shutdown_Timeout(t *testing.T) {
+	g := NewWithT(t)
+
+	storage := mocks.NewSessionStorage(t)
+	storage.EXPECT().List(models.WebdriverProtocol).Return([]*session.Session{{}})
+	storage.EXPECT().List(models.PlaywrightProtocol).Return(nil)
+	storage.EXPECT().List(models.PuppeteerProtocol).Return(nil)
+
+	d := NewDrainService(storage, time.Millisecond, zap.NewNop())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := d.Shutdown(ctx)
+	g.Expect(err).To(MatchError(context.DeadlineExceeded))
+	g.Expect(err).To(MatchError(ContainSubstring("1 sessions are still active")))
+}
@@ -0,0 +1,127 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/selebrow/selebrow/pkg/dto"
+)
+
+type CheckFunc func(ctx context.Context) error
+
+type HealthService interface {
+	Liveness(ctx context.Context) *dto.Health
+	Readiness(ctx context.Context) *dto.Health
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+	// threshold of consecutive failures after which liveness check fails, readiness check fails immediately
+	threshold int32
+	failures  *atomic.Int32
+}
+
+// HealthServiceImpl runs registered component checks concurrently, each limited by the timeout.
+// Liveness failures are also reported as readiness failures
+type HealthServiceImpl struct {
+	liveness  []check
+	readiness []check
+	timeout   time.Duration
+}
+
+func NewHealthService(timeout time.Duration) *HealthServiceImpl {
+	return &HealthServiceImpl{timeout: timeout}
+}
+
+// AddLivenessCheck registers check which failure means instance is wedged and must be restarted
+func (h *HealthServiceImpl) AddLivenessCheck(name string, fn CheckFunc) {
+	h.AddLivenessCheckThreshold(name, fn, 1)
+}
+
+// AddLivenessCheckThreshold registers check which failure means instance can't accept new sessions
+// and threshold consecutive liveness failures mean instance is wedged and must be restarted
+func (h *HealthServiceImpl) AddLivenessCheckThreshold(name string, fn CheckFunc, threshold int) {
+	h.liveness = append(h.liveness, check{name: name, fn: fn, threshold: int32(threshold), failures: new(atomic.Int32)})
+}
+
+// AddReadinessCheck registers check which failure means instance can't accept new sessions
+func (h *HealthServiceImpl) AddReadinessCheck(name string, fn CheckFunc) {
+	h.readiness = append(h.readiness, check{name: name, fn: fn})
+}
+
+func (h *HealthServiceImpl) Liveness(ctx context.Context) *dto.Health {
+	return h.run(ctx, h.liveness, true)
+}
+
+func (h *HealthServiceImpl) Readiness(ctx context.Context) *dto.Health {
+	return h.run(ctx, slices.Concat(h.liveness, h.readiness), false)
+}
+
+func (h *HealthServiceImpl) run(ctx context.Context, checks []check, liveness bool) *dto.Health {
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	results := make([]dto.HealthCheck, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = runCheck(ctx, c.fn)
+			if liveness {
+				results[i] = c.applyThreshold(results[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	res := &dto.Health{
+		Status: dto.HealthStatusOK,
+		Checks: make(map[string]dto.HealthCheck, len(checks)),
+	}
+	for i, c := range checks {
+		if results[i].Status != dto.HealthStatusOK {
+			res.Status = dto.HealthStatusFail
+		}
+		res.Checks[c.name] = results[i]
+	}
+	return res
+}
+
+// applyThreshold reports liveness failure only after threshold consecutive failures
+func (c check) applyThreshold(res dto.HealthCheck) dto.HealthCheck {
+	if res.Status == dto.HealthStatusOK {
+		c.failures.Store(0)
+		return res
+	}
+	n := c.failures.Add(1)
+	if n < c.threshold {
+		res.Status = dto.HealthStatusOK
+		res.Error = fmt.Sprintf("%d of %d consecutive failures: %s", n, c.threshold, res.Error)
+	}
+	return res
+}
+
+func runCheck(ctx context.Context, fn CheckFunc) dto.HealthCheck {
+	done := make(chan error, 1)
+	go func() {
+		done <- fn(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	if err != nil {
+		return dto.HealthCheck{Status: dto.HealthStatusFail, Error: err.Error()}
+	}
+	return dto.HealthCheck{Status: dto.HealthStatusOK}
+}
@@ -0,0 +1,91 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/pkg/dto"
+)
+
+func TestHealthServiceImpl(t *testing.T) {
+	g := NewWithT(t)
+
+	h := NewHealthService(50 * time.Millisecond)
+	h.AddLivenessCheck("watcher", func(_ context.Context) error {
+		return nil
+	})
+	h.AddReadinessCheck("docker", func(_ context.Context) error {
+		return errors.New("connection refused")
+	})
+	h.AddReadinessCheck("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(time.Second)
+		return nil
+	})
+	h.AddReadinessCheck("storage", func(_ context.Context) error {
+		return nil
+	})
+
+	g.Expect(h.Liveness(context.Background())).To(Equal(&dto.Health{
+		Status: dto.HealthStatusOK,
+		Checks: map[string]dto.HealthCheck{
+			"watcher": {Status: dto.HealthStatusOK},
+		},
+	}))
+
+	g.Expect(h.Readiness(context.Background())).To(Equal(&dto.Health{
+		Status: dto.HealthStatusFail,
+		Checks: map[string]dto.HealthCheck{
+			"watcher": {Status: dto.HealthStatusOK},
+			"docker":  {Status: dto.HealthStatusFail, Error: "connection refused"},
+			"slow":    {Status: dto.HealthStatusFail, Error: "context deadline exceeded"},
+			"storage": {Status: dto.HealthStatusOK},
+		},
+	}))
+}
+
+func TestHealthServiceImpl_LivenessFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	h := NewHealthService(time.Second)
+	h.AddLivenessCheck("watcher", func(_ context.Context) error {
+		return errors.New("not running")
+	})
+
+	g.Expect(h.Liveness(context.Background()).OK()).To(BeFalse())
+	res := h.Readiness(context.Background())
+	g.Expect(res.OK()).To(BeFalse())
+	g.Expect(res.Checks).To(HaveKeyWithValue("watcher", dto.HealthCheck{Status: dto.HealthStatusFail, Error: "not running"}))
+}
+
+func TestHealthServiceImpl_LivenessThreshold(t *testing.T) {
+	g := NewWithT(t)
+
+	h := NewHealthService(time.Second)
+	var pingErr error
+	h.AddLivenessCheckThreshold("docker", func(_ context.Context) error {
+		return pingErr
+	}, 2)
+
+	pingErr = errors.New("connection refused")
+	g.Expect(h.Readiness(context.Background()).OK()).To(BeFalse())
+	res := h.Liveness(context.Background())
+	g.Expect(res.OK()).To(BeTrue())
+	g.Expect(res.Checks).To(HaveKeyWithValue("docker", dto.HealthCheck{
+		Status: dto.HealthStatusOK,
+		Error:  "1 of 2 consecutive failures: connection refused",
+	}))
+	res = h.Liveness(context.Background())
+	g.Expect(res.OK()).To(BeFalse())
+	g.Expect(res.Checks).To(HaveKeyWithValue("docker", dto.HealthCheck{Status: dto.HealthStatusFail, Error: "connection refused"}))
+
+	// success resets consecutive failures
+	pingErr = nil
+	g.Expect(h.Liveness(context.Background()).OK()).To(BeTrue())
+	pingErr = errors.New("connection refused")
+	g.Expect(h.Liveness(context.Background()).OK()).To(BeTrue())
+}
@@ -0,0 +1,34 @@
+package pw
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/net/proxy"
+
+	"github.com/selebrow/selebrow/internal/common/clock"
+	"github.com/selebrow/selebrow/internal/services/reset"
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/pkg/browser"
+	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/event"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+// NewPuppeteerSessionService creates session service for Puppeteer browser server containers,
+// sessions lifecycle is the same as for Playwright
+func NewPuppeteerSessionService(
+	mgr browser.BrowserManager,
+	sStorage session.SessionStorage,
+	eb event.EventBroker,
+	resetter reset.BrowserResetter,
+	d proxy.ContextDialer,
+	cfg config.PWSessionConfig,
+	checkConn bool,
+	now clock.NowFunc,
+	cleanupInterval time.Duration,
+	l *zap.Logger,
+) *PWSessionService {
+	return newSessionService(
+		models.PuppeteerProtocol, "Puppeteer", mgr, sStorage, eb, resetter, d, cfg, checkConn, now, cleanupInterval, l)
+}
@@ -9,13 +9,18 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"golang.org/x/net/proxy"
 
 	"github.com/selebrow/selebrow/internal/common/clock"
+	"github.com/selebrow/selebrow/internal/services/reset"
 	"github.com/selebrow/selebrow/internal/services/session"
 	"github.com/selebrow/selebrow/pkg/browser"
 	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
@@ -25,34 +30,81 @@ var (
 	genSessionID = uuid.NewString
 )
 
+// PWSessionService manages sessions of browser server containers proxied over a single websocket connection,
+// it serves both Playwright and Puppeteer protocols
 type PWSessionService struct {
+	protocol      models.BrowserProtocol
+	name          string
 	mgr           browser.BrowserManager
 	createTimeout time.Duration
+	defTimeout    time.Duration
+	maxTimeout    time.Duration
+	maxLifetime   time.Duration
 	d             proxy.ContextDialer
 	l             *zap.SugaredLogger
 	checkConn     bool
 	now           clock.NowFunc
 	sStorage      session.SessionStorage
+	eb            event.EventBroker
+	resetter      reset.BrowserResetter
+	cancel        context.CancelFunc
+	done          chan struct{}
 }
 
 func NewPWSessionService(
 	mgr browser.BrowserManager,
 	sStorage session.SessionStorage,
+	eb event.EventBroker,
+	resetter reset.BrowserResetter,
 	d proxy.ContextDialer,
-	createTimeout time.Duration,
+	cfg config.PWSessionConfig,
 	checkConn bool,
 	now clock.NowFunc,
+	cleanupInterval time.Duration,
 	l *zap.Logger,
 ) *PWSessionService {
-	return &PWSessionService{
+	return newSessionService(
+		models.PlaywrightProtocol, "Playwright", mgr, sStorage, eb, resetter, d, cfg, checkConn, now, cleanupInterval, l)
+}
+
+func newSessionService(
+	protocol models.BrowserProtocol,
+	name string,
+	mgr browser.BrowserManager,
+	sStorage session.SessionStorage,
+	eb event.EventBroker,
+	resetter reset.BrowserResetter,
+	d proxy.ContextDialer,
+	cfg config.PWSessionConfig,
+	checkConn bool,
+	now clock.NowFunc,
+	cleanupInterval time.Duration,
+	l *zap.Logger,
+) *PWSessionService {
+	s := &PWSessionService{
+		protocol:      protocol,
+		name:          name,
 		mgr:           mgr,
-		createTimeout: createTimeout,
+		createTimeout: cfg.CreateTimeout(),
+		defTimeout:    cfg.PWSessionTimeout(),
+		maxTimeout:    cfg.MaxSessionTimeout(),
+		maxLifetime:   cfg.MaxSessionLifetime(),
 		d:             d,
 		checkConn:     checkConn,
 		l:             l.Sugar(),
 		sStorage:      sStorage,
+		eb:            eb,
+		resetter:      resetter,
 		now:           now,
 	}
+
+	if cleanupInterval > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		s.cancel = cancel
+		s.done = make(chan struct{})
+		go s.cleanupSessions(ctx, cleanupInterval)
+	}
+	return s
 }
 
 func (s *PWSessionService) CreateSession(ctx context.Context, caps capabilities.Capabilities) (*session.Session, error) {
@@ -69,7 +121,10 @@ func (s *PWSessionService) CreateSession(ctx context.Context, caps capabilities.
 	id := genSessionID()
 	sCtx, cancel := context.WithCancel(ctx)
 	sess := session.NewSession(id, browser.DefaultPlatform, br, caps, nil, s.now(), sCtx, cancel)
-	if err := s.sStorage.Add(models.PlaywrightProtocol, sess); err != nil {
+	sess.SetLastUsed(s.now())
+	sess.SetTimeout(s.sessionTimeout(caps))
+	sess.SetSpanContext(trace.SpanContextFromContext(ctx))
+	if err := s.sStorage.Add(s.protocol, sess); err != nil {
 		br.Close(context.Background(), true)
 		return nil, errors.Wrap(err, "failed to store session")
 	}
@@ -78,13 +133,13 @@ func (s *PWSessionService) CreateSession(ctx context.Context, caps capabilities.
 		zap.String("browser_name", caps.GetName()),
 		zap.String("browser_version", caps.GetVersion()),
 		zap.String("url", br.GetURL().String())).
-		Infof("Playwright session is ready in %v", time.Since(start))
+		Infof("%s session is ready in %v", s.name, time.Since(start))
 	return sess, err
 }
 
 func (s *PWSessionService) FindSession(id string) (*session.Session, error) {
 	// not used currently
-	sess, ok := s.sStorage.Get(models.PlaywrightProtocol, id)
+	sess, ok := s.sStorage.Get(s.protocol, id)
 	if !ok {
 		return nil, fmt.Errorf("session %s doesn't exist", id)
 	}
@@ -92,28 +147,52 @@ func (s *PWSessionService) FindSession(id string) (*session.Session, error) {
 }
 
 func (s *PWSessionService) ListSessions() []*session.Session {
-	return s.sStorage.List(models.PlaywrightProtocol)
+	return s.sStorage.List(s.protocol)
 }
 
 func (s *PWSessionService) DeleteSession(sess *session.Session) {
-	if !s.sStorage.Delete(models.PlaywrightProtocol, sess.ID()) {
+	if !s.sStorage.Delete(s.protocol, sess.ID()) {
 		return
 	}
 
 	sess.Cancel()() // cancel context to reset any active connections
-	sess.Browser().Close(context.Background(), false)
-	s.l.Infow("Playwright session has been deleted", zap.String("session_id", sess.ID()))
+	sess.Browser().Close(context.Background(), !s.resetBrowser(sess))
+	s.l.Infow(s.name+" session has been deleted", zap.String("session_id", sess.ID()))
+}
+
+func (s *PWSessionService) resetBrowser(sess *session.Session) bool {
+	if s.resetter == nil {
+		return true
+	}
+	err := s.resetter.Reset(context.Background(), s.protocol, sess.ReqCaps(), sess.Browser())
+	if err != nil {
+		if !errors.Is(err, reset.ErrNotReusable) {
+			s.l.Warnw("failed to reset browser", zap.String("session_id", sess.ID()), zap.Error(err))
+		}
+		return false
+	}
+	return true
 }
 
 func (s *PWSessionService) createBrowser(ctx context.Context, caps capabilities.Capabilities) (browser.Browser, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.createTimeout)
 	defer cancel()
-	br, err := s.mgr.Allocate(ctx, models.PlaywrightProtocol, caps)
+	br, err := s.mgr.Allocate(ctx, s.protocol, caps)
 	if err != nil {
-		return nil, models.WrapTimeoutErr(err, "failed to allocate playwright browser")
+		return nil, models.WrapTimeoutErr(err, fmt.Sprintf("failed to allocate %s browser", s.protocol))
 	}
 
-	err = s.waitBrowserServerStarted(ctx, br.GetURL().Host)
+	hostport := br.GetURL().Host
+	if pc, ok := caps.(*models.PWCapabilities); ok && pc.IsCDPEnabled() {
+		hostport = br.GetHostPort(models.DevtoolsPort)
+		if hostport == "" {
+			br.Close(context.Background(), false)
+			return nil, models.NewBadRequestError(
+				errors.Errorf("browser %s does not support Chrome DevTools Protocol", caps.GetName()))
+		}
+	}
+
+	err = s.waitBrowserServerStarted(ctx, hostport)
 	if err != nil {
 		br.Close(context.Background(), true)
 		return nil, models.WrapTimeoutErr(err, "browser server did not get ready within configured timeout")
@@ -161,3 +240,71 @@ func (s *PWSessionService) waitBrowserServerStarted(ctx context.Context, hostpor
 		}
 	}
 }
+
+func (s *PWSessionService) sessionTimeout(caps capabilities.Capabilities) time.Duration {
+	timeout := caps.GetTimeout()
+	if timeout <= 0 {
+		timeout = s.defTimeout
+	}
+	if timeout > s.maxTimeout {
+		timeout = s.maxTimeout
+	}
+	return timeout
+}
+
+func (s *PWSessionService) cleanupSessions(ctx context.Context, cleanupInterval time.Duration) {
+	t := time.NewTicker(cleanupInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			s.cleanupExpiredSessions()
+		case <-ctx.Done():
+			close(s.done)
+			return
+		}
+	}
+}
+
+// cleanupExpiredSessions deletes sessions with no websocket traffic for longer than session timeout
+// and sessions exceeding max lifetime, deleting session cancels its context which closes the websocket
+func (s *PWSessionService) cleanupExpiredSessions() {
+	for _, sess := range s.ListSessions() {
+		l := s.l.With(zap.String("session_id", sess.ID()))
+		now := s.now()
+		if age := now.Sub(sess.Created()); s.maxLifetime > 0 && age > s.maxLifetime {
+			l.Infof("closing %s session running for %v: max session lifetime %v is reached", s.name, age, s.maxLifetime)
+			s.DeleteSession(sess)
+			continue
+		}
+
+		timeout := sess.Timeout()
+		if idle := now.Sub(sess.LastUsed()); timeout > 0 && idle > timeout {
+			l.Infof("closing %s session idle for %v: sessionTimeout %v is reached", s.name, idle, timeout)
+			s.DeleteSession(sess)
+			caps := sess.ReqCaps()
+			s.eb.Publish(evmodels.NewSessionIdleTimeoutEvent(evmodels.SessionIdleTimeout{
+				Protocol:       s.protocol,
+				ID:             sess.ID(),
+				BrowserName:    caps.GetName(),
+				BrowserVersion: caps.GetVersion(),
+				Labels:         caps.GetLabels(),
+				IdleDuration:   idle,
+				Timeout:        timeout,
+			}))
+		}
+	}
+}
+
+func (s *PWSessionService) Shutdown(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.done:
+		return nil
+	}
+}
@@ -14,10 +14,12 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap/zaptest"
 
+	"github.com/selebrow/selebrow/internal/services/reset"
 	"github.com/selebrow/selebrow/internal/services/session"
 	"github.com/selebrow/selebrow/mocks"
 	"github.com/selebrow/selebrow/pkg/browser"
 	"github.com/selebrow/selebrow/pkg/capabilities"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
@@ -30,7 +32,7 @@ func TestPWSessionServiceImpl_CreateSession(t *testing.T) {
 	ss := new(mocks.SessionStorage)
 	testTime := time.UnixMilli(123)
 	now := func() time.Time { return testTime }
-	s := NewPWSessionService(m, ss, d, time.Second, false, now, zaptest.NewLogger(t))
+	s := NewPWSessionService(m, ss, nil, nil, d, createCfg(t, time.Second), false, now, 0, zaptest.NewLogger(t))
 
 	br := new(mocks.Browser)
 
@@ -65,6 +67,8 @@ func TestPWSessionServiceImpl_CreateSession(t *testing.T) {
 		g.Expect(sess.Browser()).To(BeIdenticalTo(br))
 		g.Expect(sess.ReqCaps()).To(BeIdenticalTo(caps))
 		g.Expect(sess.Created()).To(Equal(testTime))
+		g.Expect(sess.LastUsed()).To(Equal(testTime))
+		g.Expect(sess.Timeout()).To(Equal(time.Minute))
 		g.Expect(sess.Context()).ToNot(BeNil())
 		g.Expect(sess.Cancel()).ToNot(BeNil())
 		savedSess = sess
@@ -88,7 +92,7 @@ func TestPWSessionServiceImpl_CreateSession_Allocate_Error(t *testing.T) {
 	m := new(mocks.BrowserManager)
 	d := new(mocks.ContextDialer)
 	ss := new(mocks.SessionStorage)
-	s := NewPWSessionService(m, ss, d, time.Second, false, nil, zaptest.NewLogger(t))
+	s := NewPWSessionService(m, ss, nil, nil, d, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
 
 	ss.EXPECT().IsShutdown().Return(false).Once()
 
@@ -106,7 +110,7 @@ func TestPWServiceImpl_CreateSession_Allocate_Timeout(t *testing.T) {
 	m := new(mocks.BrowserManager)
 	d := new(mocks.ContextDialer)
 	ss := new(mocks.SessionStorage)
-	s := NewPWSessionService(m, ss, d, time.Nanosecond, false, nil, zaptest.NewLogger(t))
+	s := NewPWSessionService(m, ss, nil, nil, d, createCfg(t, time.Nanosecond), false, nil, 0, zaptest.NewLogger(t))
 
 	ss.EXPECT().IsShutdown().Return(false).Once()
 
@@ -132,7 +136,7 @@ func TestPWServiceImpl_CreateSession_Start_Failed(t *testing.T) {
 	m := new(mocks.BrowserManager)
 	d := new(mocks.ContextDialer)
 	ss := new(mocks.SessionStorage)
-	s := NewPWSessionService(m, ss, d, 500*time.Millisecond, false, nil, zaptest.NewLogger(t))
+	s := NewPWSessionService(m, ss, nil, nil, d, createCfg(t, 500*time.Millisecond), false, nil, 0, zaptest.NewLogger(t))
 
 	ss.EXPECT().IsShutdown().Return(false).Once()
 
@@ -152,13 +156,67 @@ func TestPWServiceImpl_CreateSession_Start_Failed(t *testing.T) {
 	d.AssertNumberOfCalls(t, "DialContext", 3)
 }
 
+func TestPWSessionServiceImpl_CreateSession_CDP(t *testing.T) {
+	g := NewWithT(t)
+	m := new(mocks.BrowserManager)
+	d := new(mocks.ContextDialer)
+	ss := new(mocks.SessionStorage)
+	s := NewPWSessionService(m, ss, nil, nil, d, createCfg(t, time.Second), false, time.Now, 0, zaptest.NewLogger(t))
+
+	ss.EXPECT().IsShutdown().Return(false).Once()
+	caps := &models.PWCapabilities{Browser: "chromium", CDP: true}
+	br := new(mocks.Browser)
+	m.EXPECT().Allocate(mock.Anything, models.PlaywrightProtocol, caps).Return(br, nil).Once()
+
+	br.EXPECT().GetURL().Return(testURL)
+	br.EXPECT().GetHostPort(models.DevtoolsPort).Return("testhost:9222").Once()
+	conn, _ := net.Pipe()
+	d.EXPECT().DialContext(mock.Anything, "tcp", "testhost:9222").Return(conn, nil).Once()
+	ss.EXPECT().Add(models.PlaywrightProtocol, mock.Anything).Return(nil).Once()
+
+	sess, err := s.CreateSession(context.TODO(), caps)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(sess.Browser()).To(BeIdenticalTo(br))
+
+	ss.AssertExpectations(t)
+	m.AssertExpectations(t)
+	br.AssertExpectations(t)
+	d.AssertExpectations(t)
+}
+
+func TestPWSessionServiceImpl_CreateSession_CDPNotSupported(t *testing.T) {
+	g := NewWithT(t)
+	m := new(mocks.BrowserManager)
+	d := new(mocks.ContextDialer)
+	ss := new(mocks.SessionStorage)
+	s := NewPWSessionService(m, ss, nil, nil, d, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
+
+	ss.EXPECT().IsShutdown().Return(false).Once()
+	br := new(mocks.Browser)
+	m.EXPECT().Allocate(mock.Anything, models.PlaywrightProtocol, mock.Anything).Return(br, nil).Once()
+	br.EXPECT().GetURL().Return(testURL)
+	br.EXPECT().GetHostPort(models.DevtoolsPort).Return("").Once()
+	br.EXPECT().Close(context.Background(), false).Once()
+
+	_, err := s.CreateSession(context.TODO(), &models.PWCapabilities{Browser: "firefox", CDP: true})
+	g.Expect(err).To(MatchError("browser firefox does not support Chrome DevTools Protocol"))
+	var e models.ErrorWithCode
+	g.Expect(errors.As(err, &e)).To(BeTrue())
+	g.Expect(e.Code()).To(Equal(http.StatusBadRequest))
+
+	ss.AssertExpectations(t)
+	m.AssertExpectations(t)
+	br.AssertExpectations(t)
+	d.AssertNotCalled(t, "DialContext", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestPWSessionServiceImpl_CreateSession_StorageError(t *testing.T) {
 	g := NewWithT(t)
 	m := new(mocks.BrowserManager)
 	d := new(mocks.ContextDialer)
 	ss := new(mocks.SessionStorage)
 	now := func() time.Time { return time.UnixMilli(123) }
-	s := NewPWSessionService(m, ss, d, time.Second, false, now, zaptest.NewLogger(t))
+	s := NewPWSessionService(m, ss, nil, nil, d, createCfg(t, time.Second), false, now, 0, zaptest.NewLogger(t))
 
 	br := new(mocks.Browser)
 
@@ -185,7 +243,7 @@ func TestPWSessionServiceImpl_CreateSession_StorageError(t *testing.T) {
 func TestPWSessionServiceImpl_CreateSession_Shutdown(t *testing.T) {
 	g := NewWithT(t)
 	ss := new(mocks.SessionStorage)
-	s := NewPWSessionService(nil, ss, nil, time.Second, false, nil, zaptest.NewLogger(t))
+	s := NewPWSessionService(nil, ss, nil, nil, nil, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
 
 	ss.EXPECT().IsShutdown().Return(true).Once()
 
@@ -198,7 +256,7 @@ func TestPWSessionServiceImpl_CreateSession_Shutdown(t *testing.T) {
 func TestPWSessionServiceImpl_ListSessions(t *testing.T) {
 	g := NewWithT(t)
 	ss := new(mocks.SessionStorage)
-	s := NewPWSessionService(nil, ss, nil, time.Second, false, nil, zaptest.NewLogger(t))
+	s := NewPWSessionService(nil, ss, nil, nil, nil, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
 
 	testSessList := []*session.Session{{}, {}}
 
@@ -213,7 +271,7 @@ func TestPWSessionServiceImpl_DeleteSession(t *testing.T) {
 	g := NewWithT(t)
 
 	ss := new(mocks.SessionStorage)
-	s := NewPWSessionService(nil, ss, nil, time.Second, false, nil, zaptest.NewLogger(t))
+	s := NewPWSessionService(nil, ss, nil, nil, nil, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
 
 	ctx, cancel := context.WithCancel(context.TODO())
 	defer cancel()
@@ -228,9 +286,58 @@ func TestPWSessionServiceImpl_DeleteSession(t *testing.T) {
 	ss.AssertExpectations(t)
 }
 
+func TestPuppeteerSessionService_DeleteSession(t *testing.T) {
+	g := NewWithT(t)
+
+	ss := new(mocks.SessionStorage)
+	s := NewPuppeteerSessionService(nil, ss, nil, nil, nil, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	br := new(mocks.Browser)
+	s1 := session.NewSession("12345", "", br, nil, nil, time.Time{}, ctx, cancel)
+
+	ss.EXPECT().Delete(models.PuppeteerProtocol, "12345").Return(true)
+	br.EXPECT().Close(context.Background(), false)
+	s.DeleteSession(s1)
+	g.Expect(ctx.Done()).To(BeClosed())
+
+	ss.AssertExpectations(t)
+}
+
+func TestPWSessionServiceImpl_DeleteSession_Reset(t *testing.T) {
+	tests := []struct {
+		name      string
+		resetErr  error
+		wantTrash bool
+	}{
+		{name: "reset succeeded", resetErr: nil, wantTrash: false},
+		{name: "reset failed", resetErr: errors.New("test error"), wantTrash: true},
+		{name: "not reusable", resetErr: reset.ErrNotReusable, wantTrash: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ss := mocks.NewSessionStorage(t)
+			rs := mocks.NewBrowserResetter(t)
+			s := NewPWSessionService(nil, ss, nil, rs, nil, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
+
+			ctx, cancel := context.WithCancel(context.TODO())
+			defer cancel()
+			br := mocks.NewBrowser(t)
+			caps := &models.PWCapabilities{Browser: "test"}
+			s1 := session.NewSession("12345", "", br, caps, nil, time.Time{}, ctx, cancel)
+
+			ss.EXPECT().Delete(models.PlaywrightProtocol, "12345").Return(true)
+			rs.EXPECT().Reset(context.Background(), models.PlaywrightProtocol, caps, br).Return(tc.resetErr).Once()
+			br.EXPECT().Close(context.Background(), tc.wantTrash).Once()
+			s.DeleteSession(s1)
+		})
+	}
+}
+
 func TestWDSessionServiceImpl_DeleteSession_AlreadyDeleted(t *testing.T) {
 	ss := new(mocks.SessionStorage)
-	s := NewPWSessionService(nil, ss, nil, time.Second, false, nil, zaptest.NewLogger(t))
+	s := NewPWSessionService(nil, ss, nil, nil, nil, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
 
 	s1 := session.NewSession("12345", "", nil, nil, nil, time.Time{}, nil, nil)
 
@@ -244,7 +351,7 @@ func TestWDSessionServiceImpl_FindSession(t *testing.T) {
 	g := NewWithT(t)
 
 	ss := new(mocks.SessionStorage)
-	s := NewPWSessionService(nil, ss, nil, time.Second, false, nil, zaptest.NewLogger(t))
+	s := NewPWSessionService(nil, ss, nil, nil, nil, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
 
 	s1 := session.NewSession("12345", "", nil, nil, nil, time.Time{}, nil, nil)
 	ss.EXPECT().Get(models.PlaywrightProtocol, "12345").Return(s1, true).Once()
@@ -259,7 +366,7 @@ func TestWDSessionServiceImpl_FindSession_NotFound(t *testing.T) {
 	g := NewWithT(t)
 
 	ss := new(mocks.SessionStorage)
-	s := NewPWSessionService(nil, ss, nil, time.Second, false, nil, zaptest.NewLogger(t))
+	s := NewPWSessionService(nil, ss, nil, nil, nil, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
 
 	ss.EXPECT().Get(models.PlaywrightProtocol, "12345").Return(nil, false).Once()
 	_, err := s.FindSession("12345")
@@ -267,3 +374,110 @@ func TestWDSessionServiceImpl_FindSession_NotFound(t *testing.T) {
 
 	ss.AssertExpectations(t)
 }
+
+func createCfg(t *testing.T, createTimeout time.Duration) *mocks.PWSessionConfig {
+	return createCfgWithTimeouts(t, createTimeout, time.Minute, time.Hour, 0)
+}
+
+func createCfgWithTimeouts(
+	t *testing.T,
+	createTimeout time.Duration,
+	defaultTimeout, maxTimeout, maxLifetime time.Duration,
+) *mocks.PWSessionConfig {
+	cfg := mocks.NewPWSessionConfig(t)
+	cfg.EXPECT().CreateTimeout().Return(createTimeout)
+	cfg.EXPECT().PWSessionTimeout().Return(defaultTimeout)
+	cfg.EXPECT().MaxSessionTimeout().Return(maxTimeout)
+	cfg.EXPECT().MaxSessionLifetime().Return(maxLifetime)
+	return cfg
+}
+
+func TestPWSessionServiceImpl_SessionTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		want    time.Duration
+	}{
+		{name: "default", timeout: 0, want: time.Minute},
+		{name: "requested", timeout: 5 * time.Minute, want: 5 * time.Minute},
+		{name: "bounded by max", timeout: 2 * time.Hour, want: time.Hour},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+			s := NewPWSessionService(nil, nil, nil, nil, nil, createCfg(t, time.Second), false, nil, 0, zaptest.NewLogger(t))
+			g.Expect(s.sessionTimeout(&models.PWCapabilities{Timeout: tc.timeout})).To(Equal(tc.want))
+		})
+	}
+}
+
+func TestPWSessionServiceImpl_CleanupSessions_Idle(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := createCfgWithTimeouts(t, time.Second, time.Minute, time.Hour, 0)
+	ss := mocks.NewSessionStorage(t)
+	eb := mocks.NewEventBroker(t)
+	now := func() time.Time { return time.UnixMilli(100_000) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	br := mocks.NewBrowser(t)
+	caps := &models.PWCapabilities{Browser: "chromium", Version: "1.50", Labels: map[string]string{"team": "qa"}}
+	s1 := session.NewSession("12345", "", br, caps, nil, time.UnixMilli(0), ctx, cancel)
+	s1.SetTimeout(30 * time.Second)
+	s1.SetLastUsed(time.UnixMilli(60_000))
+	s2 := session.NewSession("67890", "", nil, caps, nil, time.UnixMilli(0), nil, nil)
+	s2.SetTimeout(30 * time.Second)
+	s2.SetLastUsed(time.UnixMilli(80_000))
+
+	ss.EXPECT().List(models.PlaywrightProtocol).Return([]*session.Session{s1, s2}).Once()
+	ss.EXPECT().List(models.PlaywrightProtocol).Return([]*session.Session{})
+	ss.EXPECT().Delete(models.PlaywrightProtocol, "12345").Return(true).Once()
+	br.EXPECT().Close(context.Background(), false).Once()
+	published := make(chan evmodels.IEvent, 1)
+	eb.EXPECT().Publish(mock.Anything).Run(func(ev evmodels.IEvent) {
+		published <- ev
+	}).Once()
+
+	s := NewPWSessionService(nil, ss, eb, nil, nil, cfg, false, now, 10*time.Millisecond, zaptest.NewLogger(t))
+
+	var ev evmodels.IEvent
+	g.Eventually(published).Should(Receive(&ev))
+	g.Expect(ctx.Done()).To(BeClosed())
+	g.Expect(ev.(*evmodels.Event[evmodels.SessionIdleTimeout]).Attributes).To(Equal(evmodels.SessionIdleTimeout{
+		Protocol:       models.PlaywrightProtocol,
+		ID:             "12345",
+		BrowserName:    "chromium",
+		BrowserVersion: "1.50",
+		Labels:         map[string]string{"team": "qa"},
+		IdleDuration:   40 * time.Second,
+		Timeout:        30 * time.Second,
+	}))
+
+	g.Expect(s.Shutdown(t.Context())).To(Succeed())
+}
+
+func TestPWSessionServiceImpl_CleanupSessions_MaxLifetime(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := createCfgWithTimeouts(t, time.Second, time.Minute, time.Hour, time.Hour)
+	ss := mocks.NewSessionStorage(t)
+	now := func() time.Time { return time.UnixMilli(0).Add(61 * time.Minute) }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	br := mocks.NewBrowser(t)
+	s1 := session.NewSession("12345", "", br, &models.PWCapabilities{}, nil, time.UnixMilli(0), ctx, cancel)
+	s1.SetTimeout(time.Minute)
+	s1.SetLastUsed(now())
+
+	ss.EXPECT().List(models.PlaywrightProtocol).Return([]*session.Session{s1}).Once()
+	ss.EXPECT().List(models.PlaywrightProtocol).Return([]*session.Session{})
+	ss.EXPECT().Delete(models.PlaywrightProtocol, "12345").Return(true).Once()
+	br.EXPECT().Close(context.Background(), false).Once()
+
+	s := NewPWSessionService(nil, ss, nil, nil, nil, cfg, false, now, 10*time.Millisecond, zaptest.NewLogger(t))
+
+	g.Eventually(ctx.Done()).Should(BeClosed())
+	g.Expect(s.Shutdown(t.Context())).To(Succeed())
+}
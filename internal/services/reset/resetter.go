@@ -0,0 +1,103 @@
+package reset
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/internal/common/client"
+	"github.com/selebrow/selebrow/pkg/browser"
+	"github.com/selebrow/selebrow/pkg/browsers"
+	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+var ErrNotReusable = errors.New("browser is not reusable")
+
+type BrowserResetter interface {
+	Reset(ctx context.Context, protocol models.BrowserProtocol, caps capabilities.Capabilities, br browser.Browser) error
+}
+
+type CatalogBrowserResetter struct {
+	cat     browsers.BrowsersCatalog
+	client  client.HTTPClient
+	timeout time.Duration
+}
+
+func NewCatalogBrowserResetter(cat browsers.BrowsersCatalog, hc client.HTTPClient, timeout time.Duration) *CatalogBrowserResetter {
+	return &CatalogBrowserResetter{
+		cat:     cat,
+		client:  hc,
+		timeout: timeout,
+	}
+}
+
+// Reset brings browser to the clean state using reset protocol configured for browser image,
+// ErrNotReusable is returned if browser must not be reused
+func (r *CatalogBrowserResetter) Reset(
+	ctx context.Context,
+	protocol models.BrowserProtocol,
+	caps capabilities.Capabilities,
+	br browser.Browser,
+) error {
+	verCfg, ok := r.cat.LookupBrowserImage(protocol, caps.GetName(), caps.GetFlavor())
+	if !ok || verCfg.Reset == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cfg := verCfg.Reset
+	switch cfg.Type {
+	case models.ResetNever:
+		return ErrNotReusable
+	case models.ResetHTTP:
+		return r.resetHTTP(ctx, cfg, br)
+	case models.ResetCommand:
+		return br.Exec(ctx, cfg.Cmd)
+	default:
+		return errors.Errorf("unsupported reset type %q", cfg.Type)
+	}
+}
+
+func (r *CatalogBrowserResetter) resetHTTP(ctx context.Context, cfg *models.ResetConfig, br browser.Browser) error {
+	u := *br.GetURL()
+	host := br.GetHost()
+	if cfg.Port != "" && cfg.Port != models.BrowserPort {
+		u.Host = br.GetHostPort(cfg.Port)
+		if u.Host == "" {
+			return errors.Errorf("browser port %s is not available", cfg.Port)
+		}
+		u.Path = ""
+		host = ""
+	}
+	u.Path = path.Join("/", u.Path, cfg.Path)
+
+	method := cfg.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), http.NoBody)
+	if err != nil {
+		return err
+	}
+	if host != "" {
+		req.Host = host
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "reset request %s failed", u.String())
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return errors.Errorf("reset request %s failed with code %d", u.String(), resp.StatusCode)
+	}
+	return nil
+}
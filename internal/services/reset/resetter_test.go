@@ -0,0 +1,127 @@
+package reset_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/selebrow/selebrow/internal/services/reset"
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestCatalogBrowserResetter_Reset(t *testing.T) {
+	g := NewWithT(t)
+
+	u, err := url.Parse("http://host1:4444/wd/hub")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	tests := []struct {
+		name    string
+		cfg     *models.ResetConfig
+		setup   func(br *mocks.Browser, client *mocks.HTTPClient)
+		wantErr error
+	}{
+		{
+			name: "no reset configured",
+			cfg:  nil,
+		},
+		{
+			name:    "never",
+			cfg:     &models.ResetConfig{Type: models.ResetNever},
+			wantErr: reset.ErrNotReusable,
+		},
+		{
+			name: "command",
+			cfg:  &models.ResetConfig{Type: models.ResetCommand, Cmd: []string{"rm", "-rf", "/tmp/profile"}},
+			setup: func(br *mocks.Browser, _ *mocks.HTTPClient) {
+				br.EXPECT().Exec(mock.Anything, []string{"rm", "-rf", "/tmp/profile"}).Return(nil).Once()
+			},
+		},
+		{
+			name: "command failed",
+			cfg:  &models.ResetConfig{Type: models.ResetCommand, Cmd: []string{"false"}},
+			setup: func(br *mocks.Browser, _ *mocks.HTTPClient) {
+				br.EXPECT().Exec(mock.Anything, []string{"false"}).Return(errors.New("exit code 1")).Once()
+			},
+			wantErr: errors.New("exit code 1"),
+		},
+		{
+			name: "http browser port",
+			cfg:  &models.ResetConfig{Type: models.ResetHTTP, Path: "/reset"},
+			setup: func(br *mocks.Browser, client *mocks.HTTPClient) {
+				br.EXPECT().GetURL().Return(u).Once()
+				br.EXPECT().GetHost().Return("10.0.0.1:4444").Once()
+				client.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+					g.Expect(req.Method).To(Equal(http.MethodPost))
+					g.Expect(req.URL.String()).To(Equal("http://host1:4444/wd/hub/reset"))
+					g.Expect(req.Host).To(Equal("10.0.0.1:4444"))
+				}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(``))}, nil).Once()
+			},
+		},
+		{
+			name: "http custom port",
+			cfg:  &models.ResetConfig{Type: models.ResetHTTP, Port: models.FileserverPort, Method: http.MethodDelete, Path: "/"},
+			setup: func(br *mocks.Browser, client *mocks.HTTPClient) {
+				br.EXPECT().GetURL().Return(u).Once()
+				br.EXPECT().GetHost().Return("10.0.0.1:4444").Once()
+				br.EXPECT().GetHostPort(models.FileserverPort).Return("host1:8080").Once()
+				client.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+					g.Expect(req.Method).To(Equal(http.MethodDelete))
+					g.Expect(req.URL.String()).To(Equal("http://host1:8080/"))
+					g.Expect(req.Host).To(Equal("host1:8080"))
+				}).Return(&http.Response{StatusCode: http.StatusNoContent, Body: io.NopCloser(strings.NewReader(``))}, nil).Once()
+			},
+		},
+		{
+			name: "http failed",
+			cfg:  &models.ResetConfig{Type: models.ResetHTTP, Path: "/reset"},
+			setup: func(br *mocks.Browser, client *mocks.HTTPClient) {
+				br.EXPECT().GetURL().Return(u).Once()
+				br.EXPECT().GetHost().Return("10.0.0.1:4444").Once()
+				client.EXPECT().Do(mock.Anything).
+					Return(&http.Response{StatusCode: http.StatusInternalServerError, Body: io.NopCloser(strings.NewReader(``))}, nil).Once()
+			},
+			wantErr: errors.New("reset request http://host1:4444/wd/hub/reset failed with code 500"),
+		},
+		{
+			name:    "unsupported",
+			cfg:     &models.ResetConfig{Type: "magic"},
+			wantErr: errors.New(`unsupported reset type "magic"`),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cat := mocks.NewBrowsersCatalog(t)
+			client := mocks.NewHTTPClient(t)
+			br := mocks.NewBrowser(t)
+			caps := mocks.NewCapabilities(t)
+			caps.EXPECT().GetName().Return("chrome")
+			caps.EXPECT().GetFlavor().Return("")
+
+			cat.EXPECT().LookupBrowserImage(models.WebdriverProtocol, "chrome", "").
+				Return(models.BrowserImageConfig{Reset: tc.cfg}, true).Once()
+			if tc.setup != nil {
+				tc.setup(br, client)
+			}
+
+			r := reset.NewCatalogBrowserResetter(cat, client, time.Second)
+			err := r.Reset(context.TODO(), models.WebdriverProtocol, caps, br)
+			if tc.wantErr != nil {
+				g.Expect(err).To(MatchError(tc.wantErr.Error()))
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
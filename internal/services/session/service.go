@@ -3,12 +3,23 @@ package session
 import (
 	"context"
 
+	"github.com/pkg/errors"
+
 	"github.com/selebrow/selebrow/pkg/capabilities"
 )
 
+// ErrSessionTerminated is returned when looking up session which has been forcibly terminated
+var ErrSessionTerminated = errors.New("session has been terminated")
+
 type SessionService interface {
 	CreateSession(ctx context.Context, caps capabilities.Capabilities) (*Session, error)
 	FindSession(id string) (*Session, error)
 	ListSessions() []*Session
 	DeleteSession(sess *Session)
 }
+
+// SessionTerminator forcibly terminates sessions, subsequent lookups of terminated session fail
+// with ErrSessionTerminated
+type SessionTerminator interface {
+	TerminateSession(sess *Session, reason string)
+}
@@ -6,6 +6,8 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/selebrow/selebrow/pkg/browser"
 	"github.com/selebrow/selebrow/pkg/capabilities"
 )
@@ -19,6 +21,9 @@ type Session struct {
 	resp     map[string]interface{}
 	created  time.Time
 	lastUsed time.Time
+	timeout  time.Duration
+	timeouts int
+	spanCtx  trace.SpanContext
 	ctx      context.Context
 	cancel   context.CancelFunc
 }
@@ -88,3 +93,43 @@ func (s *Session) SetLastUsed(t time.Time) {
 	defer s.mu.Unlock()
 	s.lastUsed = t
 }
+
+// Timeout returns session idle timeout, zero value means session doesn't expire
+func (s *Session) Timeout() time.Duration {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.timeout
+}
+
+func (s *Session) SetTimeout(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timeout = d
+}
+
+// SpanContext returns span context of the session creation, it's used as a parent for proxied commands spans
+func (s *Session) SpanContext() trace.SpanContext {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.spanCtx
+}
+
+func (s *Session) SetSpanContext(sc trace.SpanContext) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.spanCtx = sc
+}
+
+// CommandTimedOut records command timeout and returns number of consecutive command timeouts
+func (s *Session) CommandTimedOut() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timeouts++
+	return s.timeouts
+}
+
+func (s *Session) ResetCommandTimeouts() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.timeouts = 0
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
@@ -24,17 +26,38 @@ type LocalSessionStorage struct {
 	sessions map[models.BrowserProtocol]map[string]*Session
 	shutdown bool
 	mtx      sync.RWMutex
+	eb       event.EventBroker
 	l        *zap.SugaredLogger
 }
 
-func NewLocalSessionStorage(l *zap.Logger) *LocalSessionStorage {
+func NewLocalSessionStorage(eb event.EventBroker, l *zap.Logger) *LocalSessionStorage {
 	return &LocalSessionStorage{
 		sessions: make(map[models.BrowserProtocol]map[string]*Session),
+		eb:       eb,
 		l:        l.Sugar(),
 	}
 }
 
 func (s *LocalSessionStorage) Add(protocol models.BrowserProtocol, sess *Session) error {
+	if err := s.add(protocol, sess); err != nil {
+		return err
+	}
+
+	caps := sess.ReqCaps()
+	s.eb.Publish(evmodels.NewSessionCreatedEvent(evmodels.SessionCreated{
+		Protocol:       protocol,
+		ID:             sess.ID(),
+		BrowserName:    caps.GetName(),
+		BrowserVersion: caps.GetVersion(),
+		TestName:       caps.GetTestName(),
+		VNCEnabled:     caps.IsVNCEnabled(),
+		Labels:         caps.GetLabels(),
+		Created:        sess.Created(),
+	}))
+	return nil
+}
+
+func (s *LocalSessionStorage) add(protocol models.BrowserProtocol, sess *Session) error {
 	s.mtx.Lock()
 	defer s.mtx.Unlock()
 	if s.shutdown {
@@ -79,20 +102,30 @@ func (s *LocalSessionStorage) List(protocol models.BrowserProtocol) []*Session {
 }
 
 func (s *LocalSessionStorage) Delete(protocol models.BrowserProtocol, id string) bool {
-	s.mtx.Lock()
-	defer s.mtx.Unlock()
-
-	ps := s.sessions[protocol]
-	if ps == nil {
+	sess := s.delete(protocol, id)
+	if sess == nil {
 		return false
 	}
 
-	if _, ok := ps[id]; !ok {
-		return false
+	s.eb.Publish(evmodels.NewSessionDeletedEvent(evmodels.SessionDeleted{
+		Protocol: protocol,
+		ID:       id,
+		Labels:   sess.ReqCaps().GetLabels(),
+	}))
+	return true
+}
+
+func (s *LocalSessionStorage) delete(protocol models.BrowserProtocol, id string) *Session {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	sess, ok := s.sessions[protocol][id]
+	if !ok {
+		return nil
 	}
 
-	delete(ps, id)
-	return true
+	delete(s.sessions[protocol], id)
+	return sess
 }
 
 func (s *LocalSessionStorage) IsShutdown() bool {
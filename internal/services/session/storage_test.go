@@ -0,0 +1,77 @@
+package session_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/selebrow/selebrow/internal/services/session"
+	"github.com/selebrow/selebrow/mocks"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+func TestLocalSessionStorage_Events(t *testing.T) {
+	g := NewWithT(t)
+
+	eb := mocks.NewEventBroker(t)
+	s := session.NewLocalSessionStorage(eb, zaptest.NewLogger(t))
+
+	caps := mocks.NewCapabilities(t)
+	caps.EXPECT().GetName().Return("chrome")
+	caps.EXPECT().GetVersion().Return("120.0")
+	caps.EXPECT().GetTestName().Return("my test")
+	caps.EXPECT().IsVNCEnabled().Return(true)
+	caps.EXPECT().GetLabels().Return(map[string]string{"team": "qa"})
+	sess := session.NewSession("123", "LINUX", nil, caps, nil, time.UnixMilli(100), nil, nil)
+
+	eb.EXPECT().Publish(mock.Anything).Run(func(ev evmodels.IEvent) {
+		g.Expect(ev.EventType()).To(Equal(evmodels.SessionCreatedEventType))
+		g.Expect(ev.(*evmodels.Event[evmodels.SessionCreated]).Attributes).To(Equal(evmodels.SessionCreated{
+			Protocol:       models.WebdriverProtocol,
+			ID:             "123",
+			BrowserName:    "chrome",
+			BrowserVersion: "120.0",
+			TestName:       "my test",
+			VNCEnabled:     true,
+			Labels:         map[string]string{"team": "qa"},
+			Created:        time.UnixMilli(100),
+		}))
+	}).Once()
+	err := s.Add(models.WebdriverProtocol, sess)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got, ok := s.Get(models.WebdriverProtocol, "123")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(got).To(BeIdenticalTo(sess))
+	g.Expect(s.List(models.WebdriverProtocol)).To(ConsistOf(sess))
+
+	eb.EXPECT().Publish(mock.Anything).Run(func(ev evmodels.IEvent) {
+		g.Expect(ev.EventType()).To(Equal(evmodels.SessionDeletedEventType))
+		g.Expect(ev.(*evmodels.Event[evmodels.SessionDeleted]).Attributes).To(Equal(evmodels.SessionDeleted{
+			Protocol: models.WebdriverProtocol,
+			ID:       "123",
+			Labels:   map[string]string{"team": "qa"},
+		}))
+	}).Once()
+	g.Expect(s.Delete(models.WebdriverProtocol, "123")).To(BeTrue())
+
+	// no event for missing session
+	g.Expect(s.Delete(models.WebdriverProtocol, "123")).To(BeFalse())
+}
+
+func TestLocalSessionStorage_Shutdown(t *testing.T) {
+	g := NewWithT(t)
+
+	s := session.NewLocalSessionStorage(mocks.NewEventBroker(t), zaptest.NewLogger(t))
+	err := s.Shutdown(context.TODO())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s.IsShutdown()).To(BeTrue())
+
+	err = s.Add(models.WebdriverProtocol, session.NewSession("123", "", nil, nil, nil, time.Time{}, nil, nil))
+	g.Expect(err).To(MatchError(session.ErrStorageShutdown))
+}
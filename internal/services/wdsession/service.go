@@ -3,6 +3,7 @@ package wdsession
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,36 +11,71 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
 	"github.com/selebrow/selebrow/internal/common/client"
 	"github.com/selebrow/selebrow/internal/common/clock"
+	"github.com/selebrow/selebrow/internal/common/fileserver"
 	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/artifacts"
+	"github.com/selebrow/selebrow/internal/services/reset"
 	"github.com/selebrow/selebrow/internal/services/session"
 	"github.com/selebrow/selebrow/pkg/browser"
 	"github.com/selebrow/selebrow/pkg/capabilities"
 	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
+	"github.com/selebrow/selebrow/pkg/tracing"
 )
 
+const (
+	// terminatedRetention is how long terminated sessions are remembered to report the reason to clients
+	terminatedRetention = 10 * time.Minute
+
+	finalScreenshotTimeout  = 10 * time.Second
+	downloadsArchiveTimeout = time.Minute
+)
+
+type terminatedSession struct {
+	at     time.Time
+	reason string
+}
+
 type WDSessionService struct {
 	mgr           browser.BrowserManager
 	client        client.HTTPClient
+	files         *fileserver.Client
 	createTimeout time.Duration
+	defTimeout    time.Duration
+	maxTimeout    time.Duration
+	maxLifetime   time.Duration
 	proxyDelete   bool
+	archive       bool
 	sStorage      session.SessionStorage
+	eb            event.EventBroker
+	resetter      reset.BrowserResetter
+	artifacts     artifacts.ArtifactStore
 	now           clock.NowFunc
 	cancel        context.CancelFunc
 	done          chan struct{}
+	releasing     sync.WaitGroup
+	mtx           sync.Mutex
+	terminated    map[string]terminatedSession
 	l             *zap.SugaredLogger
 }
 
 func NewWDSessionServiceImpl(
 	mgr browser.BrowserManager,
 	sStorage session.SessionStorage,
+	eb event.EventBroker,
+	resetter reset.BrowserResetter,
+	artifacts artifacts.ArtifactStore,
 	hc client.HTTPClient,
 	cfg config.WDSessionConfig,
 	now clock.NowFunc,
@@ -49,10 +85,19 @@ func NewWDSessionServiceImpl(
 	s := &WDSessionService{
 		mgr:           mgr,
 		client:        hc,
+		files:         fileserver.NewClient(hc),
 		createTimeout: cfg.CreateTimeout(),
+		defTimeout:    cfg.DefaultSessionTimeout(),
+		maxTimeout:    cfg.MaxSessionTimeout(),
+		maxLifetime:   cfg.MaxSessionLifetime(),
 		proxyDelete:   cfg.ProxyDelete(),
+		archive:       cfg.ArchiveDownloads(),
 		sStorage:      sStorage,
+		eb:            eb,
+		resetter:      resetter,
+		artifacts:     artifacts,
 		now:           now,
+		terminated:    make(map[string]terminatedSession),
 		l:             l.Sugar(),
 	}
 
@@ -60,7 +105,7 @@ func NewWDSessionServiceImpl(
 		ctx, cancel := context.WithCancel(context.Background())
 		s.cancel = cancel
 		s.done = make(chan struct{})
-		go s.cleanupSessions(ctx, cleanupInterval, cfg.DefaultSessionTimeout(), cfg.MaxSessionTimeout())
+		go s.cleanupSessions(ctx, cleanupInterval)
 	}
 	return s
 }
@@ -72,16 +117,41 @@ func (s *WDSessionService) ListSessions() []*session.Session {
 func (s *WDSessionService) FindSession(id string) (*session.Session, error) {
 	sess, ok := s.sStorage.Get(models.WebdriverProtocol, id)
 	if !ok {
+		s.mtx.Lock()
+		t, terminated := s.terminated[id]
+		s.mtx.Unlock()
+		if terminated {
+			return nil, errors.Wrapf(session.ErrSessionTerminated, "session %s: %s", id, t.reason)
+		}
 		return nil, fmt.Errorf("session %s doesn't exist", id)
 	}
 	return sess, nil
 }
 
 func (s *WDSessionService) DeleteSession(sess *session.Session) {
+	s.deleteSession(sess)
+}
+
+// deleteSession returns false if session has already been deleted concurrently,
+// browser is released in background so neither client nor idle sessions cleanup wait for it
+func (s *WDSessionService) deleteSession(sess *session.Session) bool {
 	if !s.sStorage.Delete(models.WebdriverProtocol, sess.ID()) {
-		return
+		return false
 	}
 
+	s.releasing.Add(1)
+	go func() {
+		defer s.releasing.Done()
+		s.releaseBrowser(sess)
+	}()
+	return true
+}
+
+// releaseBrowser runs final screenshot, downloads archive and reset (each one is bounded by its own timeout)
+// before browser is closed or returned to the pool
+func (s *WDSessionService) releaseBrowser(sess *session.Session) {
+	s.takeFinalScreenshot(sess)
+	s.archiveDownloads(sess)
 	trash := !s.proxyDelete || !s.doDeleteSession(*sess.Browser().GetURL(), sess.Browser().GetHost(), sess.ID())
 	if !trash {
 		err := s.cleanupSession(context.Background(), sess)
@@ -90,6 +160,9 @@ func (s *WDSessionService) DeleteSession(sess *session.Session) {
 			trash = true
 		}
 	}
+	if !trash {
+		trash = !s.resetBrowser(sess)
+	}
 	sess.Browser().Close(context.Background(), trash)
 	s.l.Infow("Webdriver session has been deleted", zap.String("session_id", sess.ID()))
 }
@@ -109,13 +182,17 @@ func (s *WDSessionService) CreateSession(ctx context.Context, reqCaps capabiliti
 		return nil, models.WrapTimeoutErr(err, "failed to allocate webdriver")
 	}
 
-	err = s.waitWebdriverStarted(ctx, *br.GetURL(), br.GetHost())
+	wCtx, span := tracing.Start(ctx, "webdriver.waitStarted")
+	err = s.waitWebdriverStarted(wCtx, *br.GetURL(), br.GetHost())
+	tracing.End(span, err)
 	if err != nil {
 		br.Close(context.Background(), true)
 		return nil, models.WrapTimeoutErr(err, "webdriver did not get ready within configured timeout")
 	}
 
-	res, err := s.proxyCreateSession(ctx, *br.GetURL(), br.GetHost(), reqCaps)
+	pCtx, span := tracing.Start(ctx, "webdriver.proxyCreateSession")
+	res, err := s.proxyCreateSession(pCtx, *br.GetURL(), br.GetHost(), reqCaps)
+	tracing.End(span, err)
 	if err != nil {
 		br.Close(context.Background(), true)
 		return nil, models.WrapTimeoutErr(err, "failed to proxy create session request")
@@ -127,8 +204,15 @@ func (s *WDSessionService) CreateSession(ctx context.Context, reqCaps capabiliti
 		return nil, errors.Wrap(err, "failed to parse create session response")
 	}
 
+	// managed downloads are served from the fileserver, so they are only available when it's enabled
+	if wc, ok := reqCaps.(capabilities.WDCapabilities); ok && wc.IsDownloadsEnabled() && br.GetHostPort(models.FileserverPort) != "" {
+		setDownloadsEnabled(res)
+	}
+
 	sess := session.NewSession(id, platform, br, reqCaps, res, s.now(), nil, nil)
 	sess.SetLastUsed(s.now())
+	sess.SetTimeout(s.sessionTimeout(reqCaps))
+	sess.SetSpanContext(trace.SpanContextFromContext(ctx))
 	if err := s.sStorage.Add(models.WebdriverProtocol, sess); err != nil {
 		br.Close(context.Background(), true)
 		return nil, errors.Wrap(err, "failed to store session")
@@ -195,6 +279,7 @@ func (s *WDSessionService) proxyCreateSession(
 	}
 	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
 	req.Host = host
+	tracing.Inject(ctx, req.Header)
 
 	resp, err := s.client.Do(req)
 	if err != nil {
@@ -249,6 +334,19 @@ func extractSessionID(resp map[string]interface{}) (string, error) {
 	return sess, nil
 }
 
+// setDownloadsEnabled reports Selenium managed downloads support in W3C create session response
+func setDownloadsEnabled(resp map[string]interface{}) {
+	value, ok := resp["value"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	caps, ok := value["capabilities"].(map[string]interface{})
+	if !ok {
+		return
+	}
+	caps[models.DownloadsEnabledCapability] = true
+}
+
 func (s *WDSessionService) doDeleteSession(u url.URL, host, id string) bool {
 	u.Path = path.Join(u.Path, router.SessionPath, id)
 	req, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, u.String(), http.NoBody)
@@ -269,87 +367,141 @@ func (s *WDSessionService) doDeleteSession(u url.URL, host, id string) bool {
 	return true
 }
 
-func (s *WDSessionService) cleanupSession(ctx context.Context, sess *session.Session) error {
-	hp := sess.Browser().GetHostPort(models.FileserverPort)
-	if hp == "" {
-		return nil
+// takeFinalScreenshot saves screenshot of the session being deleted when requested by capabilities
+func (s *WDSessionService) takeFinalScreenshot(sess *session.Session) {
+	if s.artifacts == nil {
+		return
 	}
-	baseURL, err := url.Parse("http://" + hp)
+	if wc, ok := sess.ReqCaps().(capabilities.WDCapabilities); !ok || !wc.IsFinalScreenshotEnabled() {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), finalScreenshotTimeout)
+	defer cancel()
+	data, err := s.getScreenshot(ctx, *sess.Browser().GetURL(), sess.Browser().GetHost(), sess.ID())
 	if err != nil {
-		return err
+		s.l.Warnw("failed to take final screenshot", zap.String("session_id", sess.ID()), zap.Error(err))
+		return
+	}
+	if err := s.artifacts.SaveScreenshot(sess.ID(), sess.ReqCaps().GetTestName(), data); err != nil {
+		s.l.Warnw("failed to save final screenshot", zap.String("session_id", sess.ID()), zap.Error(err))
 	}
+}
 
-	files, err := s.getFiles(ctx, baseURL)
+func (s *WDSessionService) getScreenshot(ctx context.Context, u url.URL, host, id string) ([]byte, error) {
+	u.Path = path.Join(u.Path, router.SessionPath, id, "screenshot")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	req.Host = host
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	for _, file := range files {
-		if err := s.deleteFile(ctx, baseURL, file); err != nil {
-			return err
-		}
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, errors.Errorf("%s %s failed with code %d: %s", req.Method, req.URL, resp.StatusCode, string(b))
 	}
 
-	if l := len(files); l > 0 {
-		s.l.With(zap.String("session_id", sess.ID())).Infof("Webdriver session cleanup: %d files have been deleted", l)
+	var res struct {
+		Value string `json:"value"`
 	}
-	return nil
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, errors.Wrap(err, "failed to parse screenshot response")
+	}
+	return base64.StdEncoding.DecodeString(res.Value)
 }
 
-func (s *WDSessionService) getFiles(ctx context.Context, baseURL *url.URL) ([]string, error) {
-	u := *baseURL
-	q := make(url.Values)
-	q.Set("json", "true")
-	u.RawQuery = q.Encode()
-	r, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), http.NoBody)
+// archiveDownloads saves downloaded files to artifacts before they are wiped by the session cleanup
+func (s *WDSessionService) archiveDownloads(sess *session.Session) {
+	if !s.archive || s.artifacts == nil {
+		return
+	}
+	hp := sess.Browser().GetHostPort(models.FileserverPort)
+	if hp == "" {
+		return
+	}
+	l := s.l.With(zap.String("session_id", sess.ID()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), downloadsArchiveTimeout)
+	defer cancel()
+	baseURL := &url.URL{Scheme: "http", Host: hp}
+	files, err := s.files.List(ctx, baseURL)
 	if err != nil {
-		return nil, err
+		l.Warnw("failed to list downloaded files", zap.Error(err))
+		return
 	}
-	resp, err := s.client.Do(r)
+	if len(files) == 0 {
+		return
+	}
+
+	w, err := s.artifacts.CreateDownloadsArchive(sess.ID())
 	if err != nil {
-		return nil, err
+		l.Warnw("failed to create downloads archive", zap.Error(err))
+		return
 	}
-	defer resp.Body.Close()
-	var res []string
-	err = json.NewDecoder(resp.Body).Decode(&res)
-	return res, err
+	err = s.files.Archive(ctx, baseURL, files, fileserver.ArchiveZip, w)
+	if cErr := w.Close(); err == nil {
+		err = cErr
+	}
+	if err != nil {
+		l.Warnw("failed to archive downloaded files", zap.Error(err))
+		return
+	}
+	l.Infof("%d downloaded files have been archived", len(files))
+}
+
+func (s *WDSessionService) resetBrowser(sess *session.Session) bool {
+	if s.resetter == nil {
+		return true
+	}
+	err := s.resetter.Reset(context.Background(), models.WebdriverProtocol, sess.ReqCaps(), sess.Browser())
+	if err != nil {
+		if !errors.Is(err, reset.ErrNotReusable) {
+			s.l.Warnw("failed to reset browser", zap.String("session_id", sess.ID()), zap.Error(err))
+		}
+		return false
+	}
+	return true
 }
 
-func (s *WDSessionService) deleteFile(ctx context.Context, baseURL *url.URL, file string) error {
-	u := *baseURL
-	u.Path = path.Join(u.Path, file)
-	r, err := http.NewRequestWithContext(ctx, http.MethodDelete, u.String(), http.NoBody)
+func (s *WDSessionService) cleanupSession(ctx context.Context, sess *session.Session) error {
+	hp := sess.Browser().GetHostPort(models.FileserverPort)
+	if hp == "" {
+		return nil
+	}
+	baseURL, err := url.Parse("http://" + hp)
 	if err != nil {
 		return err
 	}
-	resp, err := s.client.Do(r)
+
+	files, err := s.files.List(ctx, baseURL)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusNotFound {
-		b, err := io.ReadAll(resp.Body)
-		if err != nil {
-			s.l.Errorw("failed to read the response", zap.Error(err))
-		}
 
-		return errors.Errorf("%s %s failed with code %d: %s", r.Method, r.URL.String(), resp.StatusCode, string(b))
+	for _, file := range files {
+		if err := s.files.Delete(ctx, baseURL, file); err != nil {
+			return err
+		}
 	}
 
+	if l := len(files); l > 0 {
+		s.l.With(zap.String("session_id", sess.ID())).Infof("Webdriver session cleanup: %d files have been deleted", l)
+	}
 	return nil
 }
 
-func (s *WDSessionService) cleanupSessions(
-	ctx context.Context,
-	cleanupInterval time.Duration,
-	defaultTimeout, maxTimeout time.Duration,
-) {
+func (s *WDSessionService) cleanupSessions(ctx context.Context, cleanupInterval time.Duration) {
 	t := time.NewTicker(cleanupInterval)
 	defer t.Stop()
 	for {
 		select {
 		case <-t.C:
-			s.cleanupIdleSessions(defaultTimeout, maxTimeout)
+			s.cleanupIdleSessions()
 		case <-ctx.Done():
 			close(s.done)
 			return
@@ -357,33 +509,100 @@ func (s *WDSessionService) cleanupSessions(
 	}
 }
 
-func (s *WDSessionService) cleanupIdleSessions(defaultTimeout, maxTimeout time.Duration) {
+func (s *WDSessionService) sessionTimeout(caps capabilities.Capabilities) time.Duration {
+	timeout := caps.GetTimeout()
+	if timeout <= 0 {
+		timeout = s.defTimeout
+	}
+	if timeout > s.maxTimeout {
+		timeout = s.maxTimeout
+	}
+	return timeout
+}
+
+// sessionLifetime returns max session lifetime, capability can only lower the global limit
+func (s *WDSessionService) sessionLifetime(caps capabilities.Capabilities) time.Duration {
+	lifetime := caps.GetMaxLifetime()
+	if lifetime <= 0 || (s.maxLifetime > 0 && lifetime > s.maxLifetime) {
+		lifetime = s.maxLifetime
+	}
+	return lifetime
+}
+
+func (s *WDSessionService) cleanupIdleSessions() {
+	s.pruneTerminated()
 	sessions := s.ListSessions()
 	for _, sess := range sessions {
-		timeout := sess.ReqCaps().GetTimeout()
-		if timeout <= 0 {
-			timeout = defaultTimeout
-		}
-		if timeout > maxTimeout {
-			timeout = maxTimeout
+		if lifetime := s.sessionLifetime(sess.ReqCaps()); lifetime > 0 {
+			if age := s.now().Sub(sess.Created()); age > lifetime {
+				reason := fmt.Sprintf("max session lifetime %v is reached", lifetime)
+				s.l.With(zap.String("session_id", sess.ID())).
+					Infof("terminating Webdriver session running for %v: %s", age, reason)
+				s.TerminateSession(sess, reason)
+				continue
+			}
 		}
+
+		timeout := s.sessionTimeout(sess.ReqCaps())
 		if idle := s.now().Sub(sess.LastUsed()); idle > timeout {
+			if !s.deleteSession(sess) {
+				continue
+			}
 			s.l.With(zap.String("session_id", sess.ID())).
-				Infof("closing Webdriver session idle for %v: sessionTimeout %v is reached", idle, timeout)
-			s.DeleteSession(sess)
+				Infof("closed Webdriver session idle for %v: sessionTimeout %v is reached", idle, timeout)
+			caps := sess.ReqCaps()
+			s.eb.Publish(evmodels.NewSessionIdleTimeoutEvent(evmodels.SessionIdleTimeout{
+				Protocol:       models.WebdriverProtocol,
+				ID:             sess.ID(),
+				BrowserName:    caps.GetName(),
+				BrowserVersion: caps.GetVersion(),
+				Labels:         caps.GetLabels(),
+				IdleDuration:   idle,
+				Timeout:        timeout,
+			}))
+		}
+	}
+}
+
+// TerminateSession deletes session and remembers the reason for a while, so subsequent commands get a meaningful error
+func (s *WDSessionService) TerminateSession(sess *session.Session, reason string) {
+	s.mtx.Lock()
+	s.terminated[sess.ID()] = terminatedSession{at: s.now(), reason: reason}
+	s.mtx.Unlock()
+	s.DeleteSession(sess)
+}
+
+func (s *WDSessionService) pruneTerminated() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	now := s.now()
+	for id, t := range s.terminated {
+		if now.Sub(t.at) > terminatedRetention {
+			delete(s.terminated, id)
 		}
 	}
 }
 
 func (s *WDSessionService) Shutdown(ctx context.Context) error {
-	if s.cancel == nil {
-		return nil
+	if s.cancel != nil {
+		s.cancel()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-s.done:
+		}
 	}
-	s.cancel()
+
+	// wait for browsers of deleted sessions to be released
+	released := make(chan struct{})
+	go func() {
+		s.releasing.Wait()
+		close(released)
+	}()
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-s.done:
+	case <-released:
 		return nil
 	}
 }
@@ -1,6 +1,8 @@
 package wdsession_test
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -20,6 +22,7 @@ import (
 	"github.com/selebrow/selebrow/mocks"
 	"github.com/selebrow/selebrow/pkg/browser"
 	"github.com/selebrow/selebrow/pkg/capabilities"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
@@ -31,13 +34,15 @@ func TestWDSessionServiceImpl_CreateSession(t *testing.T) {
 	ss := mocks.NewSessionStorage(t)
 	createTime := time.UnixMilli(123)
 	now := func() time.Time { return createTime }
-	svc := wdsession.NewWDSessionServiceImpl(mgr, ss, client, cfg, now, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(mgr, ss, nil, nil, nil, client, cfg, now, 0, zaptest.NewLogger(t))
 
-	caps := mocks.NewCapabilities(t)
+	caps := mocks.NewWDCapabilities(t)
 	caps.EXPECT().GetPlatform().Return("cp/m")
 	caps.EXPECT().GetName().Return("opera")
 	caps.EXPECT().GetVersion().Return("123.23")
 	caps.EXPECT().GetRawCapabilities().Return([]byte("{}"))
+	caps.EXPECT().GetTimeout().Return(2 * time.Hour)
+	caps.EXPECT().IsDownloadsEnabled().Return(true)
 
 	ss.EXPECT().IsShutdown().Return(false).Once()
 
@@ -57,6 +62,7 @@ func TestWDSessionServiceImpl_CreateSession(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	br.EXPECT().GetURL().Return(u)
 	br.EXPECT().GetHost().Return("hst:111")
+	br.EXPECT().GetHostPort(models.FileserverPort).Return("host:8080")
 
 	client.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
 		expUrl := *u
@@ -89,7 +95,10 @@ func TestWDSessionServiceImpl_CreateSession(t *testing.T) {
 		g.Expect(req.URL).To(Equal(&expUrl))
 		g.Expect(req.Host).To(Equal("hst:111"))
 		g.Expect(req.Header.Get("Content-Type")).To(Equal("application/json; charset=UTF-8"))
-	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"value":{"sessionId":"123"}}`))}, nil).Once()
+	}).Return(&http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(`{"value":{"sessionId":"123","capabilities":{"browserName":"opera"}}}`)),
+	}, nil).Once()
 
 	var savedSess *session.Session
 	ss.EXPECT().Add(models.WebdriverProtocol, mock.Anything).RunAndReturn(func(_ models.BrowserProtocol, sess *session.Session) error {
@@ -101,10 +110,15 @@ func TestWDSessionServiceImpl_CreateSession(t *testing.T) {
 		g.Expect(sess.Resp()).To(Equal(map[string]interface{}{
 			"value": map[string]interface{}{
 				"sessionId": "123",
+				"capabilities": map[string]interface{}{
+					"browserName":         "opera",
+					"se:downloadsEnabled": true,
+				},
 			},
 		}))
 		g.Expect(sess.Context()).To(BeNil())
 		g.Expect(sess.Cancel()).To(BeNil())
+		g.Expect(sess.Timeout()).To(Equal(time.Hour))
 
 		savedSess = sess
 		return nil
@@ -124,7 +138,7 @@ func TestWDSessionServiceImpl_CreateSessionDefaultPlatform(t *testing.T) {
 	mgr := mocks.NewBrowserManager(t)
 	ss := mocks.NewSessionStorage(t)
 	now := func() time.Time { return time.Time{} }
-	svc := wdsession.NewWDSessionServiceImpl(mgr, ss, client, cfg, now, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(mgr, ss, nil, nil, nil, client, cfg, now, 0, zaptest.NewLogger(t))
 
 	sess, err := createSession(t, g, svc, ss, mgr, client, "", "netscape", "11", "http://host1", "s1", "hst:11111")
 	g.Expect(err).ToNot(HaveOccurred())
@@ -138,11 +152,11 @@ func TestWDSessionServiceImpl_CreateSessionTimeout(t *testing.T) {
 	cfg := createCfg(t, time.Nanosecond, false)
 	mgr := mocks.NewBrowserManager(t)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(mgr, ss, nil, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(mgr, ss, nil, nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
 
 	ss.EXPECT().IsShutdown().Return(false).Once()
 
-	caps := mocks.NewCapabilities(t)
+	caps := mocks.NewWDCapabilities(t)
 	caps.EXPECT().GetPlatform().Return("")
 
 	mgr.EXPECT().
@@ -162,7 +176,7 @@ func TestWDSessionServiceImpl_CreateSession_Shutdown(t *testing.T) {
 
 	cfg := createCfg(t, time.Second, false)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
 
 	ss.EXPECT().IsShutdown().Return(true).Once()
 	_, err := svc.CreateSession(context.TODO(), nil)
@@ -176,11 +190,13 @@ func TestWDSessionServiceImpl_CreateSession_StorageError(t *testing.T) {
 	mgr := mocks.NewBrowserManager(t)
 	ss := mocks.NewSessionStorage(t)
 	now := func() time.Time { return time.UnixMilli(123) }
-	svc := wdsession.NewWDSessionServiceImpl(mgr, ss, client, cfg, now, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(mgr, ss, nil, nil, nil, client, cfg, now, 0, zaptest.NewLogger(t))
 
-	caps := mocks.NewCapabilities(t)
+	caps := mocks.NewWDCapabilities(t)
 	caps.EXPECT().GetPlatform().Return("cp/m")
 	caps.EXPECT().GetRawCapabilities().Return([]byte("{}"))
+	caps.EXPECT().GetTimeout().Return(0)
+	caps.EXPECT().IsDownloadsEnabled().Return(false)
 
 	ss.EXPECT().IsShutdown().Return(false).Once()
 
@@ -209,7 +225,7 @@ func TestWDSessionServiceImpl_ListSessions(t *testing.T) {
 	g := NewWithT(t)
 	cfg := createCfg(t, time.Second, false)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
 
 	testSessList := []*session.Session{{}, {}}
 
@@ -221,7 +237,7 @@ func TestWDSessionServiceImpl_ListSessions(t *testing.T) {
 func TestWDSessionServiceImpl_DeleteSession(t *testing.T) {
 	cfg := createCfg(t, time.Second, false)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
 
 	br1 := mocks.NewBrowser(t)
 	s1 := session.NewSession("12345", "", br1, nil, nil, time.Time{}, nil, nil)
@@ -229,6 +245,7 @@ func TestWDSessionServiceImpl_DeleteSession(t *testing.T) {
 	ss.EXPECT().Delete(models.WebdriverProtocol, "12345").Return(true).Once()
 	br1.EXPECT().Close(context.Background(), true).Once()
 	svc.DeleteSession(s1)
+	_ = svc.Shutdown(t.Context()) // wait for browser to be released in background
 }
 
 func TestWDSessionServiceImpl_DeleteSessionProxy(t *testing.T) {
@@ -237,7 +254,7 @@ func TestWDSessionServiceImpl_DeleteSessionProxy(t *testing.T) {
 	client := mocks.NewHTTPClient(t)
 	cfg := createCfg(t, time.Second, true)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, client, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, client, cfg, nil, 0, zaptest.NewLogger(t))
 
 	u, err := url.Parse("http://host1")
 	g.Expect(err).ToNot(HaveOccurred())
@@ -270,6 +287,104 @@ func TestWDSessionServiceImpl_DeleteSessionProxy(t *testing.T) {
 	br1.EXPECT().Close(context.Background(), false).Once()
 
 	svc.DeleteSession(s1)
+	_ = svc.Shutdown(t.Context()) // wait for browser to be released in background
+}
+
+func TestWDSessionServiceImpl_DeleteSessionFinalScreenshot(t *testing.T) {
+	g := NewWithT(t)
+
+	client := mocks.NewHTTPClient(t)
+	store := mocks.NewArtifactStore(t)
+	cfg := createCfg(t, time.Second, false)
+	ss := mocks.NewSessionStorage(t)
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, store, client, cfg, nil, 0, zaptest.NewLogger(t))
+
+	u, err := url.Parse("http://host1")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caps := mocks.NewWDCapabilities(t)
+	caps.EXPECT().IsFinalScreenshotEnabled().Return(true)
+	caps.EXPECT().GetTestName().Return("my test")
+	br1 := mocks.NewBrowser(t)
+	s1 := session.NewSession("s1", "", br1, caps, nil, time.Time{}, nil, nil)
+
+	ss.EXPECT().Delete(models.WebdriverProtocol, "s1").Return(true).Once()
+	br1.EXPECT().GetURL().Return(u)
+	br1.EXPECT().GetHost().Return("hst:11111")
+
+	client.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.Method).To(Equal(http.MethodGet))
+		g.Expect(req.URL.String()).To(Equal("http://host1/session/s1/screenshot"))
+		g.Expect(req.Host).To(Equal("hst:11111"))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"value":"iVBORw0KGgo="}`))}, nil).Once()
+	store.EXPECT().SaveScreenshot("s1", "my test", []byte("\x89PNG\r\n\x1a\n")).Return(nil).Once()
+	br1.EXPECT().Close(context.Background(), true).Once()
+
+	svc.DeleteSession(s1)
+	_ = svc.Shutdown(t.Context()) // wait for browser to be released in background
+}
+
+func TestWDSessionServiceImpl_DeleteSessionFinalScreenshotError(t *testing.T) {
+	client := mocks.NewHTTPClient(t)
+	store := mocks.NewArtifactStore(t)
+	cfg := createCfg(t, time.Second, false)
+	ss := mocks.NewSessionStorage(t)
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, store, client, cfg, nil, 0, zaptest.NewLogger(t))
+
+	u, _ := url.Parse("http://host1")
+	caps := mocks.NewWDCapabilities(t)
+	caps.EXPECT().IsFinalScreenshotEnabled().Return(true)
+	br1 := mocks.NewBrowser(t)
+	s1 := session.NewSession("s1", "", br1, caps, nil, time.Time{}, nil, nil)
+
+	ss.EXPECT().Delete(models.WebdriverProtocol, "s1").Return(true).Once()
+	br1.EXPECT().GetURL().Return(u)
+	br1.EXPECT().GetHost().Return("hst:11111")
+	client.EXPECT().Do(mock.Anything).
+		Return(&http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(strings.NewReader(`no such window`))}, nil).Once()
+	br1.EXPECT().Close(context.Background(), true).Once()
+
+	svc.DeleteSession(s1)
+	_ = svc.Shutdown(t.Context()) // wait for browser to be released in background
+}
+
+func TestWDSessionServiceImpl_DeleteSessionArchiveDownloads(t *testing.T) {
+	g := NewWithT(t)
+
+	client := mocks.NewHTTPClient(t)
+	store := mocks.NewArtifactStore(t)
+	cfg := createCfgWithArchive(t, time.Second, false, time.Minute, time.Hour, 0, true)
+	ss := mocks.NewSessionStorage(t)
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, store, client, cfg, nil, 0, zaptest.NewLogger(t))
+
+	caps := mocks.NewWDCapabilities(t)
+	caps.EXPECT().IsFinalScreenshotEnabled().Return(false)
+	br1 := mocks.NewBrowser(t)
+	s1 := session.NewSession("s1", "", br1, caps, nil, time.Time{}, nil, nil)
+
+	ss.EXPECT().Delete(models.WebdriverProtocol, "s1").Return(true).Once()
+	br1.EXPECT().GetHostPort(models.FileserverPort).Return("host1:3322").Once()
+	client.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.Method).To(Equal(http.MethodGet))
+		g.Expect(req.URL.String()).To(Equal("http://host1:3322?json=true"))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[ "file1.txt" ]`))}, nil).Once()
+	client.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.Method).To(Equal(http.MethodGet))
+		g.Expect(req.URL.String()).To(Equal("http://host1:3322/file1.txt"))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`hello`))}, nil).Once()
+
+	archive := &bufferCloser{}
+	store.EXPECT().CreateDownloadsArchive("s1").Return(archive, nil).Once()
+	br1.EXPECT().Close(context.Background(), true).Once()
+
+	svc.DeleteSession(s1)
+	g.Expect(svc.Shutdown(t.Context())).To(Succeed())
+
+	g.Expect(archive.closed).To(BeTrue())
+	zr, err := zip.NewReader(bytes.NewReader(archive.Bytes()), int64(archive.Len()))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(zr.File).To(HaveLen(1))
+	g.Expect(zr.File[0].Name).To(Equal("file1.txt"))
 }
 
 func TestWDSessionServiceImpl_DeleteSessionTrash(t *testing.T) {
@@ -278,7 +393,7 @@ func TestWDSessionServiceImpl_DeleteSessionTrash(t *testing.T) {
 	client := mocks.NewHTTPClient(t)
 	cfg := createCfg(t, time.Second, true)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, client, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, client, cfg, nil, 0, zaptest.NewLogger(t))
 
 	u, err := url.Parse("http://host1")
 	g.Expect(err).ToNot(HaveOccurred())
@@ -298,6 +413,7 @@ func TestWDSessionServiceImpl_DeleteSessionTrash(t *testing.T) {
 		g.Expect(req.Host).To(Equal("hst:11111"))
 	}).Return(&http.Response{StatusCode: http.StatusServiceUnavailable, Body: io.NopCloser(strings.NewReader(``))}, nil)
 	svc.DeleteSession(s1)
+	_ = svc.Shutdown(t.Context()) // wait for browser to be released in background
 }
 
 func TestWDSessionServiceImpl_DeleteSession_CleanupTrash(t *testing.T) {
@@ -306,7 +422,7 @@ func TestWDSessionServiceImpl_DeleteSession_CleanupTrash(t *testing.T) {
 	client := mocks.NewHTTPClient(t)
 	cfg := createCfg(t, time.Second, true)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, client, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, client, cfg, nil, 0, zaptest.NewLogger(t))
 
 	u, err := url.Parse("http://host1")
 	g.Expect(err).ToNot(HaveOccurred())
@@ -334,17 +450,76 @@ func TestWDSessionServiceImpl_DeleteSession_CleanupTrash(t *testing.T) {
 	br1.EXPECT().Close(context.Background(), true).Once()
 
 	svc.DeleteSession(s1)
+	_ = svc.Shutdown(t.Context()) // wait for browser to be released in background
+}
+
+func TestWDSessionServiceImpl_DeleteSession_ResetTrash(t *testing.T) {
+	g := NewWithT(t)
+
+	client := mocks.NewHTTPClient(t)
+	cfg := createCfg(t, time.Second, true)
+	ss := mocks.NewSessionStorage(t)
+	rs := mocks.NewBrowserResetter(t)
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, rs, nil, client, cfg, nil, 0, zaptest.NewLogger(t))
+
+	u, err := url.Parse("http://host1")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	br1 := mocks.NewBrowser(t)
+	caps := mocks.NewWDCapabilities(t)
+	s1 := session.NewSession("s1", "", br1, caps, nil, time.Time{}, nil, nil)
+
+	ss.EXPECT().Delete(models.WebdriverProtocol, "s1").Return(true).Once()
+	br1.EXPECT().GetURL().Return(u)
+	br1.EXPECT().GetHost().Return("hst:11111")
+	client.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.Method).To(Equal(http.MethodDelete))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(``))}, nil).Once()
+	br1.EXPECT().GetHostPort(models.FileserverPort).Return("host1:3322").Once()
+	client.EXPECT().Do(mock.Anything).Run(func(req *http.Request) {
+		g.Expect(req.Method).To(Equal(http.MethodGet))
+	}).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`[]`))}, nil).Once()
+	rs.EXPECT().Reset(context.Background(), models.WebdriverProtocol, caps, br1).Return(errors.New("test error")).Once()
+	br1.EXPECT().Close(context.Background(), true).Once()
+
+	svc.DeleteSession(s1)
+	_ = svc.Shutdown(t.Context()) // wait for browser to be released in background
+}
+
+func TestWDSessionServiceImpl_DeleteSession_Background(t *testing.T) {
+	g := NewWithT(t)
+	cfg := createCfg(t, time.Second, false)
+	ss := mocks.NewSessionStorage(t)
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
+
+	br1 := mocks.NewBrowser(t)
+	s1 := session.NewSession("12345", "", br1, nil, nil, time.Time{}, nil, nil)
+
+	release := make(chan struct{})
+	ss.EXPECT().Delete(models.WebdriverProtocol, "12345").Return(true).Once()
+	br1.EXPECT().Close(context.Background(), true).Run(func(context.Context, bool) {
+		<-release
+	}).Once()
+	svc.DeleteSession(s1)
+
+	// slow browser release doesn't block delete, but shutdown waits for it
+	ctx, cancel := context.WithTimeout(t.Context(), 20*time.Millisecond)
+	defer cancel()
+	g.Expect(svc.Shutdown(ctx)).To(MatchError(context.DeadlineExceeded))
+	close(release)
+	g.Expect(svc.Shutdown(t.Context())).To(Succeed())
 }
 
 func TestWDSessionServiceImpl_DeleteSession_AlreadyDeleted(t *testing.T) {
 	cfg := createCfg(t, time.Second, false)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
 
 	s1 := session.NewSession("12345", "", nil, nil, nil, time.Time{}, nil, nil)
 
 	ss.EXPECT().Delete(models.WebdriverProtocol, "12345").Return(false)
 	svc.DeleteSession(s1)
+	_ = svc.Shutdown(t.Context()) // wait for browser to be released in background
 }
 
 func TestWDSessionServiceImpl_FindSession(t *testing.T) {
@@ -352,7 +527,7 @@ func TestWDSessionServiceImpl_FindSession(t *testing.T) {
 
 	cfg := createCfg(t, time.Second, false)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
 
 	s1 := session.NewSession("12345", "", nil, nil, nil, time.Time{}, nil, nil)
 	ss.EXPECT().Get(models.WebdriverProtocol, "12345").Return(s1, true).Once()
@@ -366,7 +541,7 @@ func TestWDSessionServiceImpl_FindSession_NotFound(t *testing.T) {
 
 	cfg := createCfg(t, time.Second, false)
 	ss := mocks.NewSessionStorage(t)
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
 
 	ss.EXPECT().Get(models.WebdriverProtocol, "12345").Return(nil, false).Once()
 	_, err := svc.FindSession("12345")
@@ -377,7 +552,7 @@ func TestWDSessionServiceImpl_Shutdown_NoOp(t *testing.T) {
 	g := NewWithT(t)
 
 	cfg := createCfg(t, time.Second, false)
-	svc := wdsession.NewWDSessionServiceImpl(nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
+	svc := wdsession.NewWDSessionServiceImpl(nil, nil, nil, nil, nil, nil, cfg, nil, 0, zaptest.NewLogger(t))
 
 	err := svc.Shutdown(t.Context())
 	g.Expect(err).ToNot(HaveOccurred())
@@ -386,15 +561,14 @@ func TestWDSessionServiceImpl_Shutdown_NoOp(t *testing.T) {
 func TestWDSessionServiceImpl_CleanupSessions(t *testing.T) {
 	g := NewWithT(t)
 
-	cfg := createCfg(t, time.Second, false)
-	cfg.EXPECT().DefaultSessionTimeout().Return(time.Second).Once()
-	cfg.EXPECT().MaxSessionTimeout().Return(50 * time.Millisecond).Once()
+	cfg := createCfgWithTimeouts(t, time.Second, false, time.Second, 50*time.Millisecond)
 
 	ss := mocks.NewSessionStorage(t)
 	now := func() time.Time { return time.UnixMilli(123) }
 
 	br1 := mocks.NewBrowser(t)
-	caps := mocks.NewCapabilities(t)
+	caps := mocks.NewWDCapabilities(t)
+	caps.EXPECT().GetMaxLifetime().Return(0).Once()
 	caps.EXPECT().GetTimeout().Return(0).Once()
 	s1 := session.NewSession("12345", "", br1, caps, nil, time.Time{}, nil, nil)
 	s1.SetLastUsed(time.UnixMilli(70))
@@ -408,21 +582,149 @@ func TestWDSessionServiceImpl_CleanupSessions(t *testing.T) {
 			return true
 		}).Once()
 	br1.EXPECT().Close(context.Background(), true).Once()
-	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, cfg, now, 10*time.Millisecond, zaptest.NewLogger(t))
+	caps.EXPECT().GetName().Return("chrome").Once()
+	caps.EXPECT().GetVersion().Return("120.0").Once()
+	caps.EXPECT().GetLabels().Return(map[string]string{"team": "qa"}).Once()
+	published := make(chan evmodels.IEvent, 1)
+	eb := mocks.NewEventBroker(t)
+	eb.EXPECT().Publish(mock.Anything).Run(func(ev evmodels.IEvent) {
+		published <- ev
+	}).Once()
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, eb, nil, nil, nil, cfg, now, 10*time.Millisecond, zaptest.NewLogger(t))
 
 	g.Eventually(ch).Should(BeClosed())
+	var ev evmodels.IEvent
+	g.Eventually(published).Should(Receive(&ev))
+	g.Expect(ev.EventType()).To(Equal(evmodels.SessionIdleTimeoutEventType))
+	g.Expect(ev.(*evmodels.Event[evmodels.SessionIdleTimeout]).Attributes).To(Equal(evmodels.SessionIdleTimeout{
+		Protocol:       models.WebdriverProtocol,
+		ID:             "12345",
+		BrowserName:    "chrome",
+		BrowserVersion: "120.0",
+		Labels:         map[string]string{"team": "qa"},
+		IdleDuration:   53 * time.Millisecond,
+		Timeout:        50 * time.Millisecond,
+	}))
 
 	err := svc.Shutdown(t.Context())
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestWDSessionServiceImpl_CleanupSessions_AlreadyDeleted(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := createCfgWithTimeouts(t, time.Second, false, time.Second, 50*time.Millisecond)
+
+	ss := mocks.NewSessionStorage(t)
+	now := func() time.Time { return time.UnixMilli(123) }
+
+	caps := mocks.NewWDCapabilities(t)
+	caps.EXPECT().GetMaxLifetime().Return(0).Once()
+	caps.EXPECT().GetTimeout().Return(0).Once()
+	s1 := session.NewSession("12345", "", nil, caps, nil, time.Time{}, nil, nil)
+	s1.SetLastUsed(time.UnixMilli(70))
+
+	ss.EXPECT().List(models.WebdriverProtocol).Return([]*session.Session{s1}).Once()
+	ss.EXPECT().List(models.WebdriverProtocol).Return([]*session.Session{})
+	ch := make(chan struct{})
+	// session is deleted by client concurrently, so no idle timeout event is expected
+	ss.EXPECT().Delete(models.WebdriverProtocol, "12345").
+		RunAndReturn(func(protocol models.BrowserProtocol, id string) bool {
+			close(ch)
+			return false
+		}).Once()
+	eb := mocks.NewEventBroker(t)
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, eb, nil, nil, nil, cfg, now, 10*time.Millisecond, zaptest.NewLogger(t))
+
+	g.Eventually(ch).Should(BeClosed())
+	err := svc.Shutdown(t.Context())
+	g.Expect(err).ToNot(HaveOccurred())
+}
+
+func TestWDSessionServiceImpl_CleanupSessions_MaxLifetime(t *testing.T) {
+	g := NewWithT(t)
+
+	cfg := createCfgWithLifetime(t, time.Second, false, time.Hour, time.Hour, time.Hour)
+
+	ss := mocks.NewSessionStorage(t)
+	now := func() time.Time { return time.UnixMilli(0).Add(31 * time.Minute) }
+
+	br1 := mocks.NewBrowser(t)
+	caps := mocks.NewWDCapabilities(t)
+	caps.EXPECT().GetMaxLifetime().Return(30 * time.Minute)
+	s1 := session.NewSession("12345", "", br1, caps, nil, time.UnixMilli(0), nil, nil)
+	s1.SetLastUsed(now())
+
+	ss.EXPECT().List(models.WebdriverProtocol).Return([]*session.Session{s1}).Once()
+	ss.EXPECT().List(models.WebdriverProtocol).Return([]*session.Session{})
+	ch := make(chan struct{})
+	ss.EXPECT().Delete(models.WebdriverProtocol, "12345").
+		RunAndReturn(func(protocol models.BrowserProtocol, id string) bool {
+			close(ch)
+			return true
+		}).Once()
+	ss.EXPECT().Get(models.WebdriverProtocol, "12345").Return(nil, false).Once()
+	br1.EXPECT().Close(context.Background(), true).Once()
+	svc := wdsession.NewWDSessionServiceImpl(nil, ss, nil, nil, nil, nil, cfg, now, 10*time.Millisecond, zaptest.NewLogger(t))
+
+	g.Eventually(ch).Should(BeClosed())
+	err := svc.Shutdown(t.Context())
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, err = svc.FindSession("12345")
+	g.Expect(err).To(MatchError(session.ErrSessionTerminated))
+	g.Expect(err).To(MatchError(ContainSubstring("max session lifetime 30m0s is reached")))
+}
+
 func createCfg(t *testing.T, timeout time.Duration, proxyDelete bool) *mocks.WDSessionConfig {
+	return createCfgWithTimeouts(t, timeout, proxyDelete, time.Minute, time.Hour)
+}
+
+func createCfgWithTimeouts(
+	t *testing.T,
+	timeout time.Duration,
+	proxyDelete bool,
+	defaultTimeout, maxTimeout time.Duration,
+) *mocks.WDSessionConfig {
+	return createCfgWithLifetime(t, timeout, proxyDelete, defaultTimeout, maxTimeout, 0)
+}
+
+func createCfgWithLifetime(
+	t *testing.T,
+	timeout time.Duration,
+	proxyDelete bool,
+	defaultTimeout, maxTimeout, maxLifetime time.Duration,
+) *mocks.WDSessionConfig {
+	return createCfgWithArchive(t, timeout, proxyDelete, defaultTimeout, maxTimeout, maxLifetime, false)
+}
+
+func createCfgWithArchive(
+	t *testing.T,
+	timeout time.Duration,
+	proxyDelete bool,
+	defaultTimeout, maxTimeout, maxLifetime time.Duration,
+	archive bool,
+) *mocks.WDSessionConfig {
 	cfg := mocks.NewWDSessionConfig(t)
 	cfg.EXPECT().CreateTimeout().Return(timeout)
 	cfg.EXPECT().ProxyDelete().Return(proxyDelete)
+	cfg.EXPECT().DefaultSessionTimeout().Return(defaultTimeout)
+	cfg.EXPECT().MaxSessionTimeout().Return(maxTimeout)
+	cfg.EXPECT().MaxSessionLifetime().Return(maxLifetime)
+	cfg.EXPECT().ArchiveDownloads().Return(archive)
 	return cfg
 }
 
+type bufferCloser struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (b *bufferCloser) Close() error {
+	b.closed = true
+	return nil
+}
+
 func createSession(
 	t *testing.T,
 	g *WithT,
@@ -435,11 +737,13 @@ func createSession(
 	ss.EXPECT().IsShutdown().Return(false).Once()
 	ss.EXPECT().Add(models.WebdriverProtocol, mock.Anything).Return(nil).Once()
 
-	caps := mocks.NewCapabilities(t)
+	caps := mocks.NewWDCapabilities(t)
 	caps.EXPECT().GetPlatform().Return(platform)
 	caps.EXPECT().GetName().Return(browserName)
 	caps.EXPECT().GetVersion().Return(version)
 	caps.EXPECT().GetRawCapabilities().Return([]byte("{}"))
+	caps.EXPECT().GetTimeout().Return(0)
+	caps.EXPECT().IsDownloadsEnabled().Return(false)
 
 	u, err := url.Parse(driverUrl)
 	g.Expect(err).ToNot(HaveOccurred())
@@ -85,6 +85,107 @@ func (_c *Browser_Close_Call) RunAndReturn(run func(ctx context.Context, trash b
 	return _c
 }
 
+// Exec provides a mock function for the type Browser
+func (_mock *Browser) Exec(ctx context.Context, cmd []string) error {
+	ret := _mock.Called(ctx, cmd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Exec")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = returnFunc(ctx, cmd)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Browser_Exec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Exec'
+type Browser_Exec_Call struct {
+	*mock.Call
+}
+
+// Exec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cmd []string
+func (_e *Browser_Expecter) Exec(ctx interface{}, cmd interface{}) *Browser_Exec_Call {
+	return &Browser_Exec_Call{Call: _e.mock.On("Exec", ctx, cmd)}
+}
+
+func (_c *Browser_Exec_Call) Run(run func(ctx context.Context, cmd []string)) *Browser_Exec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *Browser_Exec_Call) Return(err error) *Browser_Exec_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Browser_Exec_Call) RunAndReturn(run func(ctx context.Context, cmd []string) error) *Browser_Exec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBackendID provides a mock function for the type Browser
+func (_mock *Browser) GetBackendID() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBackendID")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Browser_GetBackendID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBackendID'
+type Browser_GetBackendID_Call struct {
+	*mock.Call
+}
+
+// GetBackendID is a helper method to define mock.On call
+func (_e *Browser_Expecter) GetBackendID() *Browser_GetBackendID_Call {
+	return &Browser_GetBackendID_Call{Call: _e.mock.On("GetBackendID")}
+}
+
+func (_c *Browser_GetBackendID_Call) Run(run func()) *Browser_GetBackendID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Browser_GetBackendID_Call) Return(s string) *Browser_GetBackendID_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Browser_GetBackendID_Call) RunAndReturn(run func() string) *Browser_GetBackendID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetHost provides a mock function for the type Browser
 func (_mock *Browser) GetHost() string {
 	ret := _mock.Called()
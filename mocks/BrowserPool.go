@@ -9,6 +9,7 @@ import (
 
 	"github.com/selebrow/selebrow/pkg/browser"
 	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/dto"
 	"github.com/selebrow/selebrow/pkg/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -114,6 +115,110 @@ func (_c *BrowserPool_Checkout_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
+// Drain provides a mock function for the type BrowserPool
+func (_mock *BrowserPool) Drain(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Drain")
+	}
+
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// BrowserPool_Drain_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Drain'
+type BrowserPool_Drain_Call struct {
+	*mock.Call
+}
+
+// Drain is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *BrowserPool_Expecter) Drain(ctx interface{}) *BrowserPool_Drain_Call {
+	return &BrowserPool_Drain_Call{Call: _e.mock.On("Drain", ctx)}
+}
+
+func (_c *BrowserPool_Drain_Call) Run(run func(ctx context.Context)) *BrowserPool_Drain_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BrowserPool_Drain_Call) Return(n int, err error) *BrowserPool_Drain_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *BrowserPool_Drain_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *BrowserPool_Drain_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Info provides a mock function for the type BrowserPool
+func (_mock *BrowserPool) Info() dto.Pool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Info")
+	}
+
+	var r0 dto.Pool
+	if returnFunc, ok := ret.Get(0).(func() dto.Pool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(dto.Pool)
+	}
+	return r0
+}
+
+// BrowserPool_Info_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Info'
+type BrowserPool_Info_Call struct {
+	*mock.Call
+}
+
+// Info is a helper method to define mock.On call
+func (_e *BrowserPool_Expecter) Info() *BrowserPool_Info_Call {
+	return &BrowserPool_Info_Call{Call: _e.mock.On("Info")}
+}
+
+func (_c *BrowserPool_Info_Call) Run(run func()) *BrowserPool_Info_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *BrowserPool_Info_Call) Return(pool dto.Pool) *BrowserPool_Info_Call {
+	_c.Call.Return(pool)
+	return _c
+}
+
+func (_c *BrowserPool_Info_Call) RunAndReturn(run func() dto.Pool) *BrowserPool_Info_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Shutdown provides a mock function for the type BrowserPool
 func (_mock *BrowserPool) Shutdown(ctx context.Context) error {
 	ret := _mock.Called(ctx)
@@ -37,16 +37,16 @@ func (_m *BrowserPoolFactory) EXPECT() *BrowserPoolFactory_Expecter {
 }
 
 // GetPool provides a mock function for the type BrowserPoolFactory
-func (_mock *BrowserPoolFactory) GetPool(name string) pool.BrowserPool {
-	ret := _mock.Called(name)
+func (_mock *BrowserPoolFactory) GetPool(key pool.PoolKey) pool.BrowserPool {
+	ret := _mock.Called(key)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetPool")
 	}
 
 	var r0 pool.BrowserPool
-	if returnFunc, ok := ret.Get(0).(func(string) pool.BrowserPool); ok {
-		r0 = returnFunc(name)
+	if returnFunc, ok := ret.Get(0).(func(pool.PoolKey) pool.BrowserPool); ok {
+		r0 = returnFunc(key)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(pool.BrowserPool)
@@ -61,16 +61,16 @@ type BrowserPoolFactory_GetPool_Call struct {
 }
 
 // GetPool is a helper method to define mock.On call
-//   - name string
-func (_e *BrowserPoolFactory_Expecter) GetPool(name interface{}) *BrowserPoolFactory_GetPool_Call {
-	return &BrowserPoolFactory_GetPool_Call{Call: _e.mock.On("GetPool", name)}
+//   - key pool.PoolKey
+func (_e *BrowserPoolFactory_Expecter) GetPool(key interface{}) *BrowserPoolFactory_GetPool_Call {
+	return &BrowserPoolFactory_GetPool_Call{Call: _e.mock.On("GetPool", key)}
 }
 
-func (_c *BrowserPoolFactory_GetPool_Call) Run(run func(name string)) *BrowserPoolFactory_GetPool_Call {
+func (_c *BrowserPoolFactory_GetPool_Call) Run(run func(key pool.PoolKey)) *BrowserPoolFactory_GetPool_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 pool.PoolKey
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(pool.PoolKey)
 		}
 		run(
 			arg0,
@@ -84,7 +84,7 @@ func (_c *BrowserPoolFactory_GetPool_Call) Return(browserPool pool.BrowserPool)
 	return _c
 }
 
-func (_c *BrowserPoolFactory_GetPool_Call) RunAndReturn(run func(name string) pool.BrowserPool) *BrowserPoolFactory_GetPool_Call {
+func (_c *BrowserPoolFactory_GetPool_Call) RunAndReturn(run func(key pool.PoolKey) pool.BrowserPool) *BrowserPoolFactory_GetPool_Call {
 	_c.Call.Return(run)
 	return _c
 }
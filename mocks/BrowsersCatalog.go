@@ -96,6 +96,59 @@ func (_c *BrowsersCatalog_GetBrowsers_Call) RunAndReturn(run func(protocol model
 	return _c
 }
 
+// GetFlavors provides a mock function for the type BrowsersCatalog
+func (_mock *BrowsersCatalog) GetFlavors(protocol models.BrowserProtocol) []string {
+	ret := _mock.Called(protocol)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFlavors")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func(models.BrowserProtocol) []string); ok {
+		r0 = returnFunc(protocol)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// BrowsersCatalog_GetFlavors_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFlavors'
+type BrowsersCatalog_GetFlavors_Call struct {
+	*mock.Call
+}
+
+// GetFlavors is a helper method to define mock.On call
+//   - protocol models.BrowserProtocol
+func (_e *BrowsersCatalog_Expecter) GetFlavors(protocol interface{}) *BrowsersCatalog_GetFlavors_Call {
+	return &BrowsersCatalog_GetFlavors_Call{Call: _e.mock.On("GetFlavors", protocol)}
+}
+
+func (_c *BrowsersCatalog_GetFlavors_Call) Run(run func(protocol models.BrowserProtocol)) *BrowsersCatalog_GetFlavors_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 models.BrowserProtocol
+		if args[0] != nil {
+			arg0 = args[0].(models.BrowserProtocol)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *BrowsersCatalog_GetFlavors_Call) Return(result []string) *BrowsersCatalog_GetFlavors_Call {
+	_c.Call.Return(result)
+	return _c
+}
+
+func (_c *BrowsersCatalog_GetFlavors_Call) RunAndReturn(run func(protocol models.BrowserProtocol) []string) *BrowsersCatalog_GetFlavors_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetImages provides a mock function for the type BrowsersCatalog
 func (_mock *BrowsersCatalog) GetImages() []string {
 	ret := _mock.Called()
@@ -265,6 +265,50 @@ func (_c *Capabilities_GetLinks_Call) RunAndReturn(run func() []string) *Capabil
 	return _c
 }
 
+// GetMaxLifetime provides a mock function for the type Capabilities
+func (_mock *Capabilities) GetMaxLifetime() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMaxLifetime")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Capabilities_GetMaxLifetime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMaxLifetime'
+type Capabilities_GetMaxLifetime_Call struct {
+	*mock.Call
+}
+
+// GetMaxLifetime is a helper method to define mock.On call
+func (_e *Capabilities_Expecter) GetMaxLifetime() *Capabilities_GetMaxLifetime_Call {
+	return &Capabilities_GetMaxLifetime_Call{Call: _e.mock.On("GetMaxLifetime")}
+}
+
+func (_c *Capabilities_GetMaxLifetime_Call) Run(run func()) *Capabilities_GetMaxLifetime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Capabilities_GetMaxLifetime_Call) Return(duration time.Duration) *Capabilities_GetMaxLifetime_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Capabilities_GetMaxLifetime_Call) RunAndReturn(run func() time.Duration) *Capabilities_GetMaxLifetime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetName provides a mock function for the type Capabilities
 func (_mock *Capabilities) GetName() string {
 	ret := _mock.Called()
@@ -39,6 +39,138 @@ func (_m *Config) EXPECT() *Config_Expecter {
 	return &Config_Expecter{mock: &_m.Mock}
 }
 
+// ArchiveDownloads provides a mock function for the type Config
+func (_mock *Config) ArchiveDownloads() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveDownloads")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// Config_ArchiveDownloads_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveDownloads'
+type Config_ArchiveDownloads_Call struct {
+	*mock.Call
+}
+
+// ArchiveDownloads is a helper method to define mock.On call
+func (_e *Config_Expecter) ArchiveDownloads() *Config_ArchiveDownloads_Call {
+	return &Config_ArchiveDownloads_Call{Call: _e.mock.On("ArchiveDownloads")}
+}
+
+func (_c *Config_ArchiveDownloads_Call) Run(run func()) *Config_ArchiveDownloads_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_ArchiveDownloads_Call) Return(b bool) *Config_ArchiveDownloads_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *Config_ArchiveDownloads_Call) RunAndReturn(run func() bool) *Config_ArchiveDownloads_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArtifactsDir provides a mock function for the type Config
+func (_mock *Config) ArtifactsDir() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtifactsDir")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Config_ArtifactsDir_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArtifactsDir'
+type Config_ArtifactsDir_Call struct {
+	*mock.Call
+}
+
+// ArtifactsDir is a helper method to define mock.On call
+func (_e *Config_Expecter) ArtifactsDir() *Config_ArtifactsDir_Call {
+	return &Config_ArtifactsDir_Call{Call: _e.mock.On("ArtifactsDir")}
+}
+
+func (_c *Config_ArtifactsDir_Call) Run(run func()) *Config_ArtifactsDir_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_ArtifactsDir_Call) Return(s string) *Config_ArtifactsDir_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Config_ArtifactsDir_Call) RunAndReturn(run func() string) *Config_ArtifactsDir_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ArtifactsRetention provides a mock function for the type Config
+func (_mock *Config) ArtifactsRetention() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArtifactsRetention")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Config_ArtifactsRetention_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArtifactsRetention'
+type Config_ArtifactsRetention_Call struct {
+	*mock.Call
+}
+
+// ArtifactsRetention is a helper method to define mock.On call
+func (_e *Config_Expecter) ArtifactsRetention() *Config_ArtifactsRetention_Call {
+	return &Config_ArtifactsRetention_Call{Call: _e.mock.On("ArtifactsRetention")}
+}
+
+func (_c *Config_ArtifactsRetention_Call) Run(run func()) *Config_ArtifactsRetention_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_ArtifactsRetention_Call) Return(duration time.Duration) *Config_ArtifactsRetention_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Config_ArtifactsRetention_Call) RunAndReturn(run func() time.Duration) *Config_ArtifactsRetention_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Backend provides a mock function for the type Config
 func (_mock *Config) Backend() config.BackendType {
 	ret := _mock.Called()
@@ -129,6 +261,228 @@ func (_c *Config_BrowsersURI_Call) RunAndReturn(run func() []string) *Config_Bro
 	return _c
 }
 
+// CommandLog provides a mock function for the type Config
+func (_mock *Config) CommandLog() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommandLog")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// Config_CommandLog_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommandLog'
+type Config_CommandLog_Call struct {
+	*mock.Call
+}
+
+// CommandLog is a helper method to define mock.On call
+func (_e *Config_Expecter) CommandLog() *Config_CommandLog_Call {
+	return &Config_CommandLog_Call{Call: _e.mock.On("CommandLog")}
+}
+
+func (_c *Config_CommandLog_Call) Run(run func()) *Config_CommandLog_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_CommandLog_Call) Return(b bool) *Config_CommandLog_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *Config_CommandLog_Call) RunAndReturn(run func() bool) *Config_CommandLog_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CommandLogDir provides a mock function for the type Config
+func (_mock *Config) CommandLogDir() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommandLogDir")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Config_CommandLogDir_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommandLogDir'
+type Config_CommandLogDir_Call struct {
+	*mock.Call
+}
+
+// CommandLogDir is a helper method to define mock.On call
+func (_e *Config_Expecter) CommandLogDir() *Config_CommandLogDir_Call {
+	return &Config_CommandLogDir_Call{Call: _e.mock.On("CommandLogDir")}
+}
+
+func (_c *Config_CommandLogDir_Call) Run(run func()) *Config_CommandLogDir_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_CommandLogDir_Call) Return(s string) *Config_CommandLogDir_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Config_CommandLogDir_Call) RunAndReturn(run func() string) *Config_CommandLogDir_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CommandTimeout provides a mock function for the type Config
+func (_mock *Config) CommandTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommandTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Config_CommandTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommandTimeout'
+type Config_CommandTimeout_Call struct {
+	*mock.Call
+}
+
+// CommandTimeout is a helper method to define mock.On call
+func (_e *Config_Expecter) CommandTimeout() *Config_CommandTimeout_Call {
+	return &Config_CommandTimeout_Call{Call: _e.mock.On("CommandTimeout")}
+}
+
+func (_c *Config_CommandTimeout_Call) Run(run func()) *Config_CommandTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_CommandTimeout_Call) Return(duration time.Duration) *Config_CommandTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Config_CommandTimeout_Call) RunAndReturn(run func() time.Duration) *Config_CommandTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CommandTimeoutKillAfter provides a mock function for the type Config
+func (_mock *Config) CommandTimeoutKillAfter() int {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommandTimeoutKillAfter")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// Config_CommandTimeoutKillAfter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommandTimeoutKillAfter'
+type Config_CommandTimeoutKillAfter_Call struct {
+	*mock.Call
+}
+
+// CommandTimeoutKillAfter is a helper method to define mock.On call
+func (_e *Config_Expecter) CommandTimeoutKillAfter() *Config_CommandTimeoutKillAfter_Call {
+	return &Config_CommandTimeoutKillAfter_Call{Call: _e.mock.On("CommandTimeoutKillAfter")}
+}
+
+func (_c *Config_CommandTimeoutKillAfter_Call) Run(run func()) *Config_CommandTimeoutKillAfter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_CommandTimeoutKillAfter_Call) Return(n int) *Config_CommandTimeoutKillAfter_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *Config_CommandTimeoutKillAfter_Call) RunAndReturn(run func() int) *Config_CommandTimeoutKillAfter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CommandTimeoutOverrides provides a mock function for the type Config
+func (_mock *Config) CommandTimeoutOverrides() map[string]time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommandTimeoutOverrides")
+	}
+
+	var r0 map[string]time.Duration
+	if returnFunc, ok := ret.Get(0).(func() map[string]time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]time.Duration)
+		}
+	}
+	return r0
+}
+
+// Config_CommandTimeoutOverrides_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommandTimeoutOverrides'
+type Config_CommandTimeoutOverrides_Call struct {
+	*mock.Call
+}
+
+// CommandTimeoutOverrides is a helper method to define mock.On call
+func (_e *Config_Expecter) CommandTimeoutOverrides() *Config_CommandTimeoutOverrides_Call {
+	return &Config_CommandTimeoutOverrides_Call{Call: _e.mock.On("CommandTimeoutOverrides")}
+}
+
+func (_c *Config_CommandTimeoutOverrides_Call) Run(run func()) *Config_CommandTimeoutOverrides_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_CommandTimeoutOverrides_Call) Return(stringToDuration map[string]time.Duration) *Config_CommandTimeoutOverrides_Call {
+	_c.Call.Return(stringToDuration)
+	return _c
+}
+
+func (_c *Config_CommandTimeoutOverrides_Call) RunAndReturn(run func() map[string]time.Duration) *Config_CommandTimeoutOverrides_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ConnectTimeout provides a mock function for the type Config
 func (_mock *Config) ConnectTimeout() time.Duration {
 	ret := _mock.Called()
@@ -571,6 +925,316 @@ func (_c *Config_DockerPullImages_Call) RunAndReturn(run func() bool) *Config_Do
 	return _c
 }
 
+// DrainTimeout provides a mock function for the type Config
+func (_mock *Config) DrainTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DrainTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Config_DrainTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DrainTimeout'
+type Config_DrainTimeout_Call struct {
+	*mock.Call
+}
+
+// DrainTimeout is a helper method to define mock.On call
+func (_e *Config_Expecter) DrainTimeout() *Config_DrainTimeout_Call {
+	return &Config_DrainTimeout_Call{Call: _e.mock.On("DrainTimeout")}
+}
+
+func (_c *Config_DrainTimeout_Call) Run(run func()) *Config_DrainTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_DrainTimeout_Call) Return(duration time.Duration) *Config_DrainTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Config_DrainTimeout_Call) RunAndReturn(run func() time.Duration) *Config_DrainTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EventFile provides a mock function for the type Config
+func (_mock *Config) EventFile() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventFile")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Config_EventFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EventFile'
+type Config_EventFile_Call struct {
+	*mock.Call
+}
+
+// EventFile is a helper method to define mock.On call
+func (_e *Config_Expecter) EventFile() *Config_EventFile_Call {
+	return &Config_EventFile_Call{Call: _e.mock.On("EventFile")}
+}
+
+func (_c *Config_EventFile_Call) Run(run func()) *Config_EventFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_EventFile_Call) Return(s string) *Config_EventFile_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Config_EventFile_Call) RunAndReturn(run func() string) *Config_EventFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EventStdout provides a mock function for the type Config
+func (_mock *Config) EventStdout() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventStdout")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// Config_EventStdout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EventStdout'
+type Config_EventStdout_Call struct {
+	*mock.Call
+}
+
+// EventStdout is a helper method to define mock.On call
+func (_e *Config_Expecter) EventStdout() *Config_EventStdout_Call {
+	return &Config_EventStdout_Call{Call: _e.mock.On("EventStdout")}
+}
+
+func (_c *Config_EventStdout_Call) Run(run func()) *Config_EventStdout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_EventStdout_Call) Return(b bool) *Config_EventStdout_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *Config_EventStdout_Call) RunAndReturn(run func() bool) *Config_EventStdout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EventWebhookRetries provides a mock function for the type Config
+func (_mock *Config) EventWebhookRetries() int {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventWebhookRetries")
+	}
+
+	var r0 int
+	if returnFunc, ok := ret.Get(0).(func() int); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	return r0
+}
+
+// Config_EventWebhookRetries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EventWebhookRetries'
+type Config_EventWebhookRetries_Call struct {
+	*mock.Call
+}
+
+// EventWebhookRetries is a helper method to define mock.On call
+func (_e *Config_Expecter) EventWebhookRetries() *Config_EventWebhookRetries_Call {
+	return &Config_EventWebhookRetries_Call{Call: _e.mock.On("EventWebhookRetries")}
+}
+
+func (_c *Config_EventWebhookRetries_Call) Run(run func()) *Config_EventWebhookRetries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_EventWebhookRetries_Call) Return(n int) *Config_EventWebhookRetries_Call {
+	_c.Call.Return(n)
+	return _c
+}
+
+func (_c *Config_EventWebhookRetries_Call) RunAndReturn(run func() int) *Config_EventWebhookRetries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EventWebhookTimeout provides a mock function for the type Config
+func (_mock *Config) EventWebhookTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventWebhookTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Config_EventWebhookTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EventWebhookTimeout'
+type Config_EventWebhookTimeout_Call struct {
+	*mock.Call
+}
+
+// EventWebhookTimeout is a helper method to define mock.On call
+func (_e *Config_Expecter) EventWebhookTimeout() *Config_EventWebhookTimeout_Call {
+	return &Config_EventWebhookTimeout_Call{Call: _e.mock.On("EventWebhookTimeout")}
+}
+
+func (_c *Config_EventWebhookTimeout_Call) Run(run func()) *Config_EventWebhookTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_EventWebhookTimeout_Call) Return(duration time.Duration) *Config_EventWebhookTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Config_EventWebhookTimeout_Call) RunAndReturn(run func() time.Duration) *Config_EventWebhookTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// EventWebhooks provides a mock function for the type Config
+func (_mock *Config) EventWebhooks() []string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EventWebhooks")
+	}
+
+	var r0 []string
+	if returnFunc, ok := ret.Get(0).(func() []string); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	return r0
+}
+
+// Config_EventWebhooks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'EventWebhooks'
+type Config_EventWebhooks_Call struct {
+	*mock.Call
+}
+
+// EventWebhooks is a helper method to define mock.On call
+func (_e *Config_Expecter) EventWebhooks() *Config_EventWebhooks_Call {
+	return &Config_EventWebhooks_Call{Call: _e.mock.On("EventWebhooks")}
+}
+
+func (_c *Config_EventWebhooks_Call) Run(run func()) *Config_EventWebhooks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_EventWebhooks_Call) Return(strings []string) *Config_EventWebhooks_Call {
+	_c.Call.Return(strings)
+	return _c
+}
+
+func (_c *Config_EventWebhooks_Call) RunAndReturn(run func() []string) *Config_EventWebhooks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// HealthCheckTimeout provides a mock function for the type Config
+func (_mock *Config) HealthCheckTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HealthCheckTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Config_HealthCheckTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HealthCheckTimeout'
+type Config_HealthCheckTimeout_Call struct {
+	*mock.Call
+}
+
+// HealthCheckTimeout is a helper method to define mock.On call
+func (_e *Config_Expecter) HealthCheckTimeout() *Config_HealthCheckTimeout_Call {
+	return &Config_HealthCheckTimeout_Call{Call: _e.mock.On("HealthCheckTimeout")}
+}
+
+func (_c *Config_HealthCheckTimeout_Call) Run(run func()) *Config_HealthCheckTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_HealthCheckTimeout_Call) Return(duration time.Duration) *Config_HealthCheckTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Config_HealthCheckTimeout_Call) RunAndReturn(run func() time.Duration) *Config_HealthCheckTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IdleTimeout provides a mock function for the type Config
 func (_mock *Config) IdleTimeout() time.Duration {
 	ret := _mock.Called()
@@ -1011,6 +1675,50 @@ func (_c *Config_MaxIdle_Call) RunAndReturn(run func() int) *Config_MaxIdle_Call
 	return _c
 }
 
+// MaxSessionLifetime provides a mock function for the type Config
+func (_mock *Config) MaxSessionLifetime() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MaxSessionLifetime")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Config_MaxSessionLifetime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MaxSessionLifetime'
+type Config_MaxSessionLifetime_Call struct {
+	*mock.Call
+}
+
+// MaxSessionLifetime is a helper method to define mock.On call
+func (_e *Config_Expecter) MaxSessionLifetime() *Config_MaxSessionLifetime_Call {
+	return &Config_MaxSessionLifetime_Call{Call: _e.mock.On("MaxSessionLifetime")}
+}
+
+func (_c *Config_MaxSessionLifetime_Call) Run(run func()) *Config_MaxSessionLifetime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_MaxSessionLifetime_Call) Return(duration time.Duration) *Config_MaxSessionLifetime_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Config_MaxSessionLifetime_Call) RunAndReturn(run func() time.Duration) *Config_MaxSessionLifetime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MaxSessionTimeout provides a mock function for the type Config
 func (_mock *Config) MaxSessionTimeout() time.Duration {
 	ret := _mock.Called()
@@ -1099,6 +1807,50 @@ func (_c *Config_Namespace_Call) RunAndReturn(run func() string) *Config_Namespa
 	return _c
 }
 
+// PWSessionTimeout provides a mock function for the type Config
+func (_mock *Config) PWSessionTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PWSessionTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Config_PWSessionTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PWSessionTimeout'
+type Config_PWSessionTimeout_Call struct {
+	*mock.Call
+}
+
+// PWSessionTimeout is a helper method to define mock.On call
+func (_e *Config_Expecter) PWSessionTimeout() *Config_PWSessionTimeout_Call {
+	return &Config_PWSessionTimeout_Call{Call: _e.mock.On("PWSessionTimeout")}
+}
+
+func (_c *Config_PWSessionTimeout_Call) Run(run func()) *Config_PWSessionTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_PWSessionTimeout_Call) Return(duration time.Duration) *Config_PWSessionTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Config_PWSessionTimeout_Call) RunAndReturn(run func() time.Duration) *Config_PWSessionTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ProjectName provides a mock function for the type Config
 func (_mock *Config) ProjectName() string {
 	ret := _mock.Called()
@@ -1645,6 +2397,270 @@ func (_c *Config_QuotaLimit_Call) RunAndReturn(run func() int) *Config_QuotaLimi
 	return _c
 }
 
+// ResetTimeout provides a mock function for the type Config
+func (_mock *Config) ResetTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Config_ResetTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetTimeout'
+type Config_ResetTimeout_Call struct {
+	*mock.Call
+}
+
+// ResetTimeout is a helper method to define mock.On call
+func (_e *Config_Expecter) ResetTimeout() *Config_ResetTimeout_Call {
+	return &Config_ResetTimeout_Call{Call: _e.mock.On("ResetTimeout")}
+}
+
+func (_c *Config_ResetTimeout_Call) Run(run func()) *Config_ResetTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_ResetTimeout_Call) Return(duration time.Duration) *Config_ResetTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Config_ResetTimeout_Call) RunAndReturn(run func() time.Duration) *Config_ResetTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ShutdownTimeout provides a mock function for the type Config
+func (_mock *Config) ShutdownTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShutdownTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// Config_ShutdownTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ShutdownTimeout'
+type Config_ShutdownTimeout_Call struct {
+	*mock.Call
+}
+
+// ShutdownTimeout is a helper method to define mock.On call
+func (_e *Config_Expecter) ShutdownTimeout() *Config_ShutdownTimeout_Call {
+	return &Config_ShutdownTimeout_Call{Call: _e.mock.On("ShutdownTimeout")}
+}
+
+func (_c *Config_ShutdownTimeout_Call) Run(run func()) *Config_ShutdownTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_ShutdownTimeout_Call) Return(duration time.Duration) *Config_ShutdownTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *Config_ShutdownTimeout_Call) RunAndReturn(run func() time.Duration) *Config_ShutdownTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TracingEndpoint provides a mock function for the type Config
+func (_mock *Config) TracingEndpoint() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TracingEndpoint")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Config_TracingEndpoint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TracingEndpoint'
+type Config_TracingEndpoint_Call struct {
+	*mock.Call
+}
+
+// TracingEndpoint is a helper method to define mock.On call
+func (_e *Config_Expecter) TracingEndpoint() *Config_TracingEndpoint_Call {
+	return &Config_TracingEndpoint_Call{Call: _e.mock.On("TracingEndpoint")}
+}
+
+func (_c *Config_TracingEndpoint_Call) Run(run func()) *Config_TracingEndpoint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_TracingEndpoint_Call) Return(s string) *Config_TracingEndpoint_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Config_TracingEndpoint_Call) RunAndReturn(run func() string) *Config_TracingEndpoint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TracingExporter provides a mock function for the type Config
+func (_mock *Config) TracingExporter() config.TracingExporter {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TracingExporter")
+	}
+
+	var r0 config.TracingExporter
+	if returnFunc, ok := ret.Get(0).(func() config.TracingExporter); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(config.TracingExporter)
+	}
+	return r0
+}
+
+// Config_TracingExporter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TracingExporter'
+type Config_TracingExporter_Call struct {
+	*mock.Call
+}
+
+// TracingExporter is a helper method to define mock.On call
+func (_e *Config_Expecter) TracingExporter() *Config_TracingExporter_Call {
+	return &Config_TracingExporter_Call{Call: _e.mock.On("TracingExporter")}
+}
+
+func (_c *Config_TracingExporter_Call) Run(run func()) *Config_TracingExporter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_TracingExporter_Call) Return(tracingExporter config.TracingExporter) *Config_TracingExporter_Call {
+	_c.Call.Return(tracingExporter)
+	return _c
+}
+
+func (_c *Config_TracingExporter_Call) RunAndReturn(run func() config.TracingExporter) *Config_TracingExporter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TracingFile provides a mock function for the type Config
+func (_mock *Config) TracingFile() string {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TracingFile")
+	}
+
+	var r0 string
+	if returnFunc, ok := ret.Get(0).(func() string); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	return r0
+}
+
+// Config_TracingFile_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TracingFile'
+type Config_TracingFile_Call struct {
+	*mock.Call
+}
+
+// TracingFile is a helper method to define mock.On call
+func (_e *Config_Expecter) TracingFile() *Config_TracingFile_Call {
+	return &Config_TracingFile_Call{Call: _e.mock.On("TracingFile")}
+}
+
+func (_c *Config_TracingFile_Call) Run(run func()) *Config_TracingFile_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_TracingFile_Call) Return(s string) *Config_TracingFile_Call {
+	_c.Call.Return(s)
+	return _c
+}
+
+func (_c *Config_TracingFile_Call) RunAndReturn(run func() string) *Config_TracingFile_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// TracingSampleRatio provides a mock function for the type Config
+func (_mock *Config) TracingSampleRatio() float64 {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for TracingSampleRatio")
+	}
+
+	var r0 float64
+	if returnFunc, ok := ret.Get(0).(func() float64); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+	return r0
+}
+
+// Config_TracingSampleRatio_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TracingSampleRatio'
+type Config_TracingSampleRatio_Call struct {
+	*mock.Call
+}
+
+// TracingSampleRatio is a helper method to define mock.On call
+func (_e *Config_Expecter) TracingSampleRatio() *Config_TracingSampleRatio_Call {
+	return &Config_TracingSampleRatio_Call{Call: _e.mock.On("TracingSampleRatio")}
+}
+
+func (_c *Config_TracingSampleRatio_Call) Run(run func()) *Config_TracingSampleRatio_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *Config_TracingSampleRatio_Call) Return(f float64) *Config_TracingSampleRatio_Call {
+	_c.Call.Return(f)
+	return _c
+}
+
+func (_c *Config_TracingSampleRatio_Call) RunAndReturn(run func() float64) *Config_TracingSampleRatio_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UI provides a mock function for the type Config
 func (_mock *Config) UI() bool {
 	ret := _mock.Called()
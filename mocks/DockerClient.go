@@ -190,6 +190,84 @@ func (_c *DockerClient_ContainerCreate_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// ContainerExec provides a mock function for the type DockerClient
+func (_mock *DockerClient) ContainerExec(ctx context.Context, containerID string, cmd []string) (int, string, error) {
+	ret := _mock.Called(ctx, containerID, cmd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ContainerExec")
+	}
+
+	var r0 int
+	var r1 string
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) (int, string, error)); ok {
+		return returnFunc(ctx, containerID, cmd)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []string) int); ok {
+		r0 = returnFunc(ctx, containerID, cmd)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []string) string); ok {
+		r1 = returnFunc(ctx, containerID, cmd)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, []string) error); ok {
+		r2 = returnFunc(ctx, containerID, cmd)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// DockerClient_ContainerExec_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ContainerExec'
+type DockerClient_ContainerExec_Call struct {
+	*mock.Call
+}
+
+// ContainerExec is a helper method to define mock.On call
+//   - ctx context.Context
+//   - containerID string
+//   - cmd []string
+func (_e *DockerClient_Expecter) ContainerExec(ctx interface{}, containerID interface{}, cmd interface{}) *DockerClient_ContainerExec_Call {
+	return &DockerClient_ContainerExec_Call{Call: _e.mock.On("ContainerExec", ctx, containerID, cmd)}
+}
+
+func (_c *DockerClient_ContainerExec_Call) Run(run func(ctx context.Context, containerID string, cmd []string)) *DockerClient_ContainerExec_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 []string
+		if args[2] != nil {
+			arg2 = args[2].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *DockerClient_ContainerExec_Call) Return(n int, s string, err error) *DockerClient_ContainerExec_Call {
+	_c.Call.Return(n, s, err)
+	return _c
+}
+
+func (_c *DockerClient_ContainerExec_Call) RunAndReturn(run func(ctx context.Context, containerID string, cmd []string) (int, string, error)) *DockerClient_ContainerExec_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ContainerInspect provides a mock function for the type DockerClient
 func (_mock *DockerClient) ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error) {
 	ret := _mock.Called(ctx, containerID)
@@ -604,3 +682,54 @@ func (_c *DockerClient_ImagePull_Call) RunAndReturn(run func(ctx context.Context
 	_c.Call.Return(run)
 	return _c
 }
+
+// Ping provides a mock function for the type DockerClient
+func (_mock *DockerClient) Ping(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// DockerClient_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type DockerClient_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *DockerClient_Expecter) Ping(ctx interface{}) *DockerClient_Ping_Call {
+	return &DockerClient_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *DockerClient_Ping_Call) Run(run func(ctx context.Context)) *DockerClient_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *DockerClient_Ping_Call) Return(err error) *DockerClient_Ping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *DockerClient_Ping_Call) RunAndReturn(run func(ctx context.Context) error) *DockerClient_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -5,6 +5,7 @@
 package mocks
 
 import (
+	"github.com/selebrow/selebrow/pkg/event"
 	"github.com/selebrow/selebrow/pkg/event/models"
 	mock "github.com/stretchr/testify/mock"
 )
@@ -137,3 +138,111 @@ func (_c *EventBroker_Subscribe_Call) RunAndReturn(run func(eventTypes ...string
 	_c.Call.Return(run)
 	return _c
 }
+
+// SubscribeWithOptions provides a mock function for the type EventBroker
+func (_mock *EventBroker) SubscribeWithOptions(opts event.SubscriptionOptions, eventTypes ...string) <-chan models.IEvent {
+	var tmpRet mock.Arguments
+	if len(eventTypes) > 0 {
+		tmpRet = _mock.Called(opts, eventTypes)
+	} else {
+		tmpRet = _mock.Called(opts)
+	}
+	ret := tmpRet
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeWithOptions")
+	}
+
+	var r0 <-chan models.IEvent
+	if returnFunc, ok := ret.Get(0).(func(event.SubscriptionOptions, ...string) <-chan models.IEvent); ok {
+		r0 = returnFunc(opts, eventTypes...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan models.IEvent)
+		}
+	}
+	return r0
+}
+
+// EventBroker_SubscribeWithOptions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubscribeWithOptions'
+type EventBroker_SubscribeWithOptions_Call struct {
+	*mock.Call
+}
+
+// SubscribeWithOptions is a helper method to define mock.On call
+//   - opts event.SubscriptionOptions
+//   - eventTypes ...string
+func (_e *EventBroker_Expecter) SubscribeWithOptions(opts interface{}, eventTypes ...interface{}) *EventBroker_SubscribeWithOptions_Call {
+	return &EventBroker_SubscribeWithOptions_Call{Call: _e.mock.On("SubscribeWithOptions",
+		append([]interface{}{opts}, eventTypes...)...)}
+}
+
+func (_c *EventBroker_SubscribeWithOptions_Call) Run(run func(opts event.SubscriptionOptions, eventTypes ...string)) *EventBroker_SubscribeWithOptions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 event.SubscriptionOptions
+		if args[0] != nil {
+			arg0 = args[0].(event.SubscriptionOptions)
+		}
+		var arg1 []string
+		var variadicArgs []string
+		if len(args) > 1 {
+			variadicArgs = args[1].([]string)
+		}
+		arg1 = variadicArgs
+		run(
+			arg0,
+			arg1...,
+		)
+	})
+	return _c
+}
+
+func (_c *EventBroker_SubscribeWithOptions_Call) Return(iEventCh <-chan models.IEvent) *EventBroker_SubscribeWithOptions_Call {
+	_c.Call.Return(iEventCh)
+	return _c
+}
+
+func (_c *EventBroker_SubscribeWithOptions_Call) RunAndReturn(run func(opts event.SubscriptionOptions, eventTypes ...string) <-chan models.IEvent) *EventBroker_SubscribeWithOptions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Unsubscribe provides a mock function for the type EventBroker
+func (_mock *EventBroker) Unsubscribe(ch <-chan models.IEvent) {
+	_mock.Called(ch)
+	return
+}
+
+// EventBroker_Unsubscribe_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Unsubscribe'
+type EventBroker_Unsubscribe_Call struct {
+	*mock.Call
+}
+
+// Unsubscribe is a helper method to define mock.On call
+//   - ch <-chan models.IEvent
+func (_e *EventBroker_Expecter) Unsubscribe(ch interface{}) *EventBroker_Unsubscribe_Call {
+	return &EventBroker_Unsubscribe_Call{Call: _e.mock.On("Unsubscribe", ch)}
+}
+
+func (_c *EventBroker_Unsubscribe_Call) Run(run func(ch <-chan models.IEvent)) *EventBroker_Unsubscribe_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 <-chan models.IEvent
+		if args[0] != nil {
+			arg0 = args[0].(<-chan models.IEvent)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *EventBroker_Unsubscribe_Call) Return() *EventBroker_Unsubscribe_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *EventBroker_Unsubscribe_Call) RunAndReturn(run func(ch <-chan models.IEvent)) *EventBroker_Unsubscribe_Call {
+	_c.Run(run)
+	return _c
+}
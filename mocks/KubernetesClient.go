@@ -209,6 +209,84 @@ func (_c *KubernetesClient_DeletePod_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// ExecPod provides a mock function for the type KubernetesClient
+func (_mock *KubernetesClient) ExecPod(ctx context.Context, podName string, container string, cmd []string) (string, error) {
+	ret := _mock.Called(ctx, podName, container, cmd)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExecPod")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string) (string, error)); ok {
+		return returnFunc(ctx, podName, container, cmd)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, []string) string); ok {
+		r0 = returnFunc(ctx, podName, container, cmd)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, []string) error); ok {
+		r1 = returnFunc(ctx, podName, container, cmd)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// KubernetesClient_ExecPod_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExecPod'
+type KubernetesClient_ExecPod_Call struct {
+	*mock.Call
+}
+
+// ExecPod is a helper method to define mock.On call
+//   - ctx context.Context
+//   - podName string
+//   - container string
+//   - cmd []string
+func (_e *KubernetesClient_Expecter) ExecPod(ctx interface{}, podName interface{}, container interface{}, cmd interface{}) *KubernetesClient_ExecPod_Call {
+	return &KubernetesClient_ExecPod_Call{Call: _e.mock.On("ExecPod", ctx, podName, container, cmd)}
+}
+
+func (_c *KubernetesClient_ExecPod_Call) Run(run func(ctx context.Context, podName string, container string, cmd []string)) *KubernetesClient_ExecPod_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 []string
+		if args[3] != nil {
+			arg3 = args[3].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *KubernetesClient_ExecPod_Call) Return(s string, err error) *KubernetesClient_ExecPod_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *KubernetesClient_ExecPod_Call) RunAndReturn(run func(ctx context.Context, podName string, container string, cmd []string) (string, error)) *KubernetesClient_ExecPod_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ListPods provides a mock function for the type KubernetesClient
 func (_mock *KubernetesClient) ListPods(ctx context.Context, selector *v10.LabelSelector) (*v1.PodList, error) {
 	ret := _mock.Called(ctx, selector)
@@ -277,6 +355,57 @@ func (_c *KubernetesClient_ListPods_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// Ping provides a mock function for the type KubernetesClient
+func (_mock *KubernetesClient) Ping(ctx context.Context) error {
+	ret := _mock.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Ping")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = returnFunc(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// KubernetesClient_Ping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Ping'
+type KubernetesClient_Ping_Call struct {
+	*mock.Call
+}
+
+// Ping is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *KubernetesClient_Expecter) Ping(ctx interface{}) *KubernetesClient_Ping_Call {
+	return &KubernetesClient_Ping_Call{Call: _e.mock.On("Ping", ctx)}
+}
+
+func (_c *KubernetesClient_Ping_Call) Run(run func(ctx context.Context)) *KubernetesClient_Ping_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *KubernetesClient_Ping_Call) Return(err error) *KubernetesClient_Ping_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *KubernetesClient_Ping_Call) RunAndReturn(run func(ctx context.Context) error) *KubernetesClient_Ping_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PortForwardPod provides a mock function for the type KubernetesClient
 func (_mock *KubernetesClient) PortForwardPod(podName string, podPort int64, localport int64, stopCh chan struct{}) error {
 	ret := _mock.Called(podName, podPort, localport, stopCh)
@@ -37,6 +37,50 @@ func (_m *PoolConfig) EXPECT() *PoolConfig_Expecter {
 	return &PoolConfig_Expecter{mock: &_m.Mock}
 }
 
+// HealthCheckTimeout provides a mock function for the type PoolConfig
+func (_mock *PoolConfig) HealthCheckTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for HealthCheckTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// PoolConfig_HealthCheckTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'HealthCheckTimeout'
+type PoolConfig_HealthCheckTimeout_Call struct {
+	*mock.Call
+}
+
+// HealthCheckTimeout is a helper method to define mock.On call
+func (_e *PoolConfig_Expecter) HealthCheckTimeout() *PoolConfig_HealthCheckTimeout_Call {
+	return &PoolConfig_HealthCheckTimeout_Call{Call: _e.mock.On("HealthCheckTimeout")}
+}
+
+func (_c *PoolConfig_HealthCheckTimeout_Call) Run(run func()) *PoolConfig_HealthCheckTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *PoolConfig_HealthCheckTimeout_Call) Return(duration time.Duration) *PoolConfig_HealthCheckTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *PoolConfig_HealthCheckTimeout_Call) RunAndReturn(run func() time.Duration) *PoolConfig_HealthCheckTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // IdleTimeout provides a mock function for the type PoolConfig
 func (_mock *PoolConfig) IdleTimeout() time.Duration {
 	ret := _mock.Called()
@@ -168,3 +212,47 @@ func (_c *PoolConfig_MaxIdle_Call) RunAndReturn(run func() int) *PoolConfig_MaxI
 	_c.Call.Return(run)
 	return _c
 }
+
+// ResetTimeout provides a mock function for the type PoolConfig
+func (_mock *PoolConfig) ResetTimeout() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResetTimeout")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// PoolConfig_ResetTimeout_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResetTimeout'
+type PoolConfig_ResetTimeout_Call struct {
+	*mock.Call
+}
+
+// ResetTimeout is a helper method to define mock.On call
+func (_e *PoolConfig_Expecter) ResetTimeout() *PoolConfig_ResetTimeout_Call {
+	return &PoolConfig_ResetTimeout_Call{Call: _e.mock.On("ResetTimeout")}
+}
+
+func (_c *PoolConfig_ResetTimeout_Call) Run(run func()) *PoolConfig_ResetTimeout_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *PoolConfig_ResetTimeout_Call) Return(duration time.Duration) *PoolConfig_ResetTimeout_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *PoolConfig_ResetTimeout_Call) RunAndReturn(run func() time.Duration) *PoolConfig_ResetTimeout_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -37,6 +37,50 @@ func (_m *WDSessionConfig) EXPECT() *WDSessionConfig_Expecter {
 	return &WDSessionConfig_Expecter{mock: &_m.Mock}
 }
 
+// ArchiveDownloads provides a mock function for the type WDSessionConfig
+func (_mock *WDSessionConfig) ArchiveDownloads() bool {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveDownloads")
+	}
+
+	var r0 bool
+	if returnFunc, ok := ret.Get(0).(func() bool); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	return r0
+}
+
+// WDSessionConfig_ArchiveDownloads_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ArchiveDownloads'
+type WDSessionConfig_ArchiveDownloads_Call struct {
+	*mock.Call
+}
+
+// ArchiveDownloads is a helper method to define mock.On call
+func (_e *WDSessionConfig_Expecter) ArchiveDownloads() *WDSessionConfig_ArchiveDownloads_Call {
+	return &WDSessionConfig_ArchiveDownloads_Call{Call: _e.mock.On("ArchiveDownloads")}
+}
+
+func (_c *WDSessionConfig_ArchiveDownloads_Call) Run(run func()) *WDSessionConfig_ArchiveDownloads_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *WDSessionConfig_ArchiveDownloads_Call) Return(b bool) *WDSessionConfig_ArchiveDownloads_Call {
+	_c.Call.Return(b)
+	return _c
+}
+
+func (_c *WDSessionConfig_ArchiveDownloads_Call) RunAndReturn(run func() bool) *WDSessionConfig_ArchiveDownloads_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTimeout provides a mock function for the type WDSessionConfig
 func (_mock *WDSessionConfig) CreateTimeout() time.Duration {
 	ret := _mock.Called()
@@ -125,6 +169,50 @@ func (_c *WDSessionConfig_DefaultSessionTimeout_Call) RunAndReturn(run func() ti
 	return _c
 }
 
+// MaxSessionLifetime provides a mock function for the type WDSessionConfig
+func (_mock *WDSessionConfig) MaxSessionLifetime() time.Duration {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for MaxSessionLifetime")
+	}
+
+	var r0 time.Duration
+	if returnFunc, ok := ret.Get(0).(func() time.Duration); ok {
+		r0 = returnFunc()
+	} else {
+		r0 = ret.Get(0).(time.Duration)
+	}
+	return r0
+}
+
+// WDSessionConfig_MaxSessionLifetime_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MaxSessionLifetime'
+type WDSessionConfig_MaxSessionLifetime_Call struct {
+	*mock.Call
+}
+
+// MaxSessionLifetime is a helper method to define mock.On call
+func (_e *WDSessionConfig_Expecter) MaxSessionLifetime() *WDSessionConfig_MaxSessionLifetime_Call {
+	return &WDSessionConfig_MaxSessionLifetime_Call{Call: _e.mock.On("MaxSessionLifetime")}
+}
+
+func (_c *WDSessionConfig_MaxSessionLifetime_Call) Run(run func()) *WDSessionConfig_MaxSessionLifetime_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *WDSessionConfig_MaxSessionLifetime_Call) Return(duration time.Duration) *WDSessionConfig_MaxSessionLifetime_Call {
+	_c.Call.Return(duration)
+	return _c
+}
+
+func (_c *WDSessionConfig_MaxSessionLifetime_Call) RunAndReturn(run func() time.Duration) *WDSessionConfig_MaxSessionLifetime_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // MaxSessionTimeout provides a mock function for the type WDSessionConfig
 func (_mock *WDSessionConfig) MaxSessionTimeout() time.Duration {
 	ret := _mock.Called()
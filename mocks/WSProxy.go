@@ -37,16 +37,16 @@ func (_m *WSProxy) EXPECT() *WSProxy_Expecter {
 }
 
 // Handler provides a mock function for the type WSProxy
-func (_mock *WSProxy) Handler(hostport string) websocket.Handler {
-	ret := _mock.Called(hostport)
+func (_mock *WSProxy) Handler(hostport string, touch func()) websocket.Handler {
+	ret := _mock.Called(hostport, touch)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Handler")
 	}
 
 	var r0 websocket.Handler
-	if returnFunc, ok := ret.Get(0).(func(string) websocket.Handler); ok {
-		r0 = returnFunc(hostport)
+	if returnFunc, ok := ret.Get(0).(func(string, func()) websocket.Handler); ok {
+		r0 = returnFunc(hostport, touch)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(websocket.Handler)
@@ -62,18 +62,24 @@ type WSProxy_Handler_Call struct {
 
 // Handler is a helper method to define mock.On call
 //   - hostport string
-func (_e *WSProxy_Expecter) Handler(hostport interface{}) *WSProxy_Handler_Call {
-	return &WSProxy_Handler_Call{Call: _e.mock.On("Handler", hostport)}
+//   - touch func()
+func (_e *WSProxy_Expecter) Handler(hostport interface{}, touch interface{}) *WSProxy_Handler_Call {
+	return &WSProxy_Handler_Call{Call: _e.mock.On("Handler", hostport, touch)}
 }
 
-func (_c *WSProxy_Handler_Call) Run(run func(hostport string)) *WSProxy_Handler_Call {
+func (_c *WSProxy_Handler_Call) Run(run func(hostport string, touch func())) *WSProxy_Handler_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 string
 		if args[0] != nil {
 			arg0 = args[0].(string)
 		}
+		var arg1 func()
+		if args[1] != nil {
+			arg1 = args[1].(func())
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
@@ -84,7 +90,7 @@ func (_c *WSProxy_Handler_Call) Return(handler websocket.Handler) *WSProxy_Handl
 	return _c
 }
 
-func (_c *WSProxy_Handler_Call) RunAndReturn(run func(hostport string) websocket.Handler) *WSProxy_Handler_Call {
+func (_c *WSProxy_Handler_Call) RunAndReturn(run func(hostport string, touch func()) websocket.Handler) *WSProxy_Handler_Call {
 	_c.Call.Return(run)
 	return _c
 }
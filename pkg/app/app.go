@@ -13,7 +13,10 @@ import (
 	"go.uber.org/zap"
 	stdProxy "golang.org/x/net/proxy"
 
+	"github.com/selebrow/selebrow/internal/browser/pool"
+	hc "github.com/selebrow/selebrow/internal/common/client"
 	"github.com/selebrow/selebrow/internal/proxy"
+	"github.com/selebrow/selebrow/internal/services/health"
 	"github.com/selebrow/selebrow/pkg/browser"
 	"github.com/selebrow/selebrow/pkg/browsers"
 	"github.com/selebrow/selebrow/pkg/config"
@@ -25,21 +28,29 @@ import (
 )
 
 var (
-	InitLogger                func() *zap.Logger                                                                  = InitLoggerFunc
-	InitConfig                func() config.Config                                                                = InitConfigFunc
-	InitDialer                func(config.Config) *net.Dialer                                                     = InitDialerFunc
-	InitTransport             func(config.Config, *net.Dialer) *http.Transport                                    = InitTransportFunc
-	InitHTTPClient            func(config.Config, http.RoundTripper) *http.Client                                 = InitHTTPClientFunc
-	InitBrowsersCatalog       func(config.Config, []byte) browsers.BrowsersCatalog                                = InitBrowsersCatalogFunc
-	InitSignalHandler         func(config.Config) *signal.Handler                                                 = InitSignalHandlerFunc
-	InitKubeClient            func(config.Config) kubeapi.KubernetesClient                                        = InitKubeClientFunc
-	InitDockerClient          func(config.Config) dockerclient.DockerClient                                       = InitDockerClientFunc
-	InitPoolManager           func(config.Config, browser.BrowserManager, *signal.Handler) browser.BrowserManager = InitPoolManagerFunc
-	InitEventBroker           func(config.Config, *signal.Handler) event.EventBroker                              = InitEventBrokerFunc
-	InitMiddleware            func(config.Config, *echo.Echo, *zap.Logger)                                        = InitMiddlewareFunc
+	InitLogger          func() *zap.Logger                                     = InitLoggerFunc
+	InitConfig          func() config.Config                                   = InitConfigFunc
+	InitDialer          func(config.Config) *net.Dialer                        = InitDialerFunc
+	InitTransport       func(config.Config, *net.Dialer) *http.Transport       = InitTransportFunc
+	InitHTTPClient      func(config.Config, http.RoundTripper) *http.Client    = InitHTTPClientFunc
+	InitBrowsersCatalog func(config.Config, []byte) browsers.BrowsersCatalog   = InitBrowsersCatalogFunc
+	InitSignalHandler   func(config.Config) *signal.Handler                    = InitSignalHandlerFunc
+	InitKubeClient      func(config.Config) kubeapi.KubernetesClient           = InitKubeClientFunc
+	InitDockerClient    func(config.Config) dockerclient.DockerClient          = InitDockerClientFunc
+	InitEventBroker     func(config.Config, *signal.Handler) event.EventBroker = InitEventBrokerFunc
+	InitMiddleware      func(config.Config, *echo.Echo, *zap.Logger)           = InitMiddlewareFunc
+	InitTracing         func(config.Config, string, string, *signal.Handler)   = InitTracingFunc
+	InitPoolManager     func(
+		config.Config,
+		browser.BrowserManager,
+		hc.HTTPClient,
+		*net.Dialer,
+		*signal.Handler,
+	) (browser.BrowserManager, pool.PoolAdmin) = InitPoolManagerFunc
 	InitDockerQuotaAuthorizer func(
 		config.Config,
 		dockerclient.DockerClient,
+		event.EventBroker,
 	) quota.QuotaAuthorizer = InitDockerQuotaAuthorizerFunc
 	InitLimitedBrowserManager func(
 		config.Config,
@@ -49,6 +60,7 @@ var (
 	InitKubernetesQuotaAuthorizer func(
 		config.Config,
 		kubeapi.KubernetesClient,
+		event.EventBroker,
 		*signal.Handler,
 	) quota.QuotaAuthorizer = InitKubernetesQuotaAuthorizerFunc
 	InitAPI func(
@@ -62,6 +74,14 @@ var (
 		InfoController,
 		WDStatusController,
 		PWController,
+		PuppeteerController,
+		PoolController,
+		SessionsController,
+		DrainController,
+		HealthController,
+		CommandsController,
+		ArtifactsController,
+		DownloadsController,
 	) = InitAPIFunc
 
 	InitEventAdapter func(
@@ -69,7 +89,7 @@ var (
 		event.EventBroker,
 		config.BackendType,
 		*signal.Handler,
-	) = func(_ config.Config, _ event.EventBroker, _ config.BackendType, _ *signal.Handler) {}
+	) = InitEventAdapterFunc
 
 	InitProxy          func(cfg config.Config) *proxy.Proxy                     = InitProxyFunc
 	InitProxyHandler   func(cfg config.Config, logger *zap.Logger) http.Handler = InitProxyHandlerFunc
@@ -88,6 +108,8 @@ func Run(gitRef, gitSha, appName string) {
 
 	cfg := InitConfig()
 	sig := InitSignalHandler(cfg)
+	hs := initHealthService()
+	InitTracing(cfg, appName, appVersion, sig)
 
 	dialer := InitDialer(cfg)
 	transport := InitTransport(cfg, dialer)
@@ -97,35 +119,50 @@ func Run(gitRef, gitSha, appName string) {
 	catalog := InitBrowsersCatalog(cfg, browsersConfig)
 
 	backend := detectBackend(cfg)
-	qa, mgr, proxyOpts := initBackend(cfg, backend, catalog, sig)
+	eb := InitEventBroker(cfg, sig)
+	InitEventAdapter(cfg, eb, backend, sig)
 
-	mgr = InitPoolManager(cfg, mgr, sig)
-	mgr = InitLimitedBrowserManager(cfg, mgr, qa)
+	qa, mgr, proxyOpts := initBackend(cfg, backend, catalog, eb, sig, hs)
 
-	sStorage := initSessionStorage(sig)
+	mgr, poolAdmin := InitPoolManager(cfg, mgr, client, dialer, sig)
+	mgr = InitLimitedBrowserManager(cfg, mgr, qa)
+	mgr = initEventedBrowserManager(mgr, eb)
 
-	eb := InitEventBroker(cfg, sig)
-	InitEventAdapter(cfg, eb, backend, sig)
+	sStorage := initSessionStorage(eb, sig)
+	drainSvc := initDrainService(sStorage, sig)
+	initHealthChecks(hs, catalog, sStorage, drainSvc)
 
-	wdSvc := initWDSessionService(cfg, mgr, sStorage, client, sig)
-	pwSvc := initPWSessionService(cfg, dialer, backend, mgr, sStorage)
+	resetter := initBrowserResetter(cfg, catalog, client)
+	artifactStore := initArtifactStore(cfg, sig)
+	wdSvc := initWDSessionService(cfg, mgr, sStorage, eb, resetter, artifactStore, client, sig)
+	pwSvc := initPWSessionService(cfg, dialer, backend, mgr, sStorage, eb, resetter, sig)
+	ppSvc := initPuppeteerSessionService(cfg, dialer, backend, mgr, sStorage, eb, resetter, sig)
+	cmdLog := initCommandLog(cfg, eb, sig)
 
 	cLog := l.Named("controller")
 	wsproxy := initWSProxy()
 
 	configController := initConfigController(browsersConfig)
-	sessionController := initWDSessionController(wdSvc, eb, proxyOpts, cLog)
-	proxyController := initProxyController(transport, wsproxy, cLog)
+	sessionController := initWDSessionController(wdSvc, catalog, eb, proxyOpts, cLog)
+	proxyController := initProxyController(cfg, transport, wsproxy, wdSvc, cmdLog, eb, cLog)
 	catalogController := initBrowsersCatalogController(catalog)
-	wdStatusController := initWDStatusController()
+	wdStatusController := initWDStatusController(drainSvc)
 	quotaController := initQuotaController(qa)
 	infoController := initInfoController(appName, gitRef, gitSha)
-	playwrightController := initPlayWrightController(pwSvc, transport, eb, proxyOpts, cLog)
+	playwrightController := initPlayWrightController(pwSvc, catalog, transport, eb, proxyOpts, cLog)
+	puppeteerController := initPuppeteerController(ppSvc, transport, eb, proxyOpts, cLog)
+	poolController := initPoolController(poolAdmin)
+	sessionsController := initSessionsController(backend, wdSvc, pwSvc, ppSvc)
+	drainController := initDrainController(drainSvc)
+	healthController := initHealthController(hs)
+	commandsController := initCommandsController(wdSvc, cmdLog)
+	artifactsController := initArtifactsController(artifactStore)
+	downloadsController := initDownloadsController(client)
 
 	srvLog := l.Named("server")
 	e := initEcho(cfg, srvLog)
 	// Routes
-	initUI(cfg, e, qa, wdSvc, pwSvc)
+	initUI(cfg, e, catalog, qa, eb, cmdLog, artifactStore, backend, wdSvc, pwSvc, ppSvc, sessionController, drainController)
 	InitAPI(
 		cfg,
 		e,
@@ -137,6 +174,14 @@ func Run(gitRef, gitSha, appName string) {
 		infoController,
 		wdStatusController,
 		playwrightController,
+		puppeteerController,
+		poolController,
+		sessionsController,
+		drainController,
+		healthController,
+		commandsController,
+		artifactsController,
+		downloadsController,
 	)
 
 	// Start proxy if enabled
@@ -164,7 +209,9 @@ func initBackend(
 	cfg config.Config,
 	backend config.BackendType,
 	catalog browsers.BrowsersCatalog,
+	eb event.EventBroker,
 	sig *signal.Handler,
+	hs *health.HealthServiceImpl,
 ) (quota.QuotaAuthorizer, browser.BrowserManager, *config.ProxyOpts) {
 	var (
 		qa          quota.QuotaAuthorizer
@@ -176,16 +223,18 @@ func initBackend(
 
 	if backend == config.BackendKubernetes {
 		client := InitKubeClient(cfg)
-		qa = InitKubernetesQuotaAuthorizer(cfg, client, sig)
+		hs.AddLivenessCheckThreshold("kubernetes", client.Ping, backendLivenessThreshold)
+		qa = InitKubernetesQuotaAuthorizer(cfg, client, eb, sig)
 		templatesData := readKubeTemplates(cfg)
-		mgr = initKubernetesWebDriverManager(cfg, client, templatesData, catalog, sig)
+		mgr = initKubernetesWebDriverManager(cfg, client, templatesData, catalog, sig, hs)
 		// proxy host expected to be set externally via Helm
 		proxyHostFn = func() string {
 			return ""
 		}
 	} else {
 		client := InitDockerClient(cfg)
-		qa = InitDockerQuotaAuthorizer(cfg, client)
+		hs.AddLivenessCheckThreshold("docker", client.Ping, backendLivenessThreshold)
+		qa = InitDockerQuotaAuthorizer(cfg, client, eb)
 		mgr, proxyHostFn = initDockerWebDriverManager(cfg, client, catalog)
 	}
 	proxyOpts := initProxyOpts(cfg, proxyHostFn)
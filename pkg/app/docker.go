@@ -7,6 +7,7 @@ import (
 	"github.com/selebrow/selebrow/pkg/browsers"
 	"github.com/selebrow/selebrow/pkg/config"
 	dockerclient "github.com/selebrow/selebrow/pkg/docker"
+	"github.com/selebrow/selebrow/pkg/event"
 	"github.com/selebrow/selebrow/pkg/log"
 	"github.com/selebrow/selebrow/pkg/quota"
 
@@ -39,7 +40,7 @@ func InitDockerClientFunc(cfg config.Config) dockerclient.DockerClient {
 	return dockerclient.NewDockerClientImpl(dockerCli, cfg.DockerPlatform(), dockerConfig)
 }
 
-func InitDockerQuotaAuthorizerFunc(cfg config.Config, client dockerclient.DockerClient) quota.QuotaAuthorizer {
+func InitDockerQuotaAuthorizerFunc(cfg config.Config, client dockerclient.DockerClient, eb event.EventBroker) quota.QuotaAuthorizer {
 	var (
 		cpu int
 		mem int64
@@ -53,7 +54,7 @@ func InitDockerQuotaAuthorizerFunc(cfg config.Config, client dockerclient.Docker
 		}
 	}
 
-	return initLimitQuotaAuthorizer(cfg, cpu, mem)
+	return initLimitQuotaAuthorizer(cfg, cpu, mem, eb)
 }
 
 func initDockerWebDriverManager(
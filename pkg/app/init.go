@@ -9,12 +9,18 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/selebrow/selebrow/internal/browser/evented"
 	"github.com/selebrow/selebrow/internal/browser/limited"
 	"github.com/selebrow/selebrow/internal/browser/pool"
 	hc "github.com/selebrow/selebrow/internal/common/client"
 	"github.com/selebrow/selebrow/internal/common/conn"
 	"github.com/selebrow/selebrow/internal/common/ws"
+	"github.com/selebrow/selebrow/internal/services/artifacts"
+	"github.com/selebrow/selebrow/internal/services/cmdlog"
+	"github.com/selebrow/selebrow/internal/services/drain"
+	"github.com/selebrow/selebrow/internal/services/health"
 	"github.com/selebrow/selebrow/internal/services/pw"
+	"github.com/selebrow/selebrow/internal/services/reset"
 	"github.com/selebrow/selebrow/internal/services/session"
 	"github.com/selebrow/selebrow/internal/services/wdsession"
 	"github.com/selebrow/selebrow/pkg/browser"
@@ -23,16 +29,20 @@ import (
 	"github.com/selebrow/selebrow/pkg/config"
 	dockerclient "github.com/selebrow/selebrow/pkg/docker"
 	"github.com/selebrow/selebrow/pkg/event"
+	"github.com/selebrow/selebrow/pkg/event/sink"
 	"github.com/selebrow/selebrow/pkg/kubeapi"
 	"github.com/selebrow/selebrow/pkg/log"
+	"github.com/selebrow/selebrow/pkg/models"
 	"github.com/selebrow/selebrow/pkg/quota"
 	"github.com/selebrow/selebrow/pkg/quota/limit"
 	"github.com/selebrow/selebrow/pkg/signal"
+	"github.com/selebrow/selebrow/pkg/tracing"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 const (
@@ -40,7 +50,13 @@ const (
 	podTemplateFile = "pod-template.yaml"
 	valuesFile      = "values.yaml"
 
-	sessionCleanupInterval = 10 * time.Second
+	sessionCleanupInterval   = 10 * time.Second
+	artifactsCleanupInterval = time.Minute
+	drainCheckInterval       = time.Second
+	webhookRetryInterval     = 500 * time.Millisecond
+	healthCheckTimeout       = 2 * time.Second
+	// backend API is considered wedged and instance is restarted after this many consecutive ping failures
+	backendLivenessThreshold = 3
 )
 
 var (
@@ -97,9 +113,9 @@ func InitHTTPClientFunc(_ config.Config, transport http.RoundTripper) *http.Clie
 	}
 }
 
-func InitSignalHandlerFunc(_ config.Config) *signal.Handler {
+func InitSignalHandlerFunc(cfg config.Config) *signal.Handler {
 	l := log.GetLogger().Named("signal")
-	return signal.NewHandler(5*time.Second, l)
+	return signal.NewHandler(cfg.ShutdownTimeout(), cfg.DrainTimeout(), l)
 }
 
 func loadBrowsersConfig(cfg config.Config, httpClient hc.HTTPClient) []byte {
@@ -177,7 +193,7 @@ const (
 	cpuPerBrowser    = 1
 )
 
-func initLimitQuotaAuthorizer(cfg config.Config, cpus int, memory int64) *limit.LimitQuotaAuthorizer {
+func initLimitQuotaAuthorizer(cfg config.Config, cpus int, memory int64, eb event.EventBroker) *limit.LimitQuotaAuthorizer {
 	lim := cfg.QuotaLimit()
 	if lim < 0 {
 		return nil
@@ -195,19 +211,29 @@ func initLimitQuotaAuthorizer(cfg config.Config, cpus int, memory int64) *limit.
 	}
 
 	l := log.GetLogger().Named("quota")
-	return limit.NewLimitQuotaAuthorizer(lim, cfg.QueueSize(), l)
+	return limit.NewLimitQuotaAuthorizer(lim, cfg.QueueSize(), eb, l)
 }
 
-func InitPoolManagerFunc(cfg config.Config, mgr browser.BrowserManager, sig *signal.Handler) browser.BrowserManager {
+func InitPoolManagerFunc(
+	cfg config.Config,
+	mgr browser.BrowserManager,
+	httpClient hc.HTTPClient,
+	dialer *net.Dialer,
+	sig *signal.Handler,
+) (browser.BrowserManager, pool.PoolAdmin) {
 	if cfg.MaxIdle() > 0 {
 		l := log.GetLogger().Named("pool")
-		f := pool.NewIdleBrowserPoolFactory(cfg, mgr, l)
+		var checker pool.HealthChecker
+		if timeout := cfg.HealthCheckTimeout(); timeout > 0 {
+			checker = pool.NewProbeHealthChecker(httpClient, dialer, timeout)
+		}
+		f := pool.NewIdleBrowserPoolFactory(cfg, mgr, checker, l)
 		pm := pool.NewBrowserPoolManager(f, capabilities.GetHash)
 		sig.RegisterShutdownHook(mgr, pm.Shutdown)
-		return pm
+		return pm, pm
 	}
 
-	return mgr
+	return mgr, nil
 }
 
 func InitLimitedBrowserManagerFunc(cfg config.Config, mgr browser.BrowserManager, qa quota.QuotaAuthorizer) browser.BrowserManager {
@@ -218,14 +244,81 @@ func InitLimitedBrowserManagerFunc(cfg config.Config, mgr browser.BrowserManager
 	return limited.NewLimitedBrowserManager(mgr, qa, cfg.QueueTimeout(), l)
 }
 
-func initSessionStorage(sig *signal.Handler) session.SessionStorage {
+func initEventedBrowserManager(mgr browser.BrowserManager, eb event.EventBroker) browser.BrowserManager {
+	return evented.NewEventedBrowserManager(mgr, eb, time.Now)
+}
+
+func initBrowserResetter(cfg config.Config, cat browsers.BrowsersCatalog, httpClient hc.HTTPClient) reset.BrowserResetter {
+	// browsers are never returned to the pool when it's disabled, so there is nothing to reset
+	if cfg.MaxIdle() == 0 {
+		return nil
+	}
+	return reset.NewCatalogBrowserResetter(cat, httpClient, cfg.ResetTimeout())
+}
+
+func initSessionStorage(eb event.EventBroker, sig *signal.Handler) session.SessionStorage {
 	l := log.GetLogger().Named("session")
 
-	s := session.NewLocalSessionStorage(l)
+	s := session.NewLocalSessionStorage(eb, l)
 	sig.RegisterShutdownHook(s, s.Shutdown)
 	return s
 }
 
+func initDrainService(storage session.SessionStorage, sig *signal.Handler) *drain.DrainServiceImpl {
+	l := log.GetLogger().Named("drain")
+
+	d := drain.NewDrainService(storage, drainCheckInterval, l)
+	sig.RegisterDrainHook(d.Shutdown)
+	return d
+}
+
+func InitTracingFunc(cfg config.Config, serviceName, serviceVersion string, sig *signal.Handler) {
+	tracing.SetGlobalPropagator()
+	p, err := tracing.NewProvider(context.Background(), cfg, serviceName, serviceVersion)
+	if err != nil {
+		InitLog.Fatalw("failed to initialize tracing", zap.Error(err))
+	}
+	if p == nil {
+		return
+	}
+	p.SetGlobal()
+	// hooks are run in reverse order, registering it early makes sure spans of in-flight requests are flushed
+	sig.RegisterShutdownHook(nil, p.Shutdown)
+	InitLog.Infof("tracing enabled using %s exporter", cfg.TracingExporter())
+}
+
+func initHealthService() *health.HealthServiceImpl {
+	return health.NewHealthService(healthCheckTimeout)
+}
+
+func initHealthChecks(
+	hs *health.HealthServiceImpl,
+	cat browsers.BrowsersCatalog,
+	storage session.SessionStorage,
+	drainSvc drain.DrainService,
+) {
+	hs.AddReadinessCheck("catalog", func(_ context.Context) error {
+		for _, p := range models.Protocols {
+			if len(cat.GetFlavors(p)) > 0 {
+				return nil
+			}
+		}
+		return errors.New("no browsers configured")
+	})
+	hs.AddReadinessCheck("sessionStorage", func(_ context.Context) error {
+		if storage.IsShutdown() {
+			return session.ErrStorageShutdown
+		}
+		return nil
+	})
+	hs.AddReadinessCheck("drain", func(_ context.Context) error {
+		if drainSvc.Draining() {
+			return drain.ErrDraining
+		}
+		return nil
+	})
+}
+
 func InitEventBrokerFunc(_ config.Config, sig *signal.Handler) event.EventBroker {
 	const defaultEventBufferSize = 100
 	l := log.GetLogger().Named("event")
@@ -234,15 +327,66 @@ func InitEventBrokerFunc(_ config.Config, sig *signal.Handler) event.EventBroker
 	return eb
 }
 
+// InitEventAdapterFunc starts export of session events to the configured sinks
+func InitEventAdapterFunc(cfg config.Config, eb event.EventBroker, backend config.BackendType, sig *signal.Handler) {
+	l := log.GetLogger().Named("event").Named("sink")
+
+	var sinks []sink.Sink
+	if fn := cfg.EventFile(); fn != "" {
+		s, err := sink.NewFileSink(fn)
+		if err != nil {
+			InitLog.Fatalw("failed to initialize events file sink", zap.Error(err))
+		}
+		sinks = append(sinks, s)
+	}
+	if cfg.EventStdout() {
+		sinks = append(sinks, sink.NewStdoutSink())
+	}
+	backoff := wait.Backoff{
+		Duration: webhookRetryInterval,
+		Factor:   2,
+		Jitter:   0.2,
+		Steps:    cfg.EventWebhookRetries() + 1,
+	}
+	for _, u := range cfg.EventWebhooks() {
+		// using Default client, webhooks are not supposed to be accessed via browsers proxy
+		s, err := sink.NewWebhookSink(u, http.DefaultClient, cfg.EventWebhookTimeout(), backoff)
+		if err != nil {
+			InitLog.Fatalw("failed to initialize events webhook sink", zap.Error(err))
+		}
+		sinks = append(sinks, s)
+	}
+
+	for _, s := range sinks {
+		a := sink.NewAdapter(eb, s, string(backend), l)
+		a.Start()
+		sig.RegisterShutdownHook(eb, a.Shutdown)
+	}
+}
+
 func initWDSessionService(
 	cfg config.Config,
 	mgr browser.BrowserManager,
 	storage session.SessionStorage,
+	eb event.EventBroker,
+	resetter reset.BrowserResetter,
+	artifactStore artifacts.ArtifactStore,
 	httpClient hc.HTTPClient,
 	sig *signal.Handler,
 ) *wdsession.WDSessionService {
 	l := log.GetLogger().Named("wdsession")
-	srv := wdsession.NewWDSessionServiceImpl(mgr, storage, httpClient, cfg, time.Now, sessionCleanupInterval, l)
+	srv := wdsession.NewWDSessionServiceImpl(
+		mgr,
+		storage,
+		eb,
+		resetter,
+		artifactStore,
+		httpClient,
+		cfg,
+		time.Now,
+		sessionCleanupInterval,
+		l,
+	)
 	sig.RegisterShutdownHook(srv, srv.Shutdown)
 	return srv
 }
@@ -253,11 +397,47 @@ func initPWSessionService(
 	backend config.BackendType,
 	mgr browser.BrowserManager,
 	storage session.SessionStorage,
+	eb event.EventBroker,
+	resetter reset.BrowserResetter,
+	sig *signal.Handler,
 ) *pw.PWSessionService {
 	l := log.GetLogger().Named("playwright")
 	// check connection only in docker port mapping mode
 	checkConn := backend == config.BackendDocker && portMappingEnabled(cfg)
-	s := pw.NewPWSessionService(mgr, storage, dialer, cfg.CreateTimeout(), checkConn, time.Now, l)
+	s := pw.NewPWSessionService(mgr, storage, eb, resetter, dialer, cfg, checkConn, time.Now, sessionCleanupInterval, l)
+	sig.RegisterShutdownHook(s, s.Shutdown)
+	return s
+}
+
+func initPuppeteerSessionService(
+	cfg config.Config,
+	dialer *net.Dialer,
+	backend config.BackendType,
+	mgr browser.BrowserManager,
+	storage session.SessionStorage,
+	eb event.EventBroker,
+	resetter reset.BrowserResetter,
+	sig *signal.Handler,
+) *pw.PWSessionService {
+	l := log.GetLogger().Named("puppeteer")
+	checkConn := backend == config.BackendDocker && portMappingEnabled(cfg)
+	s := pw.NewPuppeteerSessionService(mgr, storage, eb, resetter, dialer, cfg, checkConn, time.Now, sessionCleanupInterval, l)
+	sig.RegisterShutdownHook(s, s.Shutdown)
+	return s
+}
+
+func initArtifactStore(cfg config.Config, sig *signal.Handler) *artifacts.Store {
+	l := log.GetLogger().Named("artifacts")
+	s := artifacts.NewStore(cfg.ArtifactsDir(), cfg.ArtifactsRetention(), time.Now, artifactsCleanupInterval, l)
+	sig.RegisterShutdownHook(s, s.Shutdown)
+	return s
+}
+
+func initCommandLog(cfg config.Config, eb event.EventBroker, sig *signal.Handler) *cmdlog.Store {
+	s := cmdlog.NewStore(cfg.CommandLogDir(), cfg.CommandLog(), time.Now, log.GetLogger().Named("cmdlog"))
+	s.Start(eb)
+	// must be stopped before event broker
+	sig.RegisterShutdownHook(eb, s.Shutdown)
 	return s
 }
 
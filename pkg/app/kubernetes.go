@@ -6,8 +6,10 @@ import (
 	"time"
 
 	"github.com/selebrow/selebrow/internal/browser/kubernetes"
+	"github.com/selebrow/selebrow/internal/services/health"
 	"github.com/selebrow/selebrow/pkg/browsers"
 	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/event"
 	"github.com/selebrow/selebrow/pkg/kubeapi"
 	"github.com/selebrow/selebrow/pkg/log"
 	"github.com/selebrow/selebrow/pkg/quota"
@@ -27,9 +29,14 @@ func InitKubeClientFunc(cfg config.Config) kubeapi.KubernetesClient {
 	return kubeClient
 }
 
-func InitKubernetesQuotaAuthorizerFunc(cfg config.Config, _ kubeapi.KubernetesClient, _ *signal.Handler) quota.QuotaAuthorizer {
+func InitKubernetesQuotaAuthorizerFunc(
+	cfg config.Config,
+	_ kubeapi.KubernetesClient,
+	eb event.EventBroker,
+	_ *signal.Handler,
+) quota.QuotaAuthorizer {
 	// XXX maybe calculate from kube ResourceQuota?
-	return initLimitQuotaAuthorizer(cfg, 0, 0)
+	return initLimitQuotaAuthorizer(cfg, 0, 0, eb)
 }
 
 func readKubeTemplates(cfg config.Config) map[string]string {
@@ -53,6 +60,7 @@ func initKubernetesWebDriverManager(
 	templatesData map[string]string,
 	cat browsers.BrowsersCatalog,
 	sig *signal.Handler,
+	hs *health.HealthServiceImpl,
 ) *kubernetes.KubernetesBrowserManager {
 	l := log.GetLogger().Named("k8s")
 	bc, err := kubernetes.NewTemplatedBrowserConverter(
@@ -70,6 +78,7 @@ func initKubernetesWebDriverManager(
 		InitLog.Fatalw("failed to initialize Pod watcher", zap.Error(err))
 	}
 	sig.RegisterShutdownHook(watcher, watcher.Shutdown)
+	hs.AddLivenessCheck("podWatcher", watcher.Check)
 
 	backoff := wait.Backoff{
 		Duration: 100 * time.Millisecond,
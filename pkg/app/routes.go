@@ -6,9 +6,15 @@ import (
 	"time"
 
 	"github.com/selebrow/selebrow/html"
+	"github.com/selebrow/selebrow/internal/browser/pool"
+	hc "github.com/selebrow/selebrow/internal/common/client"
 	"github.com/selebrow/selebrow/internal/common/ws"
 	"github.com/selebrow/selebrow/internal/controllers"
 	"github.com/selebrow/selebrow/internal/router"
+	"github.com/selebrow/selebrow/internal/services/artifacts"
+	"github.com/selebrow/selebrow/internal/services/cmdlog"
+	"github.com/selebrow/selebrow/internal/services/drain"
+	"github.com/selebrow/selebrow/internal/services/health"
 	quotasrv "github.com/selebrow/selebrow/internal/services/quota"
 	"github.com/selebrow/selebrow/internal/services/session"
 	"github.com/selebrow/selebrow/pkg/browsers"
@@ -16,6 +22,7 @@ import (
 	"github.com/selebrow/selebrow/pkg/event"
 	"github.com/selebrow/selebrow/pkg/models"
 	"github.com/selebrow/selebrow/pkg/quota"
+	"github.com/selebrow/selebrow/pkg/tracing"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
@@ -61,8 +68,56 @@ type (
 
 	PWController interface {
 		CreateSession(c echo.Context) error
+		CDP(c echo.Context) error
+		CDPVersion(c echo.Context) error
 		ValidateSession(next echo.HandlerFunc) echo.HandlerFunc
 	}
+
+	PuppeteerController interface {
+		CreateSession(c echo.Context) error
+		ValidateSession(next echo.HandlerFunc) echo.HandlerFunc
+	}
+
+	PoolController interface {
+		ListPools(c echo.Context) error
+		DrainPool(c echo.Context) error
+		DrainPools(c echo.Context) error
+	}
+
+	SessionsController interface {
+		ListSessions(c echo.Context) error
+		GetSession(c echo.Context) error
+		DeleteSession(c echo.Context) error
+	}
+
+	DrainController interface {
+		Status(c echo.Context) error
+		Drain(c echo.Context) error
+		Resume(c echo.Context) error
+		RejectWhenDraining(next echo.HandlerFunc) echo.HandlerFunc
+	}
+
+	HealthController interface {
+		Healthz(c echo.Context) error
+		Readyz(c echo.Context) error
+	}
+
+	CommandsController interface {
+		Commands(c echo.Context) error
+	}
+
+	DownloadsController interface {
+		ListFiles(c echo.Context) error
+		GetFile(c echo.Context) error
+		DeleteFiles(c echo.Context) error
+		ArchiveDownloads(next echo.HandlerFunc) echo.HandlerFunc
+	}
+
+	ArtifactsController interface {
+		Screenshots(c echo.Context) error
+		Screenshot(c echo.Context) error
+		DownloadsArchive(c echo.Context) error
+	}
 )
 
 func initEcho(cfg config.Config, l *zap.Logger) *echo.Echo {
@@ -116,6 +171,18 @@ func InitMiddlewareFunc(_ config.Config, e *echo.Echo, srvLogger *zap.Logger) {
 		}))
 	}
 
+	// extract W3C trace context propagated by clients
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if isStatic(c) || isUI(c) {
+				return next(c)
+			}
+			req := c.Request()
+			c.SetRequest(req.WithContext(tracing.Extract(req.Context(), req.Header)))
+			return next(c)
+		}
+	})
+
 	e.Use(middleware.RecoverWithConfig(middleware.RecoverConfig{
 		DisablePrintStack: true, // this will be handled by zap logger
 		LogErrorFunc: func(c echo.Context, err error, _ []byte) error {
@@ -136,7 +203,17 @@ func InitAPIFunc(
 	infoController InfoController,
 	wdStatusController WDStatusController,
 	playwrightController PWController,
+	puppeteerController PuppeteerController,
+	poolController PoolController,
+	sessionsController SessionsController,
+	drainController DrainController,
+	healthController HealthController,
+	commandsController CommandsController,
+	artifactsController ArtifactsController,
+	downloadsController DownloadsController,
 ) {
+	e.GET("/healthz", healthController.Healthz)
+	e.GET("/readyz", healthController.Readyz)
 	e.GET("/browsers", catalogController.Browsers)
 	e.GET("/status", sessionController.Status)
 	e.GET("/quota", quotaController.QuotaUsage)
@@ -159,6 +236,7 @@ func InitAPIFunc(
 		router.SessRoute("/download/:%s"),
 		proxyController.Proxy,
 		sessionController.ValidateSession,
+		downloadsController.ArchiveDownloads,
 		proxyController.SetPortProxyURL(models.FileserverPort),
 	)
 	e.Any(
@@ -179,10 +257,18 @@ func InitAPIFunc(
 		sessionController.ValidateSession,
 		proxyController.SetPortProxyURL(models.DevtoolsPort),
 	)
+	e.GET(router.SessRoute(router.CommandsPath+"/:%s"), commandsController.Commands)
+	e.GET(router.ScreenshotsPath, artifactsController.Screenshots)
+	e.GET(router.SessRoute(router.ScreenshotsPath+"/:%s"), artifactsController.Screenshot)
+	e.GET(router.SessRoute(router.ArchivesPath+"/:%s"), artifactsController.DownloadsArchive)
 	wdhub := e.Group(router.WDHUBPath)
 	wdhub.GET("/status", wdStatusController.Status)
-	wdhub.POST(router.SessionPath, sessionController.CreateSession)
+	wdhub.POST(router.SessionPath, sessionController.CreateSession, drainController.RejectWhenDraining)
 	wdhub.DELETE(router.SessRoute(router.SessionPath+"/:%s"), sessionController.DeleteSession, sessionController.ValidateSession)
+	seFiles := router.SessRoute(router.SessionPath + "/:%s" + router.SEFilesPath)
+	wdhub.GET(seFiles, downloadsController.ListFiles, sessionController.ValidateSession)
+	wdhub.POST(seFiles, downloadsController.GetFile, sessionController.ValidateSession)
+	wdhub.DELETE(seFiles, downloadsController.DeleteFiles, sessionController.ValidateSession)
 	wdhub.Any(
 		router.SessRoute(router.SessionPath+"/:%s/*"),
 		proxyController.Proxy,
@@ -199,11 +285,57 @@ func InitAPIFunc(
 		proxyController.SetPortProxyURL(models.VNCPort),
 	)
 	pwBrowser := pw.Group(router.NameRoute("/:%s"))
-	pwBrowser.GET("", playwrightController.CreateSession)
-	pwBrowser.GET(router.VersionRoute("/:%s"), playwrightController.CreateSession)
+	pwBrowser.GET("", playwrightController.CreateSession, drainController.RejectWhenDraining)
+	pwBrowser.GET(router.VersionRoute("/:%s"), playwrightController.CreateSession, drainController.RejectWhenDraining)
+
+	cdpBrowser := e.Group(router.CDPPath + router.NameRoute("/:%s"))
+	cdpBrowser.GET("", playwrightController.CDP, drainController.RejectWhenDraining)
+	cdpBrowser.GET(router.VersionRoute("/:%s"), playwrightController.CDP, drainController.RejectWhenDraining)
+	for _, p := range []string{router.CDPVersionPath, router.CDPVersionPath + "/"} {
+		cdpBrowser.GET(p, playwrightController.CDPVersion)
+		cdpBrowser.GET(router.VersionRoute("/:%s")+p, playwrightController.CDPVersion)
+	}
+
+	puppeteer := e.Group(router.PuppeteerPath)
+	puppeteer.Any(
+		router.SessRoute("/vnc/:%s"),
+		proxyController.VNCProxy,
+		puppeteerController.ValidateSession,
+		proxyController.SetPortProxyURL(models.VNCPort),
+	)
+	puppeteerBrowser := puppeteer.Group(router.NameRoute("/:%s"))
+	puppeteerBrowser.GET("", puppeteerController.CreateSession, drainController.RejectWhenDraining)
+	puppeteerBrowser.GET(router.VersionRoute("/:%s"), puppeteerController.CreateSession, drainController.RejectWhenDraining)
+
+	admin := e.Group(router.AdminPath)
+	admin.GET(router.PoolsPath, poolController.ListPools)
+	admin.DELETE(router.PoolsPath, poolController.DrainPools)
+	admin.DELETE(router.NameRoute(router.PoolsPath+"/:%s"), poolController.DrainPool)
+	admin.GET(router.DrainPath, drainController.Status)
+	admin.POST(router.DrainPath, drainController.Drain)
+	admin.DELETE(router.DrainPath, drainController.Resume)
+
+	api := e.Group(router.APIPath)
+	api.GET(router.SessionsPath, sessionsController.ListSessions)
+	api.GET(router.SessRoute(router.SessionsPath+"/:%s"), sessionsController.GetSession)
+	api.DELETE(router.SessRoute(router.SessionsPath+"/:%s"), sessionsController.DeleteSession)
 }
 
-func initUI(cfg config.Config, e *echo.Echo, qa quota.QuotaAuthorizer, wdSvc session.SessionService, pwSvc session.SessionService) {
+func initUI(
+	cfg config.Config,
+	e *echo.Echo,
+	cat browsers.BrowsersCatalog,
+	qa quota.QuotaAuthorizer,
+	eb event.EventBroker,
+	cmdLog cmdlog.CommandLog,
+	artifactStore artifacts.ArtifactStore,
+	backend config.BackendType,
+	wdSvc session.SessionService,
+	pwSvc session.SessionService,
+	ppSvc session.SessionService,
+	wdCreator controllers.WDSessionCreator,
+	drainController DrainController,
+) {
 	if !cfg.UI() {
 		return
 	}
@@ -214,8 +346,15 @@ func initUI(cfg config.Config, e *echo.Echo, qa quota.QuotaAuthorizer, wdSvc ses
 		map[models.BrowserProtocol]session.SessionService{
 			models.WebdriverProtocol:  wdSvc,
 			models.PlaywrightProtocol: pwSvc,
+			models.PuppeteerProtocol:  ppSvc,
 		},
+		wdCreator,
+		cat,
 		qa,
+		eb,
+		cmdLog,
+		artifactStore,
+		backend,
 	)
 
 	e.GET("/", func(c echo.Context) error {
@@ -227,21 +366,36 @@ func initUI(cfg config.Config, e *echo.Echo, qa quota.QuotaAuthorizer, wdSvc ses
 		RedirectCode: http.StatusTemporaryRedirect,
 	}))
 	ui.GET("", uictrl.Index)
+	ui.GET(router.UIEventsPath, uictrl.Events)
 
 	wd := ui.Group(router.UIWDRoot)
 	wd.GET("", uictrl.WDSessions)
+	wd.GET(router.UILaunchPath, uictrl.WDLaunchForm)
+	wd.GET(router.UIScreenshotsPath, uictrl.WDScreenshots)
+	wd.POST(router.UILaunchPath, uictrl.WDLaunch, drainController.RejectWhenDraining)
 
 	wsSess := wd.Group(router.SessRoute("/:%s"))
+	wsSess.GET("", uictrl.WDSession)
 	wsSess.GET(router.UIVNCPath, uictrl.WDVNC)
 	wsSess.GET(router.UIResetPath, uictrl.WDReset)
+	wsSess.GET(router.UICommandsPath, uictrl.WDCommands)
 
 	pw := ui.Group(router.UIPWRoot)
 	pw.GET("", uictrl.PWSessions)
 
 	pwSess := pw.Group(router.SessRoute("/:%s"))
+	pwSess.GET("", uictrl.PWSession)
 	pwSess.GET(router.UIVNCPath, uictrl.PWVNC)
 	pwSess.GET(router.UIResetPath, uictrl.PWReset)
 
+	pp := ui.Group(router.UIPuppeteerRoot)
+	pp.GET("", uictrl.PuppeteerSessions)
+
+	ppSess := pp.Group(router.SessRoute("/:%s"))
+	ppSess.GET("", uictrl.PuppeteerSession)
+	ppSess.GET(router.UIVNCPath, uictrl.PuppeteerVNC)
+	ppSess.GET(router.UIResetPath, uictrl.PuppeteerReset)
+
 	InitLog.Infof("UI initialized at %s", uictrl.URL())
 }
 
@@ -256,9 +410,28 @@ func initRenderer(e *echo.Echo) {
 func initUIController(
 	cfg config.Config,
 	services map[models.BrowserProtocol]session.SessionService,
+	wdCreator controllers.WDSessionCreator,
+	cat browsers.BrowsersCatalog,
 	qa quota.QuotaAuthorizer,
+	eb event.EventBroker,
+	cmdLog cmdlog.CommandLog,
+	artifactStore artifacts.ArtifactStore,
+	backend config.BackendType,
 ) *controllers.UIController {
-	return controllers.NewUIController(services, qa, listen(cfg), cfg.VNCPassword())
+	return controllers.NewUIController(
+		services,
+		wdCreator,
+		cat,
+		qa,
+		eb,
+		cmdLog,
+		artifactStore,
+		backend,
+		time.Now,
+		listen(cfg),
+		cfg.VNCPassword(),
+		cfg.MaxSessionTimeout(),
+	)
 }
 
 func initConfigController(browsersConfig []byte) *controllers.ConfigController {
@@ -267,23 +440,52 @@ func initConfigController(browsersConfig []byte) *controllers.ConfigController {
 
 func initWDSessionController(
 	svc session.SessionService,
+	cat browsers.BrowsersCatalog,
 	eb event.EventBroker,
 	proxyOpts *config.ProxyOpts,
 	cLog *zap.Logger,
 ) *controllers.WDSessionController {
-	return controllers.NewWDSessionController(svc, eb, time.Now, proxyOpts, cLog.Named("wdsession"))
+	return controllers.NewWDSessionController(svc, cat, eb, time.Now, proxyOpts, cLog.Named("wdsession"))
 }
 
-func initProxyController(transport http.RoundTripper, p ws.WSProxy, cLog *zap.Logger) *controllers.ProxyController {
-	return controllers.NewProxyController(transport, p, cLog.Named("proxy"))
+func initProxyController(
+	cfg config.Config,
+	transport http.RoundTripper,
+	p ws.WSProxy,
+	terminator session.SessionTerminator,
+	cmdLog cmdlog.CommandLog,
+	eb event.EventBroker,
+	cLog *zap.Logger,
+) *controllers.ProxyController {
+	return controllers.NewProxyController(transport, p, terminator, cmdLog, eb, cfg, cLog.Named("proxy"))
 }
 
 func initBrowsersCatalogController(cat browsers.BrowsersCatalog) *controllers.BrowsersCatalogController {
 	return controllers.NewBrowsersCatalogController(cat)
 }
 
-func initWDStatusController() *controllers.WDStatusController {
-	return controllers.NewWDStatusController()
+func initWDStatusController(drainSvc drain.DrainService) *controllers.WDStatusController {
+	return controllers.NewWDStatusController(drainSvc)
+}
+
+func initCommandsController(svc session.SessionService, cmdLog cmdlog.CommandLog) *controllers.CommandsController {
+	return controllers.NewCommandsController(svc, cmdLog)
+}
+
+func initDownloadsController(httpClient hc.HTTPClient) *controllers.DownloadsController {
+	return controllers.NewDownloadsController(httpClient)
+}
+
+func initArtifactsController(store artifacts.ArtifactStore) *controllers.ArtifactsController {
+	return controllers.NewArtifactsController(store)
+}
+
+func initHealthController(hs health.HealthService) *controllers.HealthController {
+	return controllers.NewHealthController(hs)
+}
+
+func initDrainController(drainSvc drain.DrainService) *controllers.DrainController {
+	return controllers.NewDrainController(drainSvc)
 }
 
 func initQuotaController(qa quota.QuotaAuthorizer) *controllers.QuotaController {
@@ -291,16 +493,48 @@ func initQuotaController(qa quota.QuotaAuthorizer) *controllers.QuotaController
 	return controllers.NewQuotaController(srv)
 }
 
+func initPoolController(admin pool.PoolAdmin) *controllers.PoolController {
+	return controllers.NewPoolController(admin)
+}
+
+func initSessionsController(
+	backend config.BackendType,
+	wdSvc session.SessionService,
+	pwSvc session.SessionService,
+	ppSvc session.SessionService,
+) *controllers.SessionsController {
+	return controllers.NewSessionsController(
+		map[models.BrowserProtocol]session.SessionService{
+			models.WebdriverProtocol:  wdSvc,
+			models.PlaywrightProtocol: pwSvc,
+			models.PuppeteerProtocol:  ppSvc,
+		},
+		backend,
+		time.Now,
+	)
+}
+
 func initInfoController(appName, gitRef, gitSha string) *controllers.InfoController {
 	return controllers.NewInfoController(appName, gitRef, gitSha)
 }
 
 func initPlayWrightController(
 	svc session.SessionService,
+	cat browsers.BrowsersCatalog,
 	transport http.RoundTripper,
 	eb event.EventBroker,
 	proxyOpts *config.ProxyOpts,
 	cLog *zap.Logger,
 ) *controllers.PWController {
-	return controllers.NewPWController(svc, transport, eb, time.Now, proxyOpts, cLog.Named("playwright"))
+	return controllers.NewPWController(svc, cat, transport, eb, time.Now, proxyOpts, cLog.Named("playwright"))
+}
+
+func initPuppeteerController(
+	svc session.SessionService,
+	transport http.RoundTripper,
+	eb event.EventBroker,
+	proxyOpts *config.ProxyOpts,
+	cLog *zap.Logger,
+) *controllers.PuppeteerController {
+	return controllers.NewPuppeteerController(svc, transport, eb, time.Now, proxyOpts, cLog.Named("puppeteer"))
 }
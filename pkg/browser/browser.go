@@ -12,6 +12,9 @@ const DefaultPlatform = "LINUX"
 type Browser interface {
 	GetURL() *url.URL
 	GetHost() string
+	// GetBackendID returns identifier of the browser in the backend (container ID or pod name)
+	GetBackendID() string
 	GetHostPort(name models.ContainerPort) string
+	Exec(ctx context.Context, cmd []string) error
 	Close(ctx context.Context, trash bool)
 }
@@ -0,0 +1,56 @@
+package browser
+
+import (
+	"context"
+	"time"
+)
+
+// AllocationTrace is a set of hooks to observe stages of browser allocation, any hook may be nil.
+// The trace is carried by the context passed to BrowserManager.Allocate, similar to net/http/httptrace
+type AllocationTrace struct {
+	// Queued is called when allocation has to wait in the queue for available quota
+	Queued func(queueSize int)
+	// Dequeued is called when queued allocation gets quota or gives up waiting
+	Dequeued func(waited time.Duration, err error)
+	// PoolCheckout is called on checkout from the idle browsers pool
+	PoolCheckout func(hit bool)
+	// ImagePulled is called when browser image pull is completed, it may be called after allocation is cancelled
+	ImagePulled func(image string, duration time.Duration, err error)
+}
+
+type allocationTraceKey struct{}
+
+func WithAllocationTrace(ctx context.Context, trace *AllocationTrace) context.Context {
+	return context.WithValue(ctx, allocationTraceKey{}, trace)
+}
+
+// ContextAllocationTrace returns trace carried by the context, hooks of the returned trace are safe to call
+// via its methods even if there is no trace in the context
+func ContextAllocationTrace(ctx context.Context) *AllocationTrace {
+	trace, _ := ctx.Value(allocationTraceKey{}).(*AllocationTrace)
+	return trace
+}
+
+func (t *AllocationTrace) OnQueued(queueSize int) {
+	if t != nil && t.Queued != nil {
+		t.Queued(queueSize)
+	}
+}
+
+func (t *AllocationTrace) OnDequeued(waited time.Duration, err error) {
+	if t != nil && t.Dequeued != nil {
+		t.Dequeued(waited, err)
+	}
+}
+
+func (t *AllocationTrace) OnPoolCheckout(hit bool) {
+	if t != nil && t.PoolCheckout != nil {
+		t.PoolCheckout(hit)
+	}
+}
+
+func (t *AllocationTrace) OnImagePulled(image string, duration time.Duration, err error) {
+	if t != nil && t.ImagePulled != nil {
+		t.ImagePulled(image, duration, err)
+	}
+}
@@ -0,0 +1,33 @@
+package browser
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestContextAllocationTrace(t *testing.T) {
+	g := NewWithT(t)
+
+	// hooks are no-op without trace in context
+	trace := ContextAllocationTrace(context.TODO())
+	g.Expect(trace).To(BeNil())
+	trace.OnQueued(1)
+	trace.OnDequeued(time.Second, nil)
+	trace.OnPoolCheckout(true)
+	trace.OnImagePulled("img", time.Second, nil)
+
+	var hits []bool
+	ctx := WithAllocationTrace(context.TODO(), &AllocationTrace{
+		PoolCheckout: func(hit bool) {
+			hits = append(hits, hit)
+		},
+	})
+	trace = ContextAllocationTrace(ctx)
+	trace.OnQueued(1) // nil hook
+	trace.OnPoolCheckout(true)
+	trace.OnPoolCheckout(false)
+	g.Expect(hits).To(Equal([]bool{true, false}))
+}
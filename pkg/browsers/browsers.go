@@ -8,6 +8,7 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/selebrow/selebrow/pkg/browser"
+	"github.com/selebrow/selebrow/pkg/capabilities"
 	"github.com/selebrow/selebrow/pkg/dto"
 	"github.com/selebrow/selebrow/pkg/models"
 )
@@ -19,6 +20,7 @@ const (
 type BrowsersCatalog interface {
 	LookupBrowserImage(protocol models.BrowserProtocol, name, flavor string) (models.BrowserImageConfig, bool)
 	GetBrowsers(protocol models.BrowserProtocol, flavor string) (result []dto.Browser)
+	GetFlavors(protocol models.BrowserProtocol) (result []string)
 	GetImages() (result []string)
 }
 
@@ -74,6 +76,29 @@ func (b *YamlBrowsersCatalog) LookupBrowserImage(protocol models.BrowserProtocol
 	return *ic, ok
 }
 
+// NewCapabilitiesMatcher returns match func accepting capabilities the catalog has image and version tag for
+func NewCapabilitiesMatcher(cat BrowsersCatalog, protocol models.BrowserProtocol) capabilities.MatchFunc {
+	return func(caps capabilities.Capabilities) bool {
+		ic, ok := cat.LookupBrowserImage(protocol, caps.GetName(), caps.GetFlavor())
+		if !ok {
+			return false
+		}
+		_, ok = ic.GetTag(caps.GetVersion())
+		return ok
+	}
+}
+
+// NewCapabilitiesDefaults returns func providing default capabilities configured in the catalog for requested browser
+func NewCapabilitiesDefaults(cat BrowsersCatalog, protocol models.BrowserProtocol) capabilities.DefaultsFunc {
+	return func(caps capabilities.Capabilities) map[string]interface{} {
+		ic, ok := cat.LookupBrowserImage(protocol, caps.GetName(), caps.GetFlavor())
+		if !ok {
+			return nil
+		}
+		return ic.DefaultCapabilities
+	}
+}
+
 func (b *YamlBrowsersCatalog) GetBrowsers(
 	protocol models.BrowserProtocol,
 	flavor string,
@@ -110,6 +135,17 @@ func (b *YamlBrowsersCatalog) GetBrowsers(
 	return result
 }
 
+// GetFlavors returns sorted list of flavors configured for any browser of the protocol
+func (b *YamlBrowsersCatalog) GetFlavors(protocol models.BrowserProtocol) (result []string) {
+	for _, cfg := range b.cat[protocol] {
+		for flavor := range cfg.Images {
+			result = append(result, flavor)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
 func setImageRegistry(cat models.BrowserCatalog, newRegistry string) error {
 	if newRegistry == "" {
 		return nil
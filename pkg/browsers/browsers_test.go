@@ -143,6 +143,16 @@ func TestBrowsersCatalog_GetImages_WithRegistry(t *testing.T) {
 	}))
 }
 
+func TestBrowsersCatalog_GetFlavors(t *testing.T) {
+	g := NewWithT(t)
+
+	cat, err := NewYamlBrowsersCatalog([]byte(data1), "")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(cat.GetFlavors(models.WebdriverProtocol)).To(Equal([]string{"cp", "default"}))
+	g.Expect(cat.GetFlavors("unknown")).To(BeEmpty())
+}
+
 func TestBrowsersCatalog_GetBrowsers_Default_Flavor(t *testing.T) {
 	g := NewWithT(t)
 
@@ -245,3 +255,51 @@ func TestNewYamlBrowsersCatalog_Negative(t *testing.T) {
 		})
 	}
 }
+
+func TestNewCapabilitiesMatcher(t *testing.T) {
+	g := NewWithT(t)
+
+	cat, err := NewYamlBrowsersCatalog([]byte(data1), "")
+	g.Expect(err).ToNot(HaveOccurred())
+	match := NewCapabilitiesMatcher(cat, models.WebdriverProtocol)
+
+	caps := func(name, version, flavor string) *models.Capabilities {
+		return &models.Capabilities{
+			Name:            name,
+			Version:         version,
+			SelenoidOptions: &models.SelenoidOptions{Flavor: flavor},
+		}
+	}
+	g.Expect(match(caps("chrome", "", ""))).To(BeTrue())
+	g.Expect(match(caps("chrome", "115.0", "cp"))).To(BeTrue())
+	g.Expect(match(caps("chrome", "115.0", ""))).To(BeFalse())
+	g.Expect(match(caps("safari", "", ""))).To(BeFalse())
+	g.Expect(match(caps("webkit", "", ""))).To(BeFalse())
+}
+
+func TestNewCapabilitiesDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	cat, err := NewYamlBrowsersCatalog([]byte(`
+webdriver:
+  chrome:
+    images:
+      default:
+        image: webdriver/chrome
+        defaultVersion: "116.0"
+        versionTags:
+          "116.0": chrome_116.0
+        defaultCapabilities:
+          goog:chromeOptions:
+            args: [ "--no-sandbox", "--disable-dev-shm-usage" ]
+`), "")
+	g.Expect(err).ToNot(HaveOccurred())
+	defaults := NewCapabilitiesDefaults(cat, models.WebdriverProtocol)
+
+	g.Expect(defaults(&models.Capabilities{Name: "chrome"})).To(Equal(map[string]interface{}{
+		"goog:chromeOptions": map[string]interface{}{
+			"args": []interface{}{"--no-sandbox", "--disable-dev-shm-usage"},
+		},
+	}))
+	g.Expect(defaults(&models.Capabilities{Name: "firefox"})).To(BeNil())
+}
@@ -28,6 +28,8 @@ type Capabilities interface {
 	GetTestName() string
 	GetEnvs() []string
 	GetTimeout() time.Duration
+	// GetMaxLifetime returns requested maximum session lifetime regardless of activity, zero if not set
+	GetMaxLifetime() time.Duration
 	GetRawCapabilities() []byte
 	GetFlavor() string
 	GetLinks() []string
@@ -36,12 +38,34 @@ type Capabilities interface {
 	GetLabels() map[string]string
 }
 
+// WDCapabilities provides Webdriver specific capabilities
+type WDCapabilities interface {
+	Capabilities
+	// IsCommandLogEnabled returns true if proxied Webdriver commands should be recorded
+	IsCommandLogEnabled() bool
+	// IsFinalScreenshotEnabled returns true if screenshot should be taken before Webdriver session is deleted
+	IsFinalScreenshotEnabled() bool
+	// IsDownloadsEnabled returns true if Selenium managed downloads were requested with se:downloadsEnabled
+	IsDownloadsEnabled() bool
+}
+
+// MatchFunc reports whether capabilities can be satisfied, used to choose one of W3C firstMatch alternatives
+type MatchFunc func(caps Capabilities) bool
+
+// DefaultsFunc returns default capabilities of the requested browser, requested values take precedence over them
+type DefaultsFunc func(caps Capabilities) map[string]interface{}
+
 type CapsWrapper struct {
 	*models.JsonWireCapabilities
 	Capabilities *models.W3CCapabilities `json:"capabilities,omitempty"`
 }
 
-func NewCapabilities(r io.Reader, defaultProxy *models.ProxyOptions) (Capabilities, error) {
+func NewCapabilities(
+	r io.Reader,
+	defaultProxy *models.ProxyOptions,
+	match MatchFunc,
+	defaults DefaultsFunc,
+) (Capabilities, error) {
 	var unparsedCaps CapsWrapper
 
 	raw, err := io.ReadAll(r)
@@ -54,9 +78,12 @@ func NewCapabilities(r io.Reader, defaultProxy *models.ProxyOptions) (Capabiliti
 		return nil, errors.Wrap(err, "failed parsing Capabilities json")
 	}
 
-	c := new(models.Capabilities)
-
-	var updateProxyFn func(proxy *models.ProxyOptions)
+	var (
+		c               = new(models.Capabilities)
+		updateProxyFn   func(proxy *models.ProxyOptions)
+		mergeDefaultsFn func(defaults map[string]interface{}) error
+		updateRaw       bool
+	)
 	if unparsedCaps.Capabilities == nil {
 		if unparsedCaps.JsonWireCapabilities == nil || unparsedCaps.DesiredCapabilities == nil {
 			return nil, errors.New("no valid capabilities provided in request")
@@ -67,16 +94,47 @@ func NewCapabilities(r io.Reader, defaultProxy *models.ProxyOptions) (Capabiliti
 		}
 		//nolint:staticcheck // QF1008: we want to be more explicit here
 		updateProxyFn = unparsedCaps.JsonWireCapabilities.UpdateProxy
+		mergeDefaultsFn = func(defaults map[string]interface{}) error {
+			//nolint:staticcheck // QF1008: we want to be more explicit here
+			unparsedCaps.JsonWireCapabilities.MergeDefaults(defaults)
+			c = new(models.Capabilities)
+			return decodeCapabilities(c, unparsedCaps.DesiredCapabilities, "jsonwire")
+		}
 	} else {
-		if err := decodeCapabilities(c, unparsedCaps.Capabilities.Merge(), "w3c"); err != nil {
+		var i int
+		i, c, err = matchW3CCapabilities(unparsedCaps.Capabilities, match)
+		if err != nil {
 			return nil, errors.Wrap(err, "failed to decode W3C capabilities")
 		}
+		if len(unparsedCaps.Capabilities.FirstMatch) > 1 {
+			unparsedCaps.Capabilities.SelectFirstMatch(i)
+			updateRaw = true
+		}
 		updateProxyFn = unparsedCaps.Capabilities.UpdateProxy
+		mergeDefaultsFn = func(defaults map[string]interface{}) error {
+			unparsedCaps.Capabilities.MergeDefaults(defaults)
+			c = new(models.Capabilities)
+			// only the chosen alternative is left at this point
+			return decodeCapabilities(c, unparsedCaps.Capabilities.Alternatives()[0], "w3c")
+		}
+	}
+
+	if defaults != nil {
+		if d := defaults(c); len(d) > 0 {
+			if err := mergeDefaultsFn(d); err != nil {
+				return nil, errors.Wrap(err, "failed to apply default capabilities")
+			}
+			updateRaw = true
+		}
 	}
 
 	if defaultProxy != nil && (c.Proxy == nil || c.Proxy.ProxyType != models.ProxyTypeManual) {
 		updateProxyFn(defaultProxy)
 		c.Proxy = defaultProxy
+		updateRaw = true
+	}
+
+	if updateRaw {
 		raw, err = json.Marshal(unparsedCaps)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to serialize updated capabilities")
@@ -91,6 +149,25 @@ func NewCapabilities(r io.Reader, defaultProxy *models.ProxyOptions) (Capabiliti
 	return c, nil
 }
 
+// matchW3CCapabilities returns the first alternative accepted by match func along with its firstMatch index,
+// falls back to the first alternative when none is accepted so the actual reason is reported on allocation
+func matchW3CCapabilities(w3c *models.W3CCapabilities, match MatchFunc) (int, *models.Capabilities, error) {
+	var first *models.Capabilities
+	for i, alt := range w3c.Alternatives() {
+		c := new(models.Capabilities)
+		if err := decodeCapabilities(c, alt, "w3c"); err != nil {
+			return 0, nil, err
+		}
+		if match == nil || match(c) {
+			return i, c, nil
+		}
+		if first == nil {
+			first = c
+		}
+	}
+	return 0, first, nil
+}
+
 func GetHash(caps Capabilities) []byte {
 	hash := NewHash()
 	hash.Write([]byte(caps.GetPlatform()))
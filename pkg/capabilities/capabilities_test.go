@@ -22,9 +22,12 @@ const (
             "browserName": "firefox",
             "browserVersion": "119.0",
             "platformName": "gnu/hurd",
+            "se:downloadsEnabled": true,
             "selenoid:options":
             {
                 "sessionTimeout": "10m",
+                "maxSessionLifetime": "1h",
+                "finalScreenshot": true,
                 "enableVNC": true,                
                 "env": [ "a=b" ],
                 "flavor": "test"
@@ -55,6 +58,117 @@ const (
         ]
     },
     "desiredCapabilities": {}
+}`
+	// fullW3C with the first firstMatch alternative chosen
+	fullW3CSelected = `{
+    "capabilities":
+    {
+        "alwaysMatch":
+        {
+            "browserName": "firefox",
+            "browserVersion": "119.0",
+            "platformName": "gnu/hurd",
+            "se:downloadsEnabled": true,
+            "selenoid:options":
+            {
+                "sessionTimeout": "10m",
+                "maxSessionLifetime": "1h",
+                "finalScreenshot": true,
+                "enableVNC": true,
+                "env": [ "a=b" ],
+                "flavor": "test"
+            },
+            "proxy": {
+			    "proxyType": "manual",
+			    "httpProxy": "http://127.0.0.1:8080",
+			    "sslProxy": "http://127.0.0.1:8080"
+			}
+        },
+        "firstMatch":
+        [
+            {
+                "selenoid:options":
+                {
+                    "sessionTimeout": "10m",
+                    "name": "my-test",
+                    "flavor": "ignore"
+                }
+            }
+        ]
+    }
+}`
+	firstMatchW3C = `{
+    "capabilities":
+    {
+        "alwaysMatch": { "selenoid:options": { "name": "my-test" } },
+        "firstMatch":
+        [
+            { "browserName": "chrome", "selenoid:options": { "screenResolution": "1920x1080x24" } },
+            { "browserName": "firefox", "browserVersion": "119.0" }
+        ]
+    }
+}`
+	firstMatchW3CFirefox = `{
+    "capabilities":
+    {
+        "alwaysMatch": { "selenoid:options": { "name": "my-test" } },
+        "firstMatch": [ { "browserName": "firefox", "browserVersion": "119.0" } ]
+    }
+}`
+	firstMatchW3CChrome = `{
+    "capabilities":
+    {
+        "alwaysMatch": { "selenoid:options": { "name": "my-test" } },
+        "firstMatch": [ { "browserName": "chrome", "selenoid:options": { "screenResolution": "1920x1080x24" } } ]
+    }
+}`
+	defaultsW3C = `{
+    "capabilities":
+    {
+        "alwaysMatch": { "browserName": "chrome", "selenoid:options": { "name": "my-test" } },
+        "firstMatch": [ { "goog:chromeOptions": { "args": [ "--headless", "--no-sandbox" ] } } ]
+    }
+}`
+	defaultsW3CMerged = `{
+    "capabilities":
+    {
+        "alwaysMatch":
+        {
+            "browserName": "chrome",
+            "acceptInsecureCerts": true,
+            "selenoid:options": { "name": "my-test", "enableVNC": true }
+        },
+        "firstMatch":
+        [
+            {
+                "goog:chromeOptions":
+                {
+                    "args": [ "--headless", "--no-sandbox" ],
+                    "prefs": { "download.prompt_for_download": false }
+                }
+            }
+        ]
+    }
+}`
+	defaultsJsonWire = `{
+    "desiredCapabilities":
+    {
+        "browserName": "chrome",
+        "acceptInsecureCerts": false
+    }
+}`
+	defaultsJsonWireMerged = `{
+    "desiredCapabilities":
+    {
+        "browserName": "chrome",
+        "acceptInsecureCerts": false,
+        "selenoid:options": { "enableVNC": true },
+        "goog:chromeOptions":
+        {
+            "args": [ "--no-sandbox", "--disable-dev-shm-usage" ],
+            "prefs": { "download.prompt_for_download": false }
+        }
+    }
 }`
 	fullJsonWire = `{
     "desiredCapabilities":
@@ -120,17 +234,36 @@ var testProxy = &models.ProxyOptions{
 	NoProxy:   []string{"localhost", "127.0.0.1"},
 }
 
+func testDefaults(caps capabilities.Capabilities) map[string]interface{} {
+	if caps.GetName() != "chrome" {
+		return nil
+	}
+	return map[string]interface{}{
+		"acceptInsecureCerts": true,
+		"selenoid:options":    map[string]interface{}{"enableVNC": true},
+		"goog:chromeOptions": map[string]interface{}{
+			"args":  []interface{}{"--no-sandbox", "--disable-dev-shm-usage"},
+			"prefs": map[string]interface{}{"download.prompt_for_download": false},
+		},
+	}
+}
+
 func TestNewCapabilities(t *testing.T) {
 	tests := []struct {
 		name          string
 		input         string
 		defProxy      *models.ProxyOptions
+		match         capabilities.MatchFunc
+		defaults      capabilities.DefaultsFunc
 		expName       string
 		expVersion    string
 		expPlatform   string
 		expResolution string
 		expFlavor     string
 		expTimeout    time.Duration
+		expLifetime   time.Duration
+		expScreenshot bool
+		expDownloads  bool
 		expVnc        bool
 		expTestName   string
 		expEnvs       []string
@@ -154,13 +287,33 @@ func TestNewCapabilities(t *testing.T) {
 			expName:       "firefox",
 			expPlatform:   "gnu/hurd",
 			expVersion:    "119.0",
-			expResolution: "1920x1080x24",
 			expFlavor:     "test",
 			expTimeout:    10 * time.Minute,
+			expLifetime:   time.Hour,
+			expScreenshot: true,
+			expDownloads:  true,
 			expVnc:        true,
 			expTestName:   "my-test",
 			expEnvs:       []string{"a=b"},
-			expRaw:        fullW3C,
+			expRaw:        fullW3CSelected,
+		},
+		{
+			name:        "W3C firstMatch second alternative",
+			input:       firstMatchW3C,
+			match:       func(caps capabilities.Capabilities) bool { return caps.GetName() == "firefox" },
+			expName:     "firefox",
+			expVersion:  "119.0",
+			expTestName: "my-test",
+			expRaw:      firstMatchW3CFirefox,
+		},
+		{
+			name:          "W3C firstMatch no alternative matched",
+			input:         firstMatchW3C,
+			match:         func(caps capabilities.Capabilities) bool { return false },
+			expName:       "chrome",
+			expResolution: "1920x1080x24",
+			expTestName:   "my-test",
+			expRaw:        firstMatchW3CChrome,
 		},
 		{
 			name:    "W3C minimal",
@@ -180,6 +333,23 @@ func TestNewCapabilities(t *testing.T) {
 			input:   `{"capabilities":{"alwaysMatch":{"selenoid:options":{"screenResolution":"1920x1080"}}}}`,
 			wantErr: true,
 		},
+		{
+			name:        "W3C default capabilities",
+			input:       defaultsW3C,
+			defaults:    testDefaults,
+			expName:     "chrome",
+			expVnc:      true,
+			expTestName: "my-test",
+			expRaw:      defaultsW3CMerged,
+		},
+		{
+			name:     "JsonWire default capabilities",
+			input:    defaultsJsonWire,
+			defaults: testDefaults,
+			expName:  "chrome",
+			expVnc:   true,
+			expRaw:   defaultsJsonWireMerged,
+		},
 		{
 			name:          "JsonWire full",
 			input:         fullJsonWire,
@@ -212,7 +382,7 @@ func TestNewCapabilities(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
-			got, err := capabilities.NewCapabilities(strings.NewReader(tt.input), tt.defProxy)
+			got, err := capabilities.NewCapabilities(strings.NewReader(tt.input), tt.defProxy, tt.match, tt.defaults)
 			if tt.wantErr {
 				g.Expect(err).To(HaveOccurred())
 			} else {
@@ -224,6 +394,11 @@ func TestNewCapabilities(t *testing.T) {
 				g.Expect(got.GetResolution()).To(Equal(tt.expResolution))
 				g.Expect(got.GetFlavor()).To(Equal(tt.expFlavor))
 				g.Expect(got.GetTimeout()).To(Equal(tt.expTimeout))
+				g.Expect(got.GetMaxLifetime()).To(Equal(tt.expLifetime))
+				wc, ok := got.(capabilities.WDCapabilities)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(wc.IsFinalScreenshotEnabled()).To(Equal(tt.expScreenshot))
+				g.Expect(wc.IsDownloadsEnabled()).To(Equal(tt.expDownloads))
 				g.Expect(got.IsVNCEnabled()).To(Equal(tt.expVnc))
 				g.Expect(got.GetTestName()).To(Equal(tt.expTestName))
 				g.Expect(got.GetEnvs()).To(Equal(tt.expEnvs))
@@ -25,7 +25,27 @@ func ParseCmdLine(f *pflag.FlagSet, args []string) (*pflag.FlagSet, bool, error)
 
 	f.Duration(createTimeout, 3*time.Minute, "Timeout for create session requests")
 	f.Duration(defaultSessTimeout, 3*time.Minute, "Default Webdriver idle session timeout")
-	f.Duration(maxSessTimeout, 1*time.Hour, "Maximum Webdriver idle session timeout")
+	f.Duration(pwSessTimeout, 0, "Default Playwright and Puppeteer idle session timeout, applied when no websocket"+
+		" traffic is seen for this long (disabled if set to zero)")
+	f.Duration(maxSessTimeout, 1*time.Hour, "Maximum idle session timeout")
+	f.Duration(maxSessLifetime, 0, "Maximum session lifetime regardless of activity,"+
+		" it's also an upper bound for maxSessionLifetime capability (disabled if set to zero)")
+	f.Duration(cmdTimeout, 0, "Timeout for proxied Webdriver commands, timed out commands fail with W3C timeout error"+
+		" (disabled if set to zero)")
+	f.StringSlice(cmdTimeoutOverrides, []string{}, "Per-command timeouts overriding --"+cmdTimeout+
+		" as \"METHOD command=duration\", where command is a path relative to session with \"*\" matching any single"+
+		" path segment, e.g. \"POST url=5m,POST execute/async=2m,POST element/*/click=30s\"")
+	f.Int(cmdTimeoutKillAfter, 0, "Terminate Webdriver session after the number of consecutive command timeouts"+
+		" (disabled if set to zero)")
+	f.Bool(cmdLog, false, "Record proxied Webdriver commands of all sessions, "+
+		"otherwise commands are recorded for sessions with commandLog capability only")
+	f.String(cmdLogDir, filepath.Join(os.TempDir(), "selebrow", "commands"),
+		"Directory command logs are persisted to when session is deleted")
+	f.String(artifactsDir, filepath.Join(os.TempDir(), "selebrow", "artifacts"),
+		"Directory session artifacts (e.g. final screenshots) are stored in")
+	f.Duration(artifactsRetention, 24*time.Hour, "How long session artifacts are kept")
+	f.Bool(archiveDownloads, false, "Save files downloaded by Webdriver session to artifacts as zip archive"+
+		" when session is deleted")
 	f.Int(createRetries, 5, "Number of retries on transient errors, when creating browser pods (kubernetes backend only)")
 	f.Duration(connectTimeout, 200*time.Millisecond, "Browser connection timeout")
 	f.String(browsersURI, defaultBrowsersURI, "Path or URL to browsers YAML config file")
@@ -35,6 +55,9 @@ func ParseCmdLine(f *pflag.FlagSet, args []string) (*pflag.FlagSet, bool, error)
 	f.Int(poolMaxIdle, 5, "Maximum number of idle browsers in the pool (pool is disabled if set to zero)")
 	f.Duration(poolIdleTimeout, 1*time.Minute, "Timeout idle browsers in the pool")
 	f.Duration(poolMaxAge, 15*time.Minute, "Maximum browser age before it's evicted from the pool")
+	f.Duration(poolHealthCheck, time.Second, "Timeout for health check of idle browsers on checkout from the pool"+
+		" (health check is disabled if set to zero)")
+	f.Duration(poolResetTimeout, 30*time.Second, "Timeout for resetting browser before it's returned to the pool")
 
 	f.String(dockerNetwork, "", "Docker network for browser containers (docker backend only)")
 	f.Bool(dockerPrivileged, false, "Run browser docker containers in privileged mode (docker backend only)")
@@ -48,6 +71,10 @@ func ParseCmdLine(f *pflag.FlagSet, args []string) (*pflag.FlagSet, bool, error)
 	f.Int(queueSize, 25, "Queue size for requests waiting for available quota, if set to 0, queue is disabled")
 	f.Duration(queueTimeout, time.Minute, "Timeout to wait for available quota (when queue is enabled)")
 
+	f.Duration(shutdownTimeout, 5*time.Second, "Timeout for graceful shutdown, browsers still running after drain are terminated")
+	f.Duration(drainTimeout, 25*time.Second, "Grace period for running sessions to complete on shutdown, new sessions are rejected"+
+		" while draining (draining on shutdown is disabled if set to zero)")
+
 	f.String(vncPassword, DefaultVNCPassword, "VNC password to be used when connecting to VNC via UI")
 
 	f.String(proxyHost, "", "Proxy host:port to use for browser connections")
@@ -60,6 +87,20 @@ func ParseCmdLine(f *pflag.FlagSet, args []string) (*pflag.FlagSet, bool, error)
 
 	f.String(imageProxyRegistry, "", "Docker image proxy registry to use for browser images")
 
+	f.String(tracingExporter, string(TracingExporterNone), "OpenTelemetry traces exporter, valid options are: "+
+		validTracingExportersHelp)
+	f.String(tracingEndpoint, "", "OTLP collector endpoint URL, standard OTEL_EXPORTER_OTLP_* environment variables"+
+		" are used if not set (otlp exporters only)")
+	f.String(tracingFile, "", "Path to the file traces are appended to (file exporter only)")
+	f.Float64(tracingSampleRatio, 1, "Ratio of sampled traces, sampling decision of the client is honored"+
+		" when trace context is propagated")
+
+	f.String(eventFile, "", "Path to the file session events are appended to as JSON lines (disabled if not set)")
+	f.Bool(eventStdout, false, "Write session events to stdout as JSON lines")
+	f.StringSlice(eventWebhooks, []string{}, "Webhook URLs session events are POSTed to as JSON")
+	f.Int(eventWebhookRetries, 3, "Number of retries for failed webhook deliveries")
+	f.Duration(eventWebhookTimeout, 5*time.Second, "Timeout for a single webhook delivery attempt")
+
 	if err := f.Parse(args); err != nil {
 		return nil, true, err
 	}
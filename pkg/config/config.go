@@ -22,6 +22,7 @@ var (
 type (
 	BackendType     string
 	PortMappingMode string
+	TracingExporter string
 
 	ProxyHostFunc func() string
 )
@@ -35,6 +36,12 @@ const (
 	PortMappingEnabled  PortMappingMode = "enabled"
 	PortMappingDisabled PortMappingMode = "disabled"
 
+	TracingExporterNone     TracingExporter = "none"
+	TracingExporterOTLPHTTP TracingExporter = "otlp-http"
+	TracingExporterOTLPGRPC TracingExporter = "otlp-grpc"
+	TracingExporterStdout   TracingExporter = "stdout"
+	TracingExporterFile     TracingExporter = "file"
+
 	DefaultListen      = "0.0.0.0:4444"
 	DefaultLocalListen = "127.0.0.1:4444"
 
@@ -45,11 +52,23 @@ const (
 	createTimeout       = "create-timeout"
 	defaultSessTimeout  = "default-session-timeout"
 	maxSessTimeout      = "max-session-timeout"
+	maxSessLifetime     = "max-session-lifetime"
+	pwSessTimeout       = "pw-session-timeout"
+	cmdTimeout          = "command-timeout"
+	cmdTimeoutOverrides = "command-timeout-overrides"
+	cmdTimeoutKillAfter = "command-timeout-kill-after"
+	cmdLog              = "command-log"
+	cmdLogDir           = "command-log-dir"
+	artifactsDir        = "artifacts-dir"
+	artifactsRetention  = "artifacts-retention"
+	archiveDownloads    = "archive-downloads"
 	createRetries       = "create-retries"
 	connectTimeout      = "connect-timeout"
 	poolMaxIdle         = "pool-max-idle"
 	poolMaxAge          = "pool-max-age"
 	poolIdleTimeout     = "pool-idle-timeout"
+	poolHealthCheck     = "pool-health-check-timeout"
+	poolResetTimeout    = "pool-reset-timeout"
 	kubeTemplatesPath   = "kube-templates-path"
 	browsersURI         = "browsers-uri"
 	fallbackBrowsersURI = "fallback-browsers-uri"
@@ -65,6 +84,9 @@ const (
 	queueSize    = "queue-size"
 	queueTimeout = "queue-timeout"
 
+	shutdownTimeout = "shutdown-timeout"
+	drainTimeout    = "drain-timeout"
+
 	ui          = "ui"
 	vncPassword = "vnc-password"
 
@@ -78,6 +100,17 @@ const (
 
 	imageProxyRegistry = "image-proxy-registry"
 
+	tracingExporter    = "tracing-exporter"
+	tracingEndpoint    = "tracing-endpoint"
+	tracingFile        = "tracing-file"
+	tracingSampleRatio = "tracing-sample-ratio"
+
+	eventFile           = "event-file"
+	eventStdout         = "event-stdout"
+	eventWebhooks       = "event-webhooks"
+	eventWebhookRetries = "event-webhook-retries"
+	eventWebhookTimeout = "event-webhook-timeout"
+
 	defaultConfigPath  = "config/"
 	defaultBrowsersURI = defaultConfigPath + "browsers.yaml"
 )
@@ -94,6 +127,15 @@ var (
 	validPortMappingModes     = []PortMappingMode{PortMappingAuto, PortMappingEnabled, PortMappingDisabled}
 	validPortMappingModesHelp = quoteStrings(validPortMappingModes)
 
+	validTracingExporters = []TracingExporter{
+		TracingExporterNone,
+		TracingExporterOTLPHTTP,
+		TracingExporterOTLPGRPC,
+		TracingExporterStdout,
+		TracingExporterFile,
+	}
+	validTracingExportersHelp = quoteStrings(validTracingExporters)
+
 	genLineage = uuid.NewString
 )
 
@@ -108,7 +150,40 @@ type (
 		CreateTimeout() time.Duration
 		DefaultSessionTimeout() time.Duration
 		MaxSessionTimeout() time.Duration
+		MaxSessionLifetime() time.Duration
 		ProxyDelete() bool
+		// ArchiveDownloads returns true if downloaded files should be saved to artifacts when session is deleted
+		ArchiveDownloads() bool
+	}
+
+	CommandTimeoutConfig interface {
+		// CommandTimeout returns timeout for proxied Webdriver commands, zero means no timeout
+		CommandTimeout() time.Duration
+		// CommandTimeoutOverrides returns timeouts by command route keyed as method and path pattern relative to session,
+		// where "*" matches any single path segment, e.g. "POST url" or "POST element/*/click"
+		CommandTimeoutOverrides() map[string]time.Duration
+		// CommandTimeoutKillAfter returns number of consecutive command timeouts session is terminated after,
+		// zero means session is never terminated
+		CommandTimeoutKillAfter() int
+	}
+
+	CommandLogConfig interface {
+		CommandLog() bool
+		CommandLogDir() string
+	}
+
+	ArtifactsConfig interface {
+		// ArtifactsDir returns directory session artifacts e.g. final screenshots are stored in
+		ArtifactsDir() string
+		// ArtifactsRetention returns how long artifacts are kept after session is deleted
+		ArtifactsRetention() time.Duration
+	}
+
+	PWSessionConfig interface {
+		CreateTimeout() time.Duration
+		PWSessionTimeout() time.Duration
+		MaxSessionTimeout() time.Duration
+		MaxSessionLifetime() time.Duration
 	}
 
 	KubeConfig interface {
@@ -128,6 +203,8 @@ type (
 		MaxAge() time.Duration
 		MaxIdle() int
 		IdleTimeout() time.Duration
+		HealthCheckTimeout() time.Duration
+		ResetTimeout() time.Duration
 	}
 
 	DockerConfig interface {
@@ -159,15 +236,36 @@ type (
 		ProxyResolveHost() bool
 	}
 
+	TracingConfig interface {
+		TracingExporter() TracingExporter
+		TracingEndpoint() string
+		TracingFile() string
+		TracingSampleRatio() float64
+	}
+
+	EventSinksConfig interface {
+		EventFile() string
+		EventStdout() bool
+		EventWebhooks() []string
+		EventWebhookRetries() int
+		EventWebhookTimeout() time.Duration
+	}
+
 	Config interface {
 		BrowserConfig
 		WDSessionConfig
+		PWSessionConfig
+		CommandTimeoutConfig
+		CommandLogConfig
+		ArtifactsConfig
 		KubeConfig
 		CIConfig
 		PoolConfig
 		DockerConfig
 		QuotaConfig
 		ProxyConfig
+		TracingConfig
+		EventSinksConfig
 		Listen() string
 		Backend() BackendType
 		BrowsersURI() []string
@@ -175,6 +273,8 @@ type (
 		UI() bool
 		VNCPassword() string
 		ImageProxyRegistry() string
+		ShutdownTimeout() time.Duration
+		DrainTimeout() time.Duration
 	}
 
 	ConfigViper struct {
@@ -184,6 +284,8 @@ type (
 		projectName       string
 		backend           BackendType
 		dockerPortMapping PortMappingMode
+		tracingExporter   TracingExporter
+		cmdTimeouts       map[string]time.Duration
 		lineage           string
 	}
 )
@@ -208,6 +310,24 @@ func NewConfig(v *viper.Viper, f *pflag.FlagSet) (*ConfigViper, error) {
 			validPortMappingModesHelp)
 	}
 
+	exporter := TracingExporter(strings.ToLower(v.GetString(tracingExporter)))
+	if exporter == "" {
+		exporter = TracingExporterNone
+	}
+	if !slices.Contains(validTracingExporters, exporter) {
+		return nil, errors.Errorf("invalid tracing exporter specified (%s), valid options are: %s",
+			exporter,
+			validTracingExportersHelp)
+	}
+	if exporter == TracingExporterFile && v.GetString(tracingFile) == "" {
+		return nil, errors.Errorf("--%s must be set for %s tracing exporter", tracingFile, exporter)
+	}
+
+	cmdTimeouts, err := parseCommandTimeouts(v.GetStringSlice(cmdTimeoutOverrides))
+	if err != nil {
+		return nil, err
+	}
+
 	return &ConfigViper{
 		v:                 v,
 		jobID:             os.Getenv("CI_JOB_ID"),
@@ -215,6 +335,8 @@ func NewConfig(v *viper.Viper, f *pflag.FlagSet) (*ConfigViper, error) {
 		projectName:       os.Getenv("CI_PROJECT_NAME"),
 		backend:           back,
 		dockerPortMapping: portMapping,
+		tracingExporter:   exporter,
+		cmdTimeouts:       cmdTimeouts,
 		lineage:           genLineage(),
 	}, nil
 }
@@ -287,6 +409,14 @@ func (c *ConfigViper) IdleTimeout() time.Duration {
 	return c.v.GetDuration(poolIdleTimeout)
 }
 
+func (c *ConfigViper) HealthCheckTimeout() time.Duration {
+	return c.v.GetDuration(poolHealthCheck)
+}
+
+func (c *ConfigViper) ResetTimeout() time.Duration {
+	return c.v.GetDuration(poolResetTimeout)
+}
+
 func (c *ConfigViper) JobID() string {
 	return c.jobID
 }
@@ -319,10 +449,50 @@ func (c *ConfigViper) DefaultSessionTimeout() time.Duration {
 	return c.v.GetDuration(defaultSessTimeout)
 }
 
+func (c *ConfigViper) PWSessionTimeout() time.Duration {
+	return c.v.GetDuration(pwSessTimeout)
+}
+
 func (c *ConfigViper) MaxSessionTimeout() time.Duration {
 	return c.v.GetDuration(maxSessTimeout)
 }
 
+func (c *ConfigViper) MaxSessionLifetime() time.Duration {
+	return c.v.GetDuration(maxSessLifetime)
+}
+
+func (c *ConfigViper) CommandTimeout() time.Duration {
+	return c.v.GetDuration(cmdTimeout)
+}
+
+func (c *ConfigViper) CommandTimeoutOverrides() map[string]time.Duration {
+	return c.cmdTimeouts
+}
+
+func (c *ConfigViper) CommandTimeoutKillAfter() int {
+	return c.v.GetInt(cmdTimeoutKillAfter)
+}
+
+func (c *ConfigViper) CommandLog() bool {
+	return c.v.GetBool(cmdLog)
+}
+
+func (c *ConfigViper) CommandLogDir() string {
+	return c.v.GetString(cmdLogDir)
+}
+
+func (c *ConfigViper) ArtifactsDir() string {
+	return c.v.GetString(artifactsDir)
+}
+
+func (c *ConfigViper) ArtifactsRetention() time.Duration {
+	return c.v.GetDuration(artifactsRetention)
+}
+
+func (c *ConfigViper) ArchiveDownloads() bool {
+	return c.v.GetBool(archiveDownloads)
+}
+
 func (c *ConfigViper) CreateRetries() int {
 	return c.v.GetInt(createRetries)
 }
@@ -359,6 +529,14 @@ func (c *ConfigViper) VNCPassword() string {
 	return c.v.GetString(vncPassword)
 }
 
+func (c *ConfigViper) ShutdownTimeout() time.Duration {
+	return c.v.GetDuration(shutdownTimeout)
+}
+
+func (c *ConfigViper) DrainTimeout() time.Duration {
+	return c.v.GetDuration(drainTimeout)
+}
+
 func (c *ConfigViper) ProxyOpts(defaultProxyHostFn ProxyHostFunc) (*ProxyOpts, error) {
 	var err error
 	host := c.v.GetString(proxyHost)
@@ -417,6 +595,22 @@ func (c *ConfigViper) ImageProxyRegistry() string {
 	return c.v.GetString(imageProxyRegistry)
 }
 
+func (c *ConfigViper) TracingExporter() TracingExporter {
+	return c.tracingExporter
+}
+
+func (c *ConfigViper) TracingEndpoint() string {
+	return c.v.GetString(tracingEndpoint)
+}
+
+func (c *ConfigViper) TracingFile() string {
+	return c.v.GetString(tracingFile)
+}
+
+func (c *ConfigViper) TracingSampleRatio() float64 {
+	return c.v.GetFloat64(tracingSampleRatio)
+}
+
 func bindEnvVars(v *viper.Viper) error {
 	v.AutomaticEnv()
 	v.SetEnvKeyReplacer(envReplacer)
@@ -452,3 +646,43 @@ func ZapLogLevel(strLevel string, defaultLevel zapcore.Level) zapcore.Level {
 	}
 	return defaultLevel
 }
+
+func (c *ConfigViper) EventFile() string {
+	return c.v.GetString(eventFile)
+}
+
+func (c *ConfigViper) EventStdout() bool {
+	return c.v.GetBool(eventStdout)
+}
+
+func (c *ConfigViper) EventWebhooks() []string {
+	return c.v.GetStringSlice(eventWebhooks)
+}
+
+func (c *ConfigViper) EventWebhookRetries() int {
+	return c.v.GetInt(eventWebhookRetries)
+}
+
+func (c *ConfigViper) EventWebhookTimeout() time.Duration {
+	return c.v.GetDuration(eventWebhookTimeout)
+}
+
+func parseCommandTimeouts(overrides []string) (map[string]time.Duration, error) {
+	res := make(map[string]time.Duration, len(overrides))
+	for _, o := range overrides {
+		kv := strings.SplitN(o, "=", 2)
+		route := []string{}
+		if len(kv) == 2 {
+			route = strings.Fields(kv[0])
+		}
+		if len(route) != 2 || strings.Trim(route[1], "/") == "" {
+			return nil, errors.Errorf("malformed --%s value %s (\"METHOD command=duration\" expected)", cmdTimeoutOverrides, o)
+		}
+		d, err := time.ParseDuration(kv[1])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid --%s duration for command %s", cmdTimeoutOverrides, kv[0])
+		}
+		res[strings.ToUpper(route[0])+" "+strings.Trim(route[1], "/")] = d
+	}
+	return res, nil
+}
@@ -38,6 +38,35 @@ func TestNewConfig(t *testing.T) {
 			args:    []string{"--backend", "docker", "--docker-port-mapping", "qwe"},
 			wantErr: true,
 		},
+		{
+			name: "tracing file exporter",
+			args: []string{"--backend", "auto", "--docker-port-mapping", "auto", "--tracing-exporter", "FILE", "--tracing-file", "t.json"},
+		},
+		{
+			name:    "tracing file exporter without file",
+			args:    []string{"--backend", "auto", "--docker-port-mapping", "auto", "--tracing-exporter", "file"},
+			wantErr: true,
+		},
+		{
+			name:    "incorrect tracing exporter",
+			args:    []string{"--backend", "auto", "--docker-port-mapping", "auto", "--tracing-exporter", "zipkin"},
+			wantErr: true,
+		},
+		{
+			name:    "malformed command timeout override",
+			args:    []string{"--backend", "auto", "--docker-port-mapping", "auto", "--command-timeout-overrides", "url"},
+			wantErr: true,
+		},
+		{
+			name:    "command timeout override without method",
+			args:    []string{"--backend", "auto", "--docker-port-mapping", "auto", "--command-timeout-overrides", "url=5m"},
+			wantErr: true,
+		},
+		{
+			name:    "incorrect command timeout override duration",
+			args:    []string{"--backend", "auto", "--docker-port-mapping", "auto", "--command-timeout-overrides", "POST url=5"},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -46,6 +75,9 @@ func TestNewConfig(t *testing.T) {
 			f := pflag.NewFlagSet("test", pflag.ContinueOnError)
 			f.String(backend, "", "")
 			f.String(dockerPortMapping, "", "")
+			f.String(tracingExporter, "", "")
+			f.String(tracingFile, "", "")
+			f.StringSlice(cmdTimeoutOverrides, nil, "")
 
 			err := f.Parse(tt.args)
 			g.Expect(err).ToNot(HaveOccurred())
@@ -82,7 +114,10 @@ func TestConfigViper(t *testing.T) {
 	v.Set("cluster-mode-out", true)
 	v.Set("pool-max-age", 2*time.Minute)
 	v.Set("pool-idle-timeout", 23*time.Second)
+	v.Set("pool-health-check-timeout", 3*time.Second)
+	v.Set("pool-reset-timeout", 7*time.Second)
 	v.Set("create-timeout", 3*time.Minute)
+	v.Set("pw-session-timeout", 4*time.Minute)
 	v.Set("connect-timeout", 5*time.Minute)
 	v.Set("kube-config", "/asd")
 
@@ -101,6 +136,28 @@ func TestConfigViper(t *testing.T) {
 
 	v.Set("vnc-password", "12345")
 
+	v.Set(shutdownTimeout, 7*time.Second)
+	v.Set(drainTimeout, "2m")
+	v.Set(maxSessLifetime, "45m")
+	v.Set(cmdTimeout, "1m")
+	v.Set(cmdTimeoutOverrides, []string{"POST url=5m", "post /execute/async/=2m", "POST element/*/click=30s"})
+	v.Set(cmdTimeoutKillAfter, "3")
+	v.Set(cmdLog, true)
+	v.Set(cmdLogDir, "/var/log/commands")
+	v.Set(artifactsDir, "/var/lib/artifacts")
+	v.Set(artifactsRetention, "48h")
+	v.Set(archiveDownloads, true)
+
+	v.Set(tracingExporter, "otlp-grpc")
+	v.Set(tracingEndpoint, "http://collector:4317")
+	v.Set(tracingSampleRatio, "0.5")
+
+	v.Set(eventFile, "events.jsonl")
+	v.Set(eventStdout, true)
+	v.Set(eventWebhooks, []string{"http://hook1", "http://hook2"})
+	v.Set(eventWebhookRetries, "7")
+	v.Set(eventWebhookTimeout, "3s")
+
 	v.Set(proxyEnabled, true)
 	v.Set(proxyListen, ":8088")
 	v.Set(proxyAccessLogLevel, "inFO")
@@ -133,7 +190,10 @@ func TestConfigViper(t *testing.T) {
 	g.Expect(cfg.ProxyDelete()).To(BeTrue())
 	g.Expect(cfg.MaxAge()).To(Equal(2 * time.Minute))
 	g.Expect(cfg.IdleTimeout()).To(Equal(23 * time.Second))
+	g.Expect(cfg.HealthCheckTimeout()).To(Equal(3 * time.Second))
+	g.Expect(cfg.ResetTimeout()).To(Equal(7 * time.Second))
 	g.Expect(cfg.CreateTimeout()).To(Equal(3 * time.Minute))
+	g.Expect(cfg.PWSessionTimeout()).To(Equal(4 * time.Minute))
 	g.Expect(cfg.ConnectTimeout()).To(Equal(5 * time.Minute))
 	g.Expect(cfg.KubeConfig()).To(Equal("/asd"))
 	g.Expect(cfg.KubeTemplatesPath()).To(Equal("qqq/"))
@@ -153,6 +213,30 @@ func TestConfigViper(t *testing.T) {
 
 	g.Expect(cfg.UI()).To(BeTrue())
 	g.Expect(cfg.VNCPassword()).To(Equal("12345"))
+	g.Expect(cfg.ShutdownTimeout()).To(Equal(7 * time.Second))
+	g.Expect(cfg.DrainTimeout()).To(Equal(2 * time.Minute))
+	g.Expect(cfg.MaxSessionLifetime()).To(Equal(45 * time.Minute))
+	g.Expect(cfg.CommandTimeout()).To(Equal(time.Minute))
+	g.Expect(cfg.CommandTimeoutOverrides()).To(Equal(map[string]time.Duration{
+		"POST url":             5 * time.Minute,
+		"POST execute/async":   2 * time.Minute,
+		"POST element/*/click": 30 * time.Second,
+	}))
+	g.Expect(cfg.CommandTimeoutKillAfter()).To(Equal(3))
+	g.Expect(cfg.CommandLog()).To(BeTrue())
+	g.Expect(cfg.CommandLogDir()).To(Equal("/var/log/commands"))
+	g.Expect(cfg.ArtifactsDir()).To(Equal("/var/lib/artifacts"))
+	g.Expect(cfg.ArtifactsRetention()).To(Equal(48 * time.Hour))
+	g.Expect(cfg.ArchiveDownloads()).To(BeTrue())
+	g.Expect(cfg.TracingExporter()).To(Equal(TracingExporterOTLPGRPC))
+	g.Expect(cfg.TracingEndpoint()).To(Equal("http://collector:4317"))
+	g.Expect(cfg.TracingFile()).To(BeEmpty())
+	g.Expect(cfg.TracingSampleRatio()).To(Equal(0.5))
+	g.Expect(cfg.EventFile()).To(Equal("events.jsonl"))
+	g.Expect(cfg.EventStdout()).To(BeTrue())
+	g.Expect(cfg.EventWebhooks()).To(Equal([]string{"http://hook1", "http://hook2"}))
+	g.Expect(cfg.EventWebhookRetries()).To(Equal(7))
+	g.Expect(cfg.EventWebhookTimeout()).To(Equal(3 * time.Second))
 
 	g.Expect(cfg.ProxyEnabled()).To(BeTrue())
 	g.Expect(cfg.ProxyListen()).To(Equal(":8088"))
@@ -32,7 +32,9 @@ type DockerClient interface {
 	ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error)
 	ContainerRemove(ctx context.Context, containerID string, force bool) error
 	ContainerList(ctx context.Context) ([]container.Summary, error)
+	ContainerExec(ctx context.Context, containerID string, cmd []string) (int, string, error)
 	AvailableResources(ctx context.Context) (cpus int, memory int64, err error)
+	Ping(ctx context.Context) error
 }
 
 const defaultDockerHost = "127.0.0.1"
@@ -82,6 +84,11 @@ func (c *DockerClientImpl) AvailableResources(ctx context.Context) (cpus int, me
 	return info.Info.NCPU, info.Info.MemTotal, nil
 }
 
+func (c *DockerClientImpl) Ping(ctx context.Context) error {
+	_, err := c.dockerCli.Ping(ctx, client.PingOptions{})
+	return err
+}
+
 func getHostOnly(hostPort string) string {
 	// no port
 	if strings.LastIndexByte(hostPort, ':') < 0 {
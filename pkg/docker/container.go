@@ -1,8 +1,10 @@
 package docker
 
 import (
+	"bytes"
 	"context"
 
+	"github.com/moby/moby/api/pkg/stdcopy"
 	"github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/network"
 	"github.com/moby/moby/client"
@@ -69,3 +71,32 @@ func (c *DockerClientImpl) ContainerList(ctx context.Context) ([]container.Summa
 	}
 	return res.Items, nil
 }
+
+// ContainerExec Execute command in container and wait for its completion, returns exit code and combined output
+func (c *DockerClientImpl) ContainerExec(ctx context.Context, containerID string, cmd []string) (int, string, error) {
+	created, err := c.dockerCli.ExecCreate(ctx, containerID, client.ExecCreateOptions{
+		AttachStdout: true,
+		AttachStderr: true,
+		Cmd:          cmd,
+	})
+	if err != nil {
+		return 0, "", err
+	}
+
+	attached, err := c.dockerCli.ExecAttach(ctx, created.ID, client.ExecAttachOptions{})
+	if err != nil {
+		return 0, "", err
+	}
+	defer attached.Close()
+
+	var out bytes.Buffer
+	if _, err := stdcopy.StdCopy(&out, &out, attached.Reader); err != nil {
+		return 0, "", err
+	}
+
+	res, err := c.dockerCli.ExecInspect(ctx, created.ID, client.ExecInspectOptions{})
+	if err != nil {
+		return 0, "", err
+	}
+	return res.ExitCode, out.String(), nil
+}
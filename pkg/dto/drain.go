@@ -0,0 +1,6 @@
+package dto
+
+type DrainStatus struct {
+	Draining bool `json:"draining"`
+	Sessions int  `json:"sessions"`
+}
@@ -0,0 +1,29 @@
+package dto
+
+type Pool struct {
+	Name      string        `json:"name"`
+	Protocol  string        `json:"protocol"`
+	Browser   string        `json:"browser"`
+	CapsHash  string        `json:"capsHash"`
+	IdleCount int           `json:"idleCount"`
+	Idle      []IdleBrowser `json:"idle"`
+	Stats     PoolStats     `json:"stats"`
+}
+
+type IdleBrowser struct {
+	ID       string `json:"id"`
+	URL      string `json:"url"`
+	Age      string `json:"age"`
+	IdleTime string `json:"idleTime"`
+}
+
+type PoolStats struct {
+	Hits                uint64 `json:"hits"`
+	Misses              uint64 `json:"misses"`
+	Evictions           uint64 `json:"evictions"`
+	HealthCheckFailures uint64 `json:"healthCheckFailures"`
+}
+
+type PoolDrain struct {
+	Drained int `json:"drained"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type Session struct {
+	ID           string            `json:"id"`
+	Protocol     string            `json:"protocol"`
+	Platform     string            `json:"platform"`
+	Browser      string            `json:"browser"`
+	Version      string            `json:"version"`
+	Flavor       string            `json:"flavor,omitempty"`
+	TestName     string            `json:"testName,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	Capabilities json.RawMessage   `json:"capabilities,omitempty"`
+	Created      time.Time         `json:"created"`
+	LastUsed     time.Time         `json:"lastUsed,omitzero"`
+	Idle         string            `json:"idle"`
+	Timeout      string            `json:"timeout,omitempty"`
+	Backend      string            `json:"backend"`
+	BackendID    string            `json:"backendId"`
+	URL          string            `json:"url"`
+	Ports        map[string]bool   `json:"ports"`
+}
@@ -2,70 +2,209 @@ package event
 
 import (
 	"context"
+	"path"
+	"slices"
+	"strings"
 	"sync"
+	"time"
 
 	"go.uber.org/zap"
 
 	"github.com/selebrow/selebrow/pkg/event/models"
 )
 
+// OverflowPolicy defines what happens to published event when subscriber's buffer is full
+type OverflowPolicy int
+
+const (
+	// DropNewest drops the event being published
+	DropNewest OverflowPolicy = iota
+	// DropOldest drops the oldest buffered event to make room for the event being published
+	DropOldest
+	// Block blocks publisher until there is room in the buffer or timeout expires, the event is dropped on timeout
+	Block
+)
+
+// AllEvents subscribes to all event types
+const AllEvents = "*"
+
+type SubscriptionOptions struct {
+	// BufferSize is the size of subscription channel buffer, broker default is used if not set
+	BufferSize int
+	Overflow   OverflowPolicy
+	// BlockTimeout is maximum time publisher is blocked for Block overflow policy
+	BlockTimeout time.Duration
+}
+
 type EventBroker interface {
+	// Subscribe subscribes to the given event types with default options,
+	// event type may be a wildcard pattern e.g. "Session*" or "*"
 	Subscribe(eventTypes ...string) <-chan models.IEvent
+	SubscribeWithOptions(opts SubscriptionOptions, eventTypes ...string) <-chan models.IEvent
+	Unsubscribe(ch <-chan models.IEvent)
 	Publish(event models.IEvent)
 }
 
+type subscription struct {
+	ch       chan models.IEvent
+	types    map[string]bool
+	patterns []string
+	opts     SubscriptionOptions
+	// done is closed on unsubscribe to release publishers blocked on the channel
+	done   chan struct{}
+	mtx    sync.RWMutex
+	closed bool
+}
+
+// close releases blocked publishers and closes the channel once in-flight sends are completed
+func (s *subscription) close() {
+	close(s.done)
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.closed = true
+	close(s.ch)
+}
+
+func (s *subscription) matches(eventType string) bool {
+	if s.types[eventType] {
+		return true
+	}
+	return slices.ContainsFunc(s.patterns, func(p string) bool {
+		ok, _ := path.Match(p, eventType)
+		return ok
+	})
+}
+
 type EventBrokerImpl struct {
 	mtx   sync.RWMutex
-	subs  map[string][]chan models.IEvent
+	subs  []*subscription
 	bSize int
 	l     *zap.SugaredLogger
 }
 
 func NewEventBrokerImpl(bufferSize int, l *zap.Logger) *EventBrokerImpl {
 	return &EventBrokerImpl{
-		subs:  make(map[string][]chan models.IEvent),
 		bSize: bufferSize,
 		l:     l.Sugar(),
 	}
 }
 
 func (b *EventBrokerImpl) Subscribe(eventTypes ...string) <-chan models.IEvent {
+	return b.SubscribeWithOptions(SubscriptionOptions{}, eventTypes...)
+}
+
+func (b *EventBrokerImpl) SubscribeWithOptions(opts SubscriptionOptions, eventTypes ...string) <-chan models.IEvent {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = b.bSize
+	}
+	sub := &subscription{
+		ch:    make(chan models.IEvent, opts.BufferSize),
+		types: make(map[string]bool),
+		opts:  opts,
+		done:  make(chan struct{}),
+	}
+	for _, et := range eventTypes {
+		if strings.ContainsAny(et, "*?[") {
+			sub.patterns = append(sub.patterns, et)
+		} else {
+			sub.types[et] = true
+		}
+	}
+
 	b.mtx.Lock()
 	defer b.mtx.Unlock()
-	ch := make(chan models.IEvent, b.bSize)
-	for _, et := range eventTypes {
-		b.subs[et] = append(b.subs[et], ch)
+	b.subs = append(b.subs, sub)
+	return sub.ch
+}
+
+// Unsubscribe removes channel from all subscriptions and closes it
+func (b *EventBrokerImpl) Unsubscribe(ch <-chan models.IEvent) {
+	b.mtx.Lock()
+	idx := slices.IndexFunc(b.subs, func(s *subscription) bool {
+		return s.ch == ch
+	})
+	if idx < 0 {
+		b.mtx.Unlock()
+		return
 	}
-	return ch
+	sub := b.subs[idx]
+	b.subs = slices.Delete(b.subs, idx, idx+1)
+	b.mtx.Unlock()
+
+	sub.close()
 }
 
+// Publish sends event to matching subscriptions, broker lock is not held while sending
+// so subscriber blocking publisher doesn't affect other publishers and subscriptions
 func (b *EventBrokerImpl) Publish(event models.IEvent) {
 	b.mtx.RLock()
-	defer b.mtx.RUnlock()
+	var subs []*subscription
+	for _, sub := range b.subs {
+		if sub.matches(event.EventType()) {
+			subs = append(subs, sub)
+		}
+	}
+	b.mtx.RUnlock()
+
+	for _, sub := range subs {
+		b.send(sub, event)
+	}
+}
 
-	for _, ch := range b.subs[event.EventType()] {
+func (b *EventBrokerImpl) send(sub *subscription, event models.IEvent) {
+	sub.mtx.RLock()
+	defer sub.mtx.RUnlock()
+	if sub.closed {
+		return
+	}
+
+	select {
+	case sub.ch <- event:
+		return
+	default:
+	}
+
+	l := b.l.With(zap.String("type", event.EventType()))
+	switch sub.opts.Overflow {
+	case DropOldest:
+		for {
+			select {
+			case old := <-sub.ch:
+				l.With(zap.String("dropped_type", old.EventType())).
+					Warnf("dropping oldest event, channel is full: length=%d", len(sub.ch))
+			default:
+			}
+			select {
+			case sub.ch <- event:
+				return
+			default:
+			}
+		}
+	case Block:
+		t := time.NewTimer(sub.opts.BlockTimeout)
+		defer t.Stop()
 		select {
-		case ch <- event:
-		default:
-			b.l.With(zap.String("type", event.EventType())).
-				Warnf("dropping published event, channel is full: length=%d", len(ch))
+		case sub.ch <- event:
+			return
+		case <-sub.done:
+			return
+		case <-t.C:
+			l.Warnf("dropping published event, channel is full after waiting for %v: length=%d",
+				sub.opts.BlockTimeout, len(sub.ch))
 		}
+	default:
+		l.Warnf("dropping published event, channel is full: length=%d", len(sub.ch))
 	}
 }
 
 func (b *EventBrokerImpl) ShutDown(_ context.Context) error {
 	b.mtx.Lock()
-	defer b.mtx.Unlock()
+	subs := b.subs
+	b.subs = nil
+	b.mtx.Unlock()
 
-	closed := make(map[chan models.IEvent]bool)
-	for et, chs := range b.subs {
-		for _, ch := range chs {
-			if !closed[ch] {
-				close(ch)
-				closed[ch] = true
-			}
-		}
-		delete(b.subs, et)
+	for _, sub := range subs {
+		sub.close()
 	}
 	b.l.Info("event broker shutdown completed")
 	return nil
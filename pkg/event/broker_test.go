@@ -32,3 +32,150 @@ func TestEventBrokerImpl_Subscribe(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	g.Expect(ch).To(BeClosed())
 }
+
+func TestEventBrokerImpl_Unsubscribe(t *testing.T) {
+	g := NewWithT(t)
+	b := NewEventBrokerImpl(1, zaptest.NewLogger(t))
+
+	ch1 := b.Subscribe("test1", "test2")
+	ch2 := b.Subscribe("test1")
+
+	b.Unsubscribe(ch1)
+	g.Expect(ch1).To(BeClosed())
+
+	ev := models.NewEvent("test1", time.UnixMilli(111), "event1")
+	b.Publish(ev)
+	b.Publish(models.NewEvent("test2", time.UnixMilli(122), "event2"))
+
+	var got models.IEvent
+	g.Expect(ch2).To(Receive(&got))
+	g.Expect(got).To(Equal(ev))
+
+	// second unsubscribe is no-op
+	b.Unsubscribe(ch1)
+
+	err := b.ShutDown(context.TODO())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(ch2).To(BeClosed())
+
+	// unsubscribe after shutdown must not close channel twice
+	b.Unsubscribe(ch2)
+}
+
+func TestEventBrokerImpl_SubscribeWildcard(t *testing.T) {
+	g := NewWithT(t)
+	b := NewEventBrokerImpl(10, zaptest.NewLogger(t))
+
+	all := b.Subscribe(AllEvents)
+	sess := b.Subscribe("Session*", "QuotaChanged")
+
+	ev1 := models.NewEvent("SessionCreated", time.UnixMilli(111), "event1")
+	ev2 := models.NewEvent("PoolHit", time.UnixMilli(122), "event2")
+	ev3 := models.NewEvent("QuotaChanged", time.UnixMilli(133), "event3")
+	b.Publish(ev1)
+	b.Publish(ev2)
+	b.Publish(ev3)
+
+	var got models.IEvent
+	for _, ev := range []models.IEvent{ev1, ev2, ev3} {
+		g.Expect(all).To(Receive(&got))
+		g.Expect(got).To(Equal(ev))
+	}
+	g.Expect(all).ToNot(Receive())
+
+	for _, ev := range []models.IEvent{ev1, ev3} {
+		g.Expect(sess).To(Receive(&got))
+		g.Expect(got).To(Equal(ev))
+	}
+	g.Expect(sess).ToNot(Receive())
+}
+
+func TestEventBrokerImpl_SubscribeDropOldest(t *testing.T) {
+	g := NewWithT(t)
+	b := NewEventBrokerImpl(10, zaptest.NewLogger(t))
+
+	ch := b.SubscribeWithOptions(SubscriptionOptions{BufferSize: 2, Overflow: DropOldest}, "test")
+
+	ev1 := models.NewEvent("test", time.UnixMilli(111), "event1")
+	ev2 := models.NewEvent("test", time.UnixMilli(122), "event2")
+	ev3 := models.NewEvent("test", time.UnixMilli(133), "event3")
+	b.Publish(ev1)
+	b.Publish(ev2)
+	b.Publish(ev3) // ev1 should be dropped
+
+	var got models.IEvent
+	g.Expect(ch).To(Receive(&got))
+	g.Expect(got).To(Equal(ev2))
+	g.Expect(ch).To(Receive(&got))
+	g.Expect(got).To(Equal(ev3))
+	g.Expect(ch).ToNot(Receive())
+}
+
+func TestEventBrokerImpl_SubscribeBlock(t *testing.T) {
+	g := NewWithT(t)
+	b := NewEventBrokerImpl(10, zaptest.NewLogger(t))
+
+	ch := b.SubscribeWithOptions(SubscriptionOptions{BufferSize: 1, Overflow: Block, BlockTimeout: time.Second}, "test")
+
+	ev1 := models.NewEvent("test", time.UnixMilli(111), "event1")
+	ev2 := models.NewEvent("test", time.UnixMilli(122), "event2")
+	b.Publish(ev1)
+
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		b.Publish(ev2)
+	}()
+	g.Consistently(published, 50*time.Millisecond).ShouldNot(BeClosed())
+
+	var got models.IEvent
+	g.Expect(ch).To(Receive(&got))
+	g.Expect(got).To(Equal(ev1))
+	g.Eventually(published).Should(BeClosed())
+	g.Expect(ch).To(Receive(&got))
+	g.Expect(got).To(Equal(ev2))
+}
+
+func TestEventBrokerImpl_SubscribeBlockTimeout(t *testing.T) {
+	g := NewWithT(t)
+	b := NewEventBrokerImpl(10, zaptest.NewLogger(t))
+
+	ch := b.SubscribeWithOptions(SubscriptionOptions{BufferSize: 1, Overflow: Block, BlockTimeout: 10 * time.Millisecond}, "test")
+
+	ev1 := models.NewEvent("test", time.UnixMilli(111), "event1")
+	b.Publish(ev1)
+	b.Publish(models.NewEvent("test", time.UnixMilli(122), "event2")) // should be dropped after timeout
+
+	var got models.IEvent
+	g.Expect(ch).To(Receive(&got))
+	g.Expect(got).To(Equal(ev1))
+	g.Expect(ch).ToNot(Receive())
+}
+
+func TestEventBrokerImpl_SubscribeBlockDoesNotStallBroker(t *testing.T) {
+	g := NewWithT(t)
+	b := NewEventBrokerImpl(10, zaptest.NewLogger(t))
+
+	blocked := b.SubscribeWithOptions(SubscriptionOptions{BufferSize: 1, Overflow: Block, BlockTimeout: time.Minute}, "test")
+	other := b.Subscribe("other")
+
+	b.Publish(models.NewEvent("test", time.UnixMilli(111), "event1"))
+	published := make(chan struct{})
+	go func() {
+		defer close(published)
+		b.Publish(models.NewEvent("test", time.UnixMilli(122), "event2"))
+	}()
+	g.Consistently(published, 50*time.Millisecond).ShouldNot(BeClosed())
+
+	// other publishers and subscriptions are not affected by the blocked publisher
+	ev := models.NewEvent("other", time.UnixMilli(133), "event3")
+	b.Publish(ev)
+	g.Expect(other).To(Receive(Equal(ev)))
+	_ = b.Subscribe("test")
+
+	// unsubscribe releases blocked publisher
+	b.Unsubscribe(blocked)
+	g.Eventually(published).Should(BeClosed())
+	g.Expect(blocked).To(Receive())
+	g.Expect(blocked).To(BeClosed())
+}
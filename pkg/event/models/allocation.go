@@ -0,0 +1,89 @@
+package models
+
+import (
+	"time"
+
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+const (
+	AllocationStartedEventType  = "AllocationStarted"
+	AllocationFinishedEventType = "AllocationFinished"
+	AllocationQueuedEventType   = "AllocationQueued"
+	AllocationDequeuedEventType = "AllocationDequeued"
+	PoolHitEventType            = "PoolHit"
+	PoolMissEventType           = "PoolMiss"
+	ImagePulledEventType        = "ImagePulled"
+)
+
+// BrowserRequest identifies browser being allocated, session is not known until allocation is completed,
+// so events are correlated with SessionRequested event (which has session ID) by RequestID
+type BrowserRequest struct {
+	RequestID      string                 `json:"requestId,omitempty"`
+	Protocol       models.BrowserProtocol `json:"protocol"`
+	BrowserName    string                 `json:"browserName"`
+	BrowserVersion string                 `json:"browserVersion"`
+	Labels         map[string]string      `json:"labels,omitempty"`
+}
+
+type AllocationStarted struct {
+	BrowserRequest
+}
+
+type AllocationFinished struct {
+	BrowserRequest
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// AllocationQueued is published when allocation request has to wait for available quota
+type AllocationQueued struct {
+	BrowserRequest
+	QueueSize int `json:"queueSize"`
+}
+
+// AllocationDequeued is published when queued allocation request gets quota or gives up waiting
+type AllocationDequeued struct {
+	BrowserRequest
+	WaitDuration time.Duration `json:"waitDuration"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// PoolCheckout is published on checkout from the idle browsers pool with either PoolHit or PoolMiss type
+type PoolCheckout struct {
+	BrowserRequest
+}
+
+type ImagePulled struct {
+	BrowserRequest
+	Image    string        `json:"image"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+func NewAllocationStartedEvent(a AllocationStarted) *Event[AllocationStarted] {
+	return NewEvent(AllocationStartedEventType, now(), a)
+}
+
+func NewAllocationFinishedEvent(a AllocationFinished) *Event[AllocationFinished] {
+	return NewEvent(AllocationFinishedEventType, now(), a)
+}
+
+func NewAllocationQueuedEvent(a AllocationQueued) *Event[AllocationQueued] {
+	return NewEvent(AllocationQueuedEventType, now(), a)
+}
+
+func NewAllocationDequeuedEvent(a AllocationDequeued) *Event[AllocationDequeued] {
+	return NewEvent(AllocationDequeuedEventType, now(), a)
+}
+
+func NewPoolCheckoutEvent(hit bool, p PoolCheckout) *Event[PoolCheckout] {
+	if hit {
+		return NewEvent(PoolHitEventType, now(), p)
+	}
+	return NewEvent(PoolMissEventType, now(), p)
+}
+
+func NewImagePulledEvent(i ImagePulled) *Event[ImagePulled] {
+	return NewEvent(ImagePulledEventType, now(), i)
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestNewAllocationEvents(t *testing.T) {
+	g := NewWithT(t)
+	tm := time.UnixMilli(123)
+	now = func() time.Time {
+		return tm
+	}
+
+	req := BrowserRequest{
+		Protocol:       "testproto",
+		BrowserName:    "test",
+		BrowserVersion: "1.1",
+		Labels:         map[string]string{"a": "b"},
+	}
+
+	tests := []struct {
+		event   IEvent
+		expType string
+	}{
+		{event: NewAllocationStartedEvent(AllocationStarted{BrowserRequest: req}), expType: AllocationStartedEventType},
+		{event: NewAllocationFinishedEvent(AllocationFinished{BrowserRequest: req}), expType: AllocationFinishedEventType},
+		{event: NewAllocationQueuedEvent(AllocationQueued{BrowserRequest: req}), expType: AllocationQueuedEventType},
+		{event: NewAllocationDequeuedEvent(AllocationDequeued{BrowserRequest: req}), expType: AllocationDequeuedEventType},
+		{event: NewPoolCheckoutEvent(true, PoolCheckout{BrowserRequest: req}), expType: PoolHitEventType},
+		{event: NewPoolCheckoutEvent(false, PoolCheckout{BrowserRequest: req}), expType: PoolMissEventType},
+		{event: NewImagePulledEvent(ImagePulled{BrowserRequest: req}), expType: ImagePulledEventType},
+	}
+	for _, tt := range tests {
+		g.Expect(tt.event.EventTime()).To(Equal(tm))
+		g.Expect(tt.event.EventType()).To(Equal(tt.expType))
+	}
+}
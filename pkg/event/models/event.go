@@ -1,6 +1,9 @@
 package models
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 var now = time.Now
 
@@ -30,3 +33,24 @@ func NewEvent[T any](eventType string, evTime time.Time, attributes T) *Event[T]
 		Attributes: attributes,
 	}
 }
+
+// MarshalJSON serializes event along with its type and time
+func (e *Event[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type       string    `json:"type"`
+		Time       time.Time `json:"time"`
+		Attributes T         `json:"attributes"`
+	}{
+		Type:       e.eventType,
+		Time:       e.eventTime,
+		Attributes: e.Attributes,
+	})
+}
+
+// ErrorString returns error message or empty string if err is nil
+func ErrorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
@@ -1,10 +1,12 @@
 package models
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 )
 
 func TestNewEvent(t *testing.T) {
@@ -15,3 +17,37 @@ func TestNewEvent(t *testing.T) {
 	g.Expect(e.EventTime()).To(Equal(tm))
 	g.Expect(e.Attributes).To(Equal("event1"))
 }
+
+func TestEvent_MarshalJSON(t *testing.T) {
+	g := NewWithT(t)
+
+	e := NewEvent("test", time.UnixMilli(1000).UTC(), SessionRequested{
+		Protocol:      "webdriver",
+		ID:            "123",
+		BrowserName:   "chrome",
+		StartDuration: time.Second,
+		Error:         "test error",
+	})
+
+	data, err := json.Marshal(e)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data).To(MatchJSON(`{
+		"type": "test",
+		"time": "1970-01-01T00:00:01Z",
+		"attributes": {
+			"protocol": "webdriver",
+			"id": "123",
+			"browserName": "chrome",
+			"browserVersion": "",
+			"startDuration": 1000000000,
+			"error": "test error"
+		}
+	}`))
+}
+
+func TestErrorString(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(ErrorString(nil)).To(BeEmpty())
+	g.Expect(ErrorString(errors.New("test error"))).To(Equal("test error"))
+}
@@ -0,0 +1,14 @@
+package models
+
+const QuotaChangedEventType = "QuotaChanged"
+
+type QuotaChanged struct {
+	Allocated  int `json:"allocated"`
+	Limit      int `json:"limit"`
+	QueueSize  int `json:"queueSize"`
+	QueueLimit int `json:"queueLimit"`
+}
+
+func NewQuotaChangedEvent(q QuotaChanged) *Event[QuotaChanged] {
+	return NewEvent(QuotaChangedEventType, now(), q)
+}
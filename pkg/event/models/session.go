@@ -3,27 +3,90 @@ package models
 import (
 	"time"
 
+	"github.com/pkg/errors"
+
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
 const (
-	SessionRequestedEventType = "SessionRequested"
-	SessionReleasedEventType  = "SessionReleased"
+	SessionRequestedEventType   = "SessionRequested"
+	SessionReleasedEventType    = "SessionReleased"
+	SessionCreatedEventType     = "SessionCreated"
+	SessionDeletedEventType     = "SessionDeleted"
+	SessionIdleTimeoutEventType = "SessionIdleTimeout"
+	BrowserCrashedEventType     = "BrowserCrashed"
 )
 
+// SessionRequested is published when session request is completed, RequestID correlates it with allocation events
 type SessionRequested struct {
-	Protocol       models.BrowserProtocol
-	BrowserName    string
-	BrowserVersion string
-	StartDuration  time.Duration
-	Error          error
+	Protocol       models.BrowserProtocol `json:"protocol"`
+	ID             string                 `json:"id,omitempty"`
+	RequestID      string                 `json:"requestId,omitempty"`
+	BrowserName    string                 `json:"browserName"`
+	BrowserVersion string                 `json:"browserVersion"`
+	Labels         map[string]string      `json:"labels,omitempty"`
+	StartDuration  time.Duration          `json:"startDuration"`
+	Error          string                 `json:"error,omitempty"`
+	ErrorCode      int                    `json:"errorCode,omitempty"`
+}
+
+// SetError sets error message and code (if any) of the failed request and returns the error
+func (s *SessionRequested) SetError(err error) error {
+	s.Error = err.Error()
+	var ec models.ErrorWithCode
+	if errors.As(err, &ec) {
+		s.ErrorCode = ec.Code()
+	}
+	return err
 }
 
 type SessionReleased struct {
-	Protocol        models.BrowserProtocol
-	BrowserName     string
-	BrowserVersion  string
-	SessionDuration time.Duration
+	Protocol        models.BrowserProtocol `json:"protocol"`
+	ID              string                 `json:"id"`
+	BrowserName     string                 `json:"browserName"`
+	BrowserVersion  string                 `json:"browserVersion"`
+	Labels          map[string]string      `json:"labels,omitempty"`
+	SessionDuration time.Duration          `json:"sessionDuration"`
+}
+
+// SessionCreated is published when session is added to the session storage
+type SessionCreated struct {
+	Protocol       models.BrowserProtocol `json:"protocol"`
+	ID             string                 `json:"id"`
+	BrowserName    string                 `json:"browserName"`
+	BrowserVersion string                 `json:"browserVersion"`
+	TestName       string                 `json:"testName,omitempty"`
+	VNCEnabled     bool                   `json:"vncEnabled"`
+	Labels         map[string]string      `json:"labels,omitempty"`
+	Created        time.Time              `json:"created"`
+}
+
+// SessionDeleted is published when session is removed from the session storage
+type SessionDeleted struct {
+	Protocol models.BrowserProtocol `json:"protocol"`
+	ID       string                 `json:"id"`
+	Labels   map[string]string      `json:"labels,omitempty"`
+}
+
+// SessionIdleTimeout is published when session is closed after being idle for too long
+type SessionIdleTimeout struct {
+	Protocol       models.BrowserProtocol `json:"protocol"`
+	ID             string                 `json:"id"`
+	BrowserName    string                 `json:"browserName"`
+	BrowserVersion string                 `json:"browserVersion"`
+	Labels         map[string]string      `json:"labels,omitempty"`
+	IdleDuration   time.Duration          `json:"idleDuration"`
+	Timeout        time.Duration          `json:"timeout"`
+}
+
+// BrowserCrashed is published when browser of the running session becomes unreachable
+type BrowserCrashed struct {
+	Protocol       models.BrowserProtocol `json:"protocol"`
+	ID             string                 `json:"id"`
+	BrowserName    string                 `json:"browserName"`
+	BrowserVersion string                 `json:"browserVersion"`
+	Labels         map[string]string      `json:"labels,omitempty"`
+	Error          string                 `json:"error"`
 }
 
 func NewSessionRequestedEvent(s SessionRequested) *Event[SessionRequested] {
@@ -33,3 +96,19 @@ func NewSessionRequestedEvent(s SessionRequested) *Event[SessionRequested] {
 func NewSessionReleasedEvent(s SessionReleased) *Event[SessionReleased] {
 	return NewEvent(SessionReleasedEventType, now(), s)
 }
+
+func NewSessionCreatedEvent(s SessionCreated) *Event[SessionCreated] {
+	return NewEvent(SessionCreatedEventType, now(), s)
+}
+
+func NewSessionDeletedEvent(s SessionDeleted) *Event[SessionDeleted] {
+	return NewEvent(SessionDeletedEventType, now(), s)
+}
+
+func NewSessionIdleTimeoutEvent(s SessionIdleTimeout) *Event[SessionIdleTimeout] {
+	return NewEvent(SessionIdleTimeoutEventType, now(), s)
+}
+
+func NewBrowserCrashedEvent(s BrowserCrashed) *Event[BrowserCrashed] {
+	return NewEvent(BrowserCrashedEventType, now(), s)
+}
@@ -1,10 +1,14 @@
 package models
 
 import (
+	"net/http"
 	"testing"
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/selebrow/selebrow/pkg/models"
 )
 
 func TestNewSessionRequestedEvent(t *testing.T) {
@@ -19,7 +23,7 @@ func TestNewSessionRequestedEvent(t *testing.T) {
 		BrowserName:    "test",
 		BrowserVersion: "1.1",
 		StartDuration:  time.Millisecond,
-		Error:          nil,
+		Error:          "test error",
 	}
 
 	e := NewSessionRequestedEvent(se)
@@ -49,3 +53,103 @@ func TestNewSessionReleasedEvent(t *testing.T) {
 	g.Expect(e.EventType()).To(Equal(SessionReleasedEventType))
 	g.Expect(e.Attributes).To(Equal(sr))
 }
+
+func TestNewSessionCreatedEvent(t *testing.T) {
+	g := NewWithT(t)
+	tm := time.UnixMilli(333)
+	now = func() time.Time {
+		return tm
+	}
+
+	sc := SessionCreated{
+		Protocol:       "testproto",
+		ID:             "123",
+		BrowserName:    "test",
+		BrowserVersion: "1.1",
+		TestName:       "my test",
+		VNCEnabled:     true,
+		Created:        time.UnixMilli(300),
+	}
+
+	e := NewSessionCreatedEvent(sc)
+
+	g.Expect(e.EventTime()).To(Equal(tm))
+	g.Expect(e.EventType()).To(Equal(SessionCreatedEventType))
+	g.Expect(e.Attributes).To(Equal(sc))
+}
+
+func TestNewSessionDeletedEvent(t *testing.T) {
+	g := NewWithT(t)
+	tm := time.UnixMilli(444)
+	now = func() time.Time {
+		return tm
+	}
+
+	sd := SessionDeleted{
+		Protocol: "testproto",
+		ID:       "123",
+	}
+
+	e := NewSessionDeletedEvent(sd)
+
+	g.Expect(e.EventTime()).To(Equal(tm))
+	g.Expect(e.EventType()).To(Equal(SessionDeletedEventType))
+	g.Expect(e.Attributes).To(Equal(sd))
+}
+
+func TestSessionRequested_SetError(t *testing.T) {
+	g := NewWithT(t)
+
+	sr := SessionRequested{}
+	err := errors.New("test error")
+	g.Expect(sr.SetError(err)).To(Equal(err))
+	g.Expect(sr.Error).To(Equal("test error"))
+	g.Expect(sr.ErrorCode).To(BeZero())
+
+	err = errors.Wrap(models.NewBadRequestError(errors.New("bad caps")), "create session")
+	g.Expect(sr.SetError(err)).To(Equal(err))
+	g.Expect(sr.Error).To(Equal("create session: bad caps"))
+	g.Expect(sr.ErrorCode).To(Equal(http.StatusBadRequest))
+}
+
+func TestNewSessionIdleTimeoutEvent(t *testing.T) {
+	g := NewWithT(t)
+	tm := time.UnixMilli(555)
+	now = func() time.Time {
+		return tm
+	}
+
+	st := SessionIdleTimeout{
+		Protocol:     "testproto",
+		ID:           "123",
+		Labels:       map[string]string{"a": "b"},
+		IdleDuration: time.Minute,
+		Timeout:      time.Second,
+	}
+
+	e := NewSessionIdleTimeoutEvent(st)
+
+	g.Expect(e.EventTime()).To(Equal(tm))
+	g.Expect(e.EventType()).To(Equal(SessionIdleTimeoutEventType))
+	g.Expect(e.Attributes).To(Equal(st))
+}
+
+func TestNewBrowserCrashedEvent(t *testing.T) {
+	g := NewWithT(t)
+	tm := time.UnixMilli(666)
+	now = func() time.Time {
+		return tm
+	}
+
+	bc := BrowserCrashed{
+		Protocol: "testproto",
+		ID:       "123",
+		Error:    "connection refused",
+	}
+
+	e := NewBrowserCrashedEvent(bc)
+
+	g.Expect(e.EventTime()).To(Equal(tm))
+	g.Expect(e.EventType()).To(Equal(BrowserCrashedEventType))
+	g.Expect(e.Attributes).To(Equal(bc))
+}
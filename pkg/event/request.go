@@ -0,0 +1,17 @@
+package event
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID returns context carrying ID of the session request, allocation events published while serving
+// the request have the same RequestID as its SessionRequested event, which in turn has ID of the created session
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// ContextRequestID returns session request ID carried by the context or empty string if there is none
+func ContextRequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
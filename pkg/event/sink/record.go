@@ -0,0 +1,66 @@
+package sink
+
+import (
+	"time"
+
+	"github.com/selebrow/selebrow/pkg/event/models"
+)
+
+// RecordVersion is incremented on incompatible changes of the Record schema
+const RecordVersion = 1
+
+// Record is a stable JSON representation of session events exported by sinks
+type Record struct {
+	Version           int               `json:"version"`
+	Type              string            `json:"type"`
+	Time              time.Time         `json:"time"`
+	Backend           string            `json:"backend,omitempty"`
+	Protocol          string            `json:"protocol"`
+	SessionID         string            `json:"sessionId,omitempty"`
+	BrowserName       string            `json:"browserName"`
+	BrowserVersion    string            `json:"browserVersion"`
+	Labels            map[string]string `json:"labels,omitempty"`
+	StartDurationMs   *int64            `json:"startDurationMs,omitempty"`
+	SessionDurationMs *int64            `json:"sessionDurationMs,omitempty"`
+	Error             string            `json:"error,omitempty"`
+}
+
+// EventTypes lists event types exported to sinks
+var EventTypes = []string{models.SessionRequestedEventType, models.SessionReleasedEventType}
+
+// NewRecord converts event to Record, returns false for the event types which are not exported
+func NewRecord(ev models.IEvent, backend string) (*Record, bool) {
+	r := &Record{
+		Version: RecordVersion,
+		Type:    ev.EventType(),
+		Time:    ev.EventTime().UTC(),
+		Backend: backend,
+	}
+
+	switch e := ev.(type) {
+	case *models.Event[models.SessionRequested]:
+		r.Protocol = string(e.Attributes.Protocol)
+		r.SessionID = e.Attributes.ID
+		r.BrowserName = e.Attributes.BrowserName
+		r.BrowserVersion = e.Attributes.BrowserVersion
+		r.Labels = e.Attributes.Labels
+		r.StartDurationMs = durationMs(e.Attributes.StartDuration)
+		r.Error = e.Attributes.Error
+	case *models.Event[models.SessionReleased]:
+		r.Protocol = string(e.Attributes.Protocol)
+		r.SessionID = e.Attributes.ID
+		r.BrowserName = e.Attributes.BrowserName
+		r.BrowserVersion = e.Attributes.BrowserVersion
+		r.Labels = e.Attributes.Labels
+		r.SessionDurationMs = durationMs(e.Attributes.SessionDuration)
+	default:
+		return nil, false
+	}
+
+	return r, true
+}
+
+func durationMs(d time.Duration) *int64 {
+	ms := d.Milliseconds()
+	return &ms
+}
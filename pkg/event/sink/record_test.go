@@ -0,0 +1,76 @@
+package sink
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/selebrow/selebrow/pkg/event/models"
+)
+
+func TestNewRecord_SessionRequested(t *testing.T) {
+	g := NewWithT(t)
+
+	ev := models.NewEvent(models.SessionRequestedEventType, time.UnixMilli(1000).In(time.FixedZone("x", 3600)), models.SessionRequested{
+		Protocol:       "webdriver",
+		BrowserName:    "chrome",
+		BrowserVersion: "120.0",
+		Labels:         map[string]string{"team": "qa"},
+		StartDuration:  1500 * time.Millisecond,
+		Error:          "test error",
+	})
+	r, ok := NewRecord(ev, "docker")
+	g.Expect(ok).To(BeTrue())
+
+	data, err := json.Marshal(r)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data).To(MatchJSON(`{
+		"version": 1,
+		"type": "SessionRequested",
+		"time": "1970-01-01T00:00:01Z",
+		"backend": "docker",
+		"protocol": "webdriver",
+		"browserName": "chrome",
+		"browserVersion": "120.0",
+		"labels": {"team": "qa"},
+		"startDurationMs": 1500,
+		"error": "test error"
+	}`))
+}
+
+func TestNewRecord_SessionReleased(t *testing.T) {
+	g := NewWithT(t)
+
+	ev := models.NewEvent(models.SessionReleasedEventType, time.UnixMilli(2000), models.SessionReleased{
+		Protocol:        "playwright",
+		ID:              "sess1",
+		BrowserName:     "firefox",
+		BrowserVersion:  "110.0",
+		SessionDuration: time.Minute,
+	})
+	r, ok := NewRecord(ev, "kubernetes")
+	g.Expect(ok).To(BeTrue())
+
+	data, err := json.Marshal(r)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(data).To(MatchJSON(`{
+		"version": 1,
+		"type": "SessionReleased",
+		"time": "1970-01-01T00:00:02Z",
+		"backend": "kubernetes",
+		"protocol": "playwright",
+		"sessionId": "sess1",
+		"browserName": "firefox",
+		"browserVersion": "110.0",
+		"sessionDurationMs": 60000
+	}`))
+}
+
+func TestNewRecord_Unsupported(t *testing.T) {
+	g := NewWithT(t)
+
+	_, ok := NewRecord(models.NewQuotaChangedEvent(models.QuotaChanged{}), "docker")
+	g.Expect(ok).To(BeFalse())
+}
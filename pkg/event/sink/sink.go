@@ -0,0 +1,75 @@
+package sink
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/selebrow/selebrow/pkg/event"
+	"github.com/selebrow/selebrow/pkg/event/models"
+)
+
+type Sink interface {
+	Write(ctx context.Context, r *Record) error
+	Close() error
+}
+
+// Adapter subscribes to session events and forwards them to the sink,
+// each sink has its own subscription so slow sinks don't delay the others
+type Adapter struct {
+	eb      event.EventBroker
+	sink    Sink
+	backend string
+	ch      <-chan models.IEvent
+	ctx     context.Context
+	cancel  context.CancelFunc
+	done    chan struct{}
+	l       *zap.SugaredLogger
+}
+
+func NewAdapter(eb event.EventBroker, sink Sink, backend string, l *zap.Logger) *Adapter {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Adapter{
+		eb:      eb,
+		sink:    sink,
+		backend: backend,
+		ctx:     ctx,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		l:       l.Sugar(),
+	}
+}
+
+func (a *Adapter) Start() {
+	a.ch = a.eb.Subscribe(EventTypes...)
+	go a.run()
+}
+
+func (a *Adapter) run() {
+	defer close(a.done)
+	for ev := range a.ch {
+		if a.ctx.Err() != nil {
+			return
+		}
+		r, ok := NewRecord(ev, a.backend)
+		if !ok {
+			continue
+		}
+		if err := a.sink.Write(a.ctx, r); err != nil {
+			a.l.With(zap.Error(err), zap.String("type", r.Type)).Error("failed to export event")
+		}
+	}
+}
+
+// Shutdown stops receiving new events and waits for already received ones to be exported
+func (a *Adapter) Shutdown(ctx context.Context) error {
+	a.eb.Unsubscribe(a.ch)
+	select {
+	case <-a.done:
+	case <-ctx.Done():
+		a.cancel()
+		<-a.done
+	}
+	a.cancel()
+	return a.sink.Close()
+}
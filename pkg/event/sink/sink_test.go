@@ -0,0 +1,54 @@
+package sink
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/selebrow/selebrow/pkg/event"
+	"github.com/selebrow/selebrow/pkg/event/models"
+)
+
+type recordingSink struct {
+	records chan *Record
+	closed  bool
+}
+
+func (s *recordingSink) Write(_ context.Context, r *Record) error {
+	s.records <- r
+	return nil
+}
+
+func (s *recordingSink) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestAdapter(t *testing.T) {
+	g := NewWithT(t)
+	eb := event.NewEventBrokerImpl(10, zaptest.NewLogger(t))
+	s := &recordingSink{records: make(chan *Record, 10)}
+
+	a := NewAdapter(eb, s, "docker", zaptest.NewLogger(t))
+	a.Start()
+
+	eb.Publish(models.NewSessionRequestedEvent(models.SessionRequested{Protocol: "webdriver", BrowserName: "chrome"}))
+	eb.Publish(models.NewQuotaChangedEvent(models.QuotaChanged{Allocated: 1}))
+	eb.Publish(models.NewSessionReleasedEvent(models.SessionReleased{Protocol: "webdriver", BrowserName: "chrome"}))
+
+	var r *Record
+	g.Eventually(s.records).Should(Receive(&r))
+	g.Expect(r.Type).To(Equal(models.SessionRequestedEventType))
+	g.Expect(r.Backend).To(Equal("docker"))
+	g.Eventually(s.records).Should(Receive(&r))
+	g.Expect(r.Type).To(Equal(models.SessionReleasedEventType))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	g.Expect(a.Shutdown(ctx)).To(Succeed())
+	g.Expect(s.closed).To(BeTrue())
+	g.Expect(s.records).ToNot(Receive())
+}
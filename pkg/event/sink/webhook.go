@@ -0,0 +1,96 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	hc "github.com/selebrow/selebrow/internal/common/client"
+)
+
+// WebhookSink POSTs each record as JSON to the webhook URL, transport errors, 429 and 5xx responses are retried
+type WebhookSink struct {
+	url     string
+	client  hc.HTTPClient
+	timeout time.Duration
+	backoff wait.Backoff
+}
+
+func NewWebhookSink(webhookURL string, client hc.HTTPClient, timeout time.Duration, backoff wait.Backoff) (*WebhookSink, error) {
+	u, err := url.Parse(webhookURL)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid webhook URL %s", webhookURL)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.Errorf("invalid webhook URL %s, absolute http(s) URL is expected", webhookURL)
+	}
+
+	return &WebhookSink{
+		url:     webhookURL,
+		client:  client,
+		timeout: timeout,
+		backoff: backoff,
+	}, nil
+}
+
+func (s *WebhookSink) Write(ctx context.Context, r *Record) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event record")
+	}
+
+	backoff := s.backoff
+	for {
+		retry, err := s.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retry || backoff.Steps <= 1 {
+			return errors.Wrapf(err, "failed to deliver event to %s", s.url)
+		}
+
+		select {
+		case <-time.After(backoff.Step()):
+		case <-ctx.Done():
+			return errors.Wrapf(err, "failed to deliver event to %s", s.url)
+		}
+	}
+}
+
+func (s *WebhookSink) post(ctx context.Context, body []byte) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode < http.StatusMultipleChoices:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+		return true, errors.Errorf("unexpected response status: %s", resp.Status)
+	default:
+		return false, errors.Errorf("unexpected response status: %s", resp.Status)
+	}
+}
+
+func (s *WebhookSink) Close() error {
+	return nil
+}
@@ -0,0 +1,104 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+var testBackoff = wait.Backoff{Duration: time.Millisecond, Factor: 2, Steps: 3}
+
+func TestWebhookSink_Write(t *testing.T) {
+	g := NewWithT(t)
+
+	var calls atomic.Int32
+	var got Record
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		g.Expect(r.Method).To(Equal(http.MethodPost))
+		g.Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+		g.Expect(json.NewDecoder(r.Body).Decode(&got)).To(Succeed())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	s, err := NewWebhookSink(srv.URL, srv.Client(), time.Second, testBackoff)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	r := &Record{Version: 1, Type: "SessionReleased", Time: time.UnixMilli(1000).UTC(), BrowserName: "chrome"}
+	g.Expect(s.Write(context.TODO(), r)).To(Succeed())
+	g.Expect(calls.Load()).To(BeEquivalentTo(3))
+	g.Expect(&got).To(Equal(r))
+}
+
+func TestWebhookSink_WriteRetriesExhausted(t *testing.T) {
+	g := NewWithT(t)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	s, err := NewWebhookSink(srv.URL, srv.Client(), time.Second, testBackoff)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = s.Write(context.TODO(), &Record{})
+	g.Expect(err).To(MatchError(ContainSubstring("429 Too Many Requests")))
+	g.Expect(calls.Load()).To(BeEquivalentTo(3))
+}
+
+func TestWebhookSink_WriteNotRetried(t *testing.T) {
+	g := NewWithT(t)
+
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s, err := NewWebhookSink(srv.URL, srv.Client(), time.Second, testBackoff)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	err = s.Write(context.TODO(), &Record{})
+	g.Expect(err).To(MatchError(ContainSubstring("400 Bad Request")))
+	g.Expect(calls.Load()).To(BeEquivalentTo(1))
+}
+
+func TestWebhookSink_WriteCancelled(t *testing.T) {
+	g := NewWithT(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	s, err := NewWebhookSink(srv.URL, srv.Client(), time.Second, wait.Backoff{Duration: time.Hour, Steps: 2})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = s.Write(ctx, &Record{})
+	g.Expect(err).To(MatchError(ContainSubstring("502 Bad Gateway")))
+}
+
+func TestNewWebhookSink_InvalidURL(t *testing.T) {
+	g := NewWithT(t)
+
+	for _, u := range []string{"ftp://host/path", "/relative", "http://%zz"} {
+		_, err := NewWebhookSink(u, http.DefaultClient, time.Second, testBackoff)
+		g.Expect(err).To(MatchError(ContainSubstring("invalid webhook URL")), u)
+	}
+}
@@ -0,0 +1,56 @@
+package sink
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// WriterSink writes records as JSON lines
+type WriterSink struct {
+	mtx sync.Mutex
+	w   io.Writer
+	enc *json.Encoder
+}
+
+func NewWriterSink(w io.Writer) *WriterSink {
+	return &WriterSink{
+		w:   w,
+		enc: json.NewEncoder(w),
+	}
+}
+
+// NewFileSink creates sink appending records to the file, file is created if it doesn't exist
+func NewFileSink(fileName string) (*WriterSink, error) {
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open events file")
+	}
+	return NewWriterSink(f), nil
+}
+
+func NewStdoutSink() *WriterSink {
+	return NewWriterSink(os.Stdout)
+}
+
+func (s *WriterSink) Write(_ context.Context, r *Record) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.enc.Encode(r)
+}
+
+func (s *WriterSink) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.w == os.Stdout {
+		return nil
+	}
+	if c, ok := s.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestWriterSink_Write(t *testing.T) {
+	g := NewWithT(t)
+	buf := new(bytes.Buffer)
+	s := NewWriterSink(buf)
+
+	g.Expect(s.Write(context.TODO(), &Record{Version: 1, Type: "t1", Time: time.UnixMilli(0).UTC()})).To(Succeed())
+	g.Expect(s.Write(context.TODO(), &Record{Version: 1, Type: "t2", Time: time.UnixMilli(0).UTC()})).To(Succeed())
+	g.Expect(s.Close()).To(Succeed())
+
+	g.Expect(buf.String()).To(Equal(
+		`{"version":1,"type":"t1","time":"1970-01-01T00:00:00Z","protocol":"","browserName":"","browserVersion":""}` + "\n" +
+			`{"version":1,"type":"t2","time":"1970-01-01T00:00:00Z","protocol":"","browserName":"","browserVersion":""}` + "\n",
+	))
+}
+
+func TestFileSink_Append(t *testing.T) {
+	g := NewWithT(t)
+	fn := filepath.Join(t.TempDir(), "events.jsonl")
+	g.Expect(os.WriteFile(fn, []byte("{}\n"), 0o644)).To(Succeed())
+
+	s, err := NewFileSink(fn)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(s.Write(context.TODO(), &Record{Version: 1, Type: "t1", Time: time.UnixMilli(0).UTC()})).To(Succeed())
+	g.Expect(s.Close()).To(Succeed())
+
+	data, err := os.ReadFile(fn)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(data)).To(Equal(
+		"{}\n" + `{"version":1,"type":"t1","time":"1970-01-01T00:00:00Z","protocol":"","browserName":"","browserVersion":""}` + "\n",
+	))
+}
+
+func TestFileSink_Error(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := NewFileSink(filepath.Join(t.TempDir(), "missing", "events.jsonl"))
+	g.Expect(err).To(MatchError(ContainSubstring("failed to open events file")))
+}
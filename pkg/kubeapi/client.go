@@ -23,6 +23,8 @@ type KubernetesClient interface {
 	DeletePod(ctx context.Context, name string) error
 	Watch(ctx context.Context, selector *metav1.LabelSelector) (<-chan *watch.Event, error)
 	PortForwardPod(podName string, podPort, localport int64, stopCh chan struct{}) error
+	ExecPod(ctx context.Context, podName, container string, cmd []string) (string, error)
+	Ping(ctx context.Context) error
 }
 
 type ProxyFunc func(*http.Request) (*url.URL, error)
@@ -39,6 +41,11 @@ func (c *Client) ClusterModeOut() bool {
 	return c.clusterModeOut
 }
 
+// Ping checks Kubernetes API server reachability
+func (c *Client) Ping(ctx context.Context) error {
+	return c.clientset.Discovery().RESTClient().Get().AbsPath("/version").Do(ctx).Error()
+}
+
 func NewClient(cfg config.KubeConfig, l *zap.Logger) (*Client, error) {
 	var (
 		client  *Client
@@ -1,6 +1,7 @@
 package kubeapi
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
@@ -13,9 +14,11 @@ import (
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/tools/remotecommand"
 	clientWatch "k8s.io/client-go/tools/watch"
 	"k8s.io/client-go/transport/spdy"
 )
@@ -184,6 +187,33 @@ func (c *Client) portForward(request *PortForwardAPodRequest) error {
 	return nil
 }
 
+// ExecPod executes command in the pod container and waits for its completion, returns combined output
+func (c *Client) ExecPod(ctx context.Context, podName, container string, cmd []string) (string, error) {
+	req := c.clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(c.namespace).
+		Name(podName).
+		SubResource("exec").
+		VersionedParams(&core.PodExecOptions{
+			Container: container,
+			Command:   cmd,
+			Stdout:    true,
+			Stderr:    true,
+		}, scheme.ParameterCodec)
+
+	exec, err := remotecommand.NewSPDYExecutor(c.restConfig, http.MethodPost, req.URL())
+	if err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	err = exec.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdout: &out,
+		Stderr: &out,
+	})
+	return out.String(), err
+}
+
 func portForwardAPod(req *PortForwardAPodRequest) error {
 	path := fmt.Sprintf(
 		"/api/v1/namespaces/%s/pods/%s/portforward",
@@ -5,8 +5,12 @@ type BrowserProtocol string
 const (
 	WebdriverProtocol  BrowserProtocol = "webdriver"
 	PlaywrightProtocol BrowserProtocol = "playwright"
+	PuppeteerProtocol  BrowserProtocol = "puppeteer"
 )
 
+// Protocols lists all supported browser protocols
+var Protocols = []BrowserProtocol{WebdriverProtocol, PlaywrightProtocol, PuppeteerProtocol}
+
 type ContainerPort string
 
 const (
@@ -17,6 +21,17 @@ const (
 	BrowserPort    ContainerPort = "browser"
 )
 
+type ResetType string
+
+const (
+	// ResetHTTP sends a request to the endpoint exposed by the browser container
+	ResetHTTP ResetType = "http"
+	// ResetCommand executes a command inside the browser container
+	ResetCommand ResetType = "command"
+	// ResetNever means browser must not be reused by subsequent sessions
+	ResetNever ResetType = "never"
+)
+
 type BrowserCatalog map[BrowserProtocol]Browsers
 type Browsers map[string]BrowserConfig
 
@@ -37,6 +52,17 @@ type BrowserImageConfig struct {
 	ShmSize        int64                 `yaml:"shmSize"`
 	Tmpfs          []string              `yaml:"tmpfs"`
 	Volumes        []string              `yaml:"volumes"`
+	Reset          *ResetConfig          `yaml:"reset"`
+	// DefaultCapabilities are merged into requested Webdriver capabilities (or Playwright launch options)
+	DefaultCapabilities map[string]interface{} `yaml:"defaultCapabilities"`
+}
+
+type ResetConfig struct {
+	Type   ResetType     `yaml:"type"`
+	Port   ContainerPort `yaml:"port"`
+	Method string        `yaml:"method"`
+	Path   string        `yaml:"path"`
+	Cmd    []string      `yaml:"cmd"`
 }
 
 func (c BrowserImageConfig) GetTag(version string) (string, bool) {
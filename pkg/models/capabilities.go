@@ -2,15 +2,19 @@ package models
 
 import "time"
 
+// DownloadsEnabledCapability enables Selenium managed downloads
+const DownloadsEnabledCapability = "se:downloadsEnabled"
+
 // Capabilities Meaningful capabilities structure
 type Capabilities struct {
-	Name            string           `jsonwire:"browserName,omitempty"      w3c:"browserName,omitempty"`
-	DeviceName      string           `jsonwire:"deviceName,omitempty"       w3c:"deviceName,omitempty"`
-	Version         string           `jsonwire:"version,omitempty"          w3c:"browserVersion,omitempty"`
-	Platform        string           `jsonwire:"platform,omitempty"         w3c:"platformName,omitempty"`
-	Proxy           *ProxyOptions    `jsonwire:"proxy,omitempty"            w3c:"proxy,omitempty"`
-	SelenoidOptions *SelenoidOptions `jsonwire:"selenoid:options,omitempty" w3c:"selenoid:options,omitempty"`
-	RawCapabilities []byte           `jsonwire:"-"                          w3c:"-"`
+	Name             string           `jsonwire:"browserName,omitempty"         w3c:"browserName,omitempty"`
+	DeviceName       string           `jsonwire:"deviceName,omitempty"          w3c:"deviceName,omitempty"`
+	Version          string           `jsonwire:"version,omitempty"             w3c:"browserVersion,omitempty"`
+	Platform         string           `jsonwire:"platform,omitempty"            w3c:"platformName,omitempty"`
+	Proxy            *ProxyOptions    `jsonwire:"proxy,omitempty"               w3c:"proxy,omitempty"`
+	SelenoidOptions  *SelenoidOptions `jsonwire:"selenoid:options,omitempty"    w3c:"selenoid:options,omitempty"`
+	DownloadsEnabled bool             `jsonwire:"se:downloadsEnabled,omitempty" w3c:"se:downloadsEnabled,omitempty"`
+	RawCapabilities  []byte           `jsonwire:"-"                             w3c:"-"`
 }
 
 func (caps *Capabilities) GetRawCapabilities() []byte {
@@ -67,6 +71,31 @@ func (caps *Capabilities) GetTimeout() time.Duration {
 	return caps.SelenoidOptions.SessionTimeout.Duration
 }
 
+func (caps *Capabilities) GetMaxLifetime() time.Duration {
+	if caps.SelenoidOptions == nil {
+		return 0
+	}
+	return caps.SelenoidOptions.MaxLifetime.Duration
+}
+
+func (caps *Capabilities) IsCommandLogEnabled() bool {
+	if caps.SelenoidOptions == nil {
+		return false
+	}
+	return caps.SelenoidOptions.CommandLog
+}
+
+func (caps *Capabilities) IsDownloadsEnabled() bool {
+	return caps.DownloadsEnabled
+}
+
+func (caps *Capabilities) IsFinalScreenshotEnabled() bool {
+	if caps.SelenoidOptions == nil {
+		return false
+	}
+	return caps.SelenoidOptions.FinalScreenshot
+}
+
 func (caps *Capabilities) GetFlavor() string {
 	if caps.SelenoidOptions == nil {
 		return ""
@@ -101,3 +130,41 @@ func (caps *Capabilities) GetLabels() map[string]string {
 	}
 	return caps.SelenoidOptions.Labels
 }
+
+// MergeDefaults deep-merges default capabilities into dst, values present in dst take precedence.
+// Maps are merged key by key recursively while any other value including a list replaces the default one
+// as a whole, so a client can drop default list items by supplying its own list
+func MergeDefaults(dst, defaults map[string]interface{}) {
+	for k, def := range defaults {
+		cur, ok := dst[k]
+		if !ok {
+			dst[k] = copyValue(def)
+			continue
+		}
+		if c, ok := cur.(map[string]interface{}); ok {
+			if d, ok := def.(map[string]interface{}); ok {
+				MergeDefaults(c, d)
+			}
+		}
+	}
+}
+
+// copyValue copies nested maps and lists so shared defaults are never modified through merged capabilities
+func copyValue(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			res[k] = copyValue(item)
+		}
+		return res
+	case []interface{}:
+		res := make([]interface{}, len(val))
+		for i, item := range val {
+			res[i] = copyValue(item)
+		}
+		return res
+	default:
+		return v
+	}
+}
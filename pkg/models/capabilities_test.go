@@ -0,0 +1,49 @@
+package models
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestMergeDefaults(t *testing.T) {
+	g := NewWithT(t)
+
+	defaults := map[string]interface{}{
+		"acceptInsecureCerts": true,
+		"pageLoadStrategy":    "eager",
+		"goog:chromeOptions": map[string]interface{}{
+			"args":  []interface{}{"--no-sandbox", "--headless"},
+			"prefs": map[string]interface{}{"k1": "v1", "k2": "v2"},
+		},
+		"moz:firefoxOptions": map[string]interface{}{
+			"args": []interface{}{"-headless"},
+		},
+	}
+	dst := map[string]interface{}{
+		"acceptInsecureCerts": false,
+		"goog:chromeOptions": map[string]interface{}{
+			"args":  []interface{}{"--headless", "--incognito"},
+			"prefs": map[string]interface{}{"k1": "client"},
+		},
+	}
+
+	MergeDefaults(dst, defaults)
+	g.Expect(dst).To(Equal(map[string]interface{}{
+		"acceptInsecureCerts": false,
+		"pageLoadStrategy":    "eager",
+		"goog:chromeOptions": map[string]interface{}{
+			"args":  []interface{}{"--headless", "--incognito"},
+			"prefs": map[string]interface{}{"k1": "client", "k2": "v2"},
+		},
+		"moz:firefoxOptions": map[string]interface{}{
+			"args": []interface{}{"-headless"},
+		},
+	}))
+
+	// defaults must not be shared with merged capabilities
+	dst["moz:firefoxOptions"].(map[string]interface{})["args"] = []interface{}{"changed"}
+	g.Expect(defaults["moz:firefoxOptions"]).To(Equal(map[string]interface{}{
+		"args": []interface{}{"-headless"},
+	}))
+}
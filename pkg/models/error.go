@@ -11,6 +11,10 @@ import (
 const (
 	SessionNotCreatedErr    = "session not created"
 	BadSessionParametersErr = "bad session parameters"
+	InvalidSessionIDErr     = "invalid session id"
+	TimeoutErr              = "timeout"
+	InvalidArgumentErr      = "invalid argument"
+	UnknownErr              = "unknown error"
 
 	// StatusRequestCancelled unofficial status code, actually it won't be sent over the wire, we just need a marker
 	StatusRequestCancelled = 499
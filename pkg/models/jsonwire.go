@@ -8,6 +8,11 @@ type JsonWireCapabilities struct {
 	DesiredCapabilities map[string]interface{} `json:"desiredCapabilities,omitempty"`
 }
 
+// MergeDefaults adds default capabilities missing in the request
+func (caps *JsonWireCapabilities) MergeDefaults(defaults map[string]interface{}) {
+	MergeDefaults(caps.DesiredCapabilities, defaults)
+}
+
 func (caps *JsonWireCapabilities) UpdateProxy(proxy *ProxyOptions) {
 	p := *proxy
 	if proxy.NoProxy != nil {
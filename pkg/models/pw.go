@@ -16,6 +16,8 @@ type PWCapabilities struct {
 	Hosts            []string
 	Networks         []string
 	Labels           map[string]string
+	Timeout          time.Duration
+	CDP              bool
 }
 
 func (caps *PWCapabilities) GetName() string {
@@ -47,9 +49,17 @@ func (caps *PWCapabilities) GetEnvs() []string {
 }
 
 func (caps *PWCapabilities) GetTimeout() time.Duration {
+	return caps.Timeout
+}
+
+func (caps *PWCapabilities) GetMaxLifetime() time.Duration {
 	return 0
 }
 
+func (caps *PWCapabilities) IsCDPEnabled() bool {
+	return caps.CDP
+}
+
 func (caps *PWCapabilities) GetRawCapabilities() []byte {
 	return nil
 }
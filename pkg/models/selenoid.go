@@ -4,6 +4,7 @@ package models
 type SelenoidOptions struct {
 	TestName         string            `json:"name,omitempty"                  jsonwire:"name,omitempty"                  w3c:"name,omitempty"`
 	SessionTimeout   Duration          `json:"sessionTimeout,omitempty"        jsonwire:"sessionTimeout,omitempty"        w3c:"sessionTimeout,omitempty"`
+	MaxLifetime      Duration          `json:"maxSessionLifetime,omitempty"    jsonwire:"maxSessionLifetime,omitempty"    w3c:"maxSessionLifetime,omitempty"`
 	ScreenResolution string            `json:"screenResolution,omitempty"      jsonwire:"screenResolution,omitempty"      w3c:"screenResolution,omitempty"`
 	EnableVNC        bool              `json:"enableVNC,omitempty"             jsonwire:"enableVNC,omitempty"             w3c:"enableVNC,omitempty"`
 	Env              []string          `json:"env,omitempty"                   jsonwire:"env,omitempty"                   w3c:"env,omitempty"`
@@ -12,4 +13,6 @@ type SelenoidOptions struct {
 	Hosts            []string          `json:"hostsEntries,omitempty"          jsonwire:"hostsEntries,omitempty"          w3c:"hostsEntries,omitempty"`
 	Networks         []string          `json:"additionalNetworks,omitempty"    jsonwire:"additionalNetworks,omitempty"    w3c:"additionalNetworks,omitempty"`
 	Labels           map[string]string `json:"labels,omitempty"                jsonwire:"labels,omitempty"                w3c:"labels,omitempty"`
+	CommandLog       bool              `json:"commandLog,omitempty"            jsonwire:"commandLog,omitempty"            w3c:"commandLog,omitempty"`
+	FinalScreenshot  bool              `json:"finalScreenshot,omitempty"       jsonwire:"finalScreenshot,omitempty"       w3c:"finalScreenshot,omitempty"`
 }
@@ -7,15 +7,46 @@ type W3CCapabilities struct {
 	FirstMatch  []map[string]interface{} `json:"firstMatch,omitempty"`
 }
 
-func (caps *W3CCapabilities) Merge() map[string]interface{} {
-	merged := make(map[string]interface{})
-	if caps.AlwaysMatch != nil {
-		merged = deepMergeMaps(merged, caps.AlwaysMatch)
+// Alternatives returns alwaysMatch capabilities merged with each of firstMatch entries in order,
+// see https://www.w3.org/TR/webdriver2/#dfn-processing-capabilities
+func (caps *W3CCapabilities) Alternatives() []map[string]interface{} {
+	if len(caps.FirstMatch) == 0 {
+		return []map[string]interface{}{caps.merge(nil)}
 	}
+	res := make([]map[string]interface{}, 0, len(caps.FirstMatch))
 	for _, c := range caps.FirstMatch {
-		merged = deepMergeMaps(merged, c)
+		res = append(res, caps.merge(c))
+	}
+	return res
+}
+
+// SelectFirstMatch drops all firstMatch entries except the chosen one
+func (caps *W3CCapabilities) SelectFirstMatch(i int) {
+	if i >= 0 && i < len(caps.FirstMatch) {
+		caps.FirstMatch = caps.FirstMatch[i : i+1]
+	}
+}
+
+func (caps *W3CCapabilities) merge(firstMatch map[string]interface{}) map[string]interface{} {
+	merged := deepMergeMaps(make(map[string]interface{}), caps.AlwaysMatch)
+	return deepMergeMaps(merged, firstMatch)
+}
+
+// MergeDefaults adds default capabilities missing in the request, capabilities present in the chosen
+// firstMatch entry are merged there since alwaysMatch and firstMatch keys must not overlap
+func (caps *W3CCapabilities) MergeDefaults(defaults map[string]interface{}) {
+	if caps.AlwaysMatch == nil {
+		caps.AlwaysMatch = make(map[string]interface{})
+	}
+	for k, v := range defaults {
+		dst := caps.AlwaysMatch
+		if len(caps.FirstMatch) == 1 {
+			if _, ok := caps.FirstMatch[0][k]; ok {
+				dst = caps.FirstMatch[0]
+			}
+		}
+		MergeDefaults(dst, map[string]interface{}{k: v})
 	}
-	return merged
 }
 
 func (caps *W3CCapabilities) UpdateProxy(proxy *ProxyOptions) {
@@ -36,6 +67,10 @@ func deepMergeMaps(dst, src map[string]interface{}) map[string]interface{} {
 		}
 		// add the value from src if it doesn't exist in dst
 		if _, ok := dst[k]; !ok {
+			if vSrc, ok := v.(map[string]interface{}); ok {
+				// copy nested map so merging alternatives doesn't modify the source
+				v = deepMergeMaps(make(map[string]interface{}), vSrc)
+			}
 			dst[k] = v
 		}
 	}
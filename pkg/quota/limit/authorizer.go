@@ -5,10 +5,14 @@ import (
 	"context"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.uber.org/zap"
 
+	"github.com/selebrow/selebrow/pkg/browser"
+	"github.com/selebrow/selebrow/pkg/event"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 	"github.com/selebrow/selebrow/pkg/models"
 )
 
@@ -20,16 +24,18 @@ type LimitQuotaAuthorizer struct {
 	m         sync.RWMutex
 	queue     *list.List
 	qLimit    int
+	eb        event.EventBroker
 	l         *zap.SugaredLogger
 }
 
-func NewLimitQuotaAuthorizer(limit, qLimit int, l *zap.Logger) *LimitQuotaAuthorizer {
+func NewLimitQuotaAuthorizer(limit, qLimit int, eb event.EventBroker, l *zap.Logger) *LimitQuotaAuthorizer {
 	logger := l.Sugar()
 	logger.Infow("initializing quota", zap.Int("limit", limit), zap.Int("queue_limit", qLimit))
 	return &LimitQuotaAuthorizer{
 		limit:  limit,
 		queue:  list.New(),
 		qLimit: qLimit,
+		eb:     eb,
 		l:      logger,
 	}
 }
@@ -42,6 +48,7 @@ func (q *LimitQuotaAuthorizer) ExternalReserve(qty int) int {
 	q.m.Lock()
 	defer q.m.Unlock()
 	q.allocated += qty
+	q.publishChanged()
 	return q.allocated
 }
 
@@ -54,6 +61,7 @@ func (q *LimitQuotaAuthorizer) Reserve(ctx context.Context) error {
 		defer q.m.Unlock()
 		q.allocated++
 		q.l.Debugf("quota reserved: allocated=%d", q.allocated)
+		q.publishChanged()
 		return nil
 	}
 
@@ -64,8 +72,19 @@ func (q *LimitQuotaAuthorizer) Reserve(ctx context.Context) error {
 
 	ch := make(elementValue)
 	e := q.queue.PushBack(ch)
+	qSize = q.queue.Len()
+	q.publishChanged()
 	q.m.Unlock()
 
+	trace := browser.ContextAllocationTrace(ctx)
+	trace.OnQueued(qSize)
+	start := time.Now()
+	err := q.wait(ctx, ch, e)
+	trace.OnDequeued(time.Since(start), err)
+	return err
+}
+
+func (q *LimitQuotaAuthorizer) wait(ctx context.Context, ch elementValue, e *list.Element) error {
 	select {
 	case <-ctx.Done():
 		q.m.Lock()
@@ -76,6 +95,7 @@ func (q *LimitQuotaAuthorizer) Reserve(ctx context.Context) error {
 			return nil
 		default:
 			q.queue.Remove(e)
+			q.publishChanged()
 			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
 				return models.NewQuoteExceededError(errors.Wrap(ctx.Err(), q.formatError("quota wait failed")))
 			} else {
@@ -90,6 +110,7 @@ func (q *LimitQuotaAuthorizer) Reserve(ctx context.Context) error {
 func (q *LimitQuotaAuthorizer) Release() int {
 	q.m.Lock()
 	defer q.m.Unlock()
+	defer q.publishChanged()
 
 	if e := q.queue.Front(); e != nil {
 		ch, _ := q.queue.Remove(e).(elementValue)
@@ -128,6 +149,19 @@ func (q *LimitQuotaAuthorizer) QueueSize() int {
 	return q.queue.Len()
 }
 
+// publishChanged publishes current quota usage, it's called with lock held to keep events in the order of changes
+func (q *LimitQuotaAuthorizer) publishChanged() {
+	if q.eb == nil {
+		return
+	}
+	q.eb.Publish(evmodels.NewQuotaChangedEvent(evmodels.QuotaChanged{
+		Allocated:  q.allocated,
+		Limit:      q.limit,
+		QueueSize:  q.queue.Len(),
+		QueueLimit: q.qLimit,
+	}))
+}
+
 func (q *LimitQuotaAuthorizer) formatError(msg string) string {
 	return fmt.Sprintf("%s: allocated=%d, limit=%d, queue size=%d", msg, q.allocated, q.limit, q.queue.Len())
 }
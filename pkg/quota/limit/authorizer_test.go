@@ -7,12 +7,17 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
 	"go.uber.org/zap/zaptest"
+
+	"github.com/selebrow/selebrow/mocks"
+	"github.com/selebrow/selebrow/pkg/browser"
+	evmodels "github.com/selebrow/selebrow/pkg/event/models"
 )
 
 func TestLimitQuotaAuthorizer(t *testing.T) {
 	g := NewWithT(t)
-	q := NewLimitQuotaAuthorizer(2, 0, zaptest.NewLogger(t))
+	q := NewLimitQuotaAuthorizer(2, 0, nil, zaptest.NewLogger(t))
 
 	g.Expect(q.Enabled()).To(BeTrue())
 	g.Expect(q.Limit()).To(Equal(2))
@@ -52,7 +57,7 @@ func TestLimitQuotaAuthorizer(t *testing.T) {
 
 func TestLimitQuotaAuthorizer_Queue(t *testing.T) {
 	g := NewWithT(t)
-	q := NewLimitQuotaAuthorizer(1, 2, zaptest.NewLogger(t))
+	q := NewLimitQuotaAuthorizer(1, 2, nil, zaptest.NewLogger(t))
 
 	g.Expect(q.Enabled()).To(BeTrue())
 	g.Expect(q.Limit()).To(Equal(1))
@@ -107,3 +112,66 @@ func TestLimitQuotaAuthorizer_Queue(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestLimitQuotaAuthorizer_QueueTrace(t *testing.T) {
+	g := NewWithT(t)
+	q := NewLimitQuotaAuthorizer(1, 1, nil, zaptest.NewLogger(t))
+
+	queued := make(chan int, 1)
+	dequeued := make(chan error, 1)
+	ctx := browser.WithAllocationTrace(context.TODO(), &browser.AllocationTrace{
+		Queued: func(queueSize int) {
+			queued <- queueSize
+		},
+		Dequeued: func(_ time.Duration, err error) {
+			dequeued <- err
+		},
+	})
+
+	// fast path is not traced
+	g.Expect(q.Reserve(ctx)).To(Succeed())
+	g.Expect(queued).ToNot(Receive())
+
+	ch := make(chan error, 1)
+	go func() {
+		ch <- q.Reserve(ctx)
+	}()
+	g.Eventually(queued).Should(Receive(Equal(1)))
+	g.Consistently(dequeued, 20*time.Millisecond).ShouldNot(Receive())
+
+	q.Release()
+	g.Eventually(ch).Should(Receive(BeNil()))
+	g.Expect(dequeued).To(Receive(BeNil()))
+}
+
+func TestLimitQuotaAuthorizer_Events(t *testing.T) {
+	g := NewWithT(t)
+	eb := mocks.NewEventBroker(t)
+	q := NewLimitQuotaAuthorizer(1, 2, eb, zaptest.NewLogger(t))
+
+	events := make(chan evmodels.QuotaChanged, 10)
+	eb.EXPECT().Publish(mock.Anything).Run(func(ev evmodels.IEvent) {
+		events <- ev.(*evmodels.Event[evmodels.QuotaChanged]).Attributes
+	})
+
+	g.Expect(q.Reserve(context.TODO())).To(Succeed())
+	g.Expect(events).To(Receive(Equal(evmodels.QuotaChanged{Allocated: 1, Limit: 1, QueueLimit: 2})))
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	ch := make(chan error, 1)
+	go func() {
+		ch <- q.Reserve(ctx)
+	}()
+	g.Eventually(events).Should(Receive(Equal(evmodels.QuotaChanged{Allocated: 1, Limit: 1, QueueSize: 1, QueueLimit: 2})))
+
+	cancel()
+	g.Eventually(ch).Should(Receive(MatchError(context.Canceled)))
+	g.Expect(events).To(Receive(Equal(evmodels.QuotaChanged{Allocated: 1, Limit: 1, QueueLimit: 2})))
+
+	q.Release()
+	g.Expect(events).To(Receive(Equal(evmodels.QuotaChanged{Limit: 1, QueueLimit: 2})))
+
+	q.ExternalReserve(2)
+	g.Expect(events).To(Receive(Equal(evmodels.QuotaChanged{Allocated: 2, Limit: 1, QueueLimit: 2})))
+	g.Expect(events).ToNot(Receive())
+}
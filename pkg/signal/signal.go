@@ -14,16 +14,21 @@ import (
 type ShutdownHook func(ctx context.Context) error
 
 type Handler struct {
-	hooks   map[any][]ShutdownHook
-	timeout time.Duration
-	l       *zap.SugaredLogger
+	hooks        map[any][]ShutdownHook
+	drainHooks   []ShutdownHook
+	timeout      time.Duration
+	drainTimeout time.Duration
+	l            *zap.SugaredLogger
 }
 
-func NewHandler(timeout time.Duration, l *zap.Logger) *Handler {
+// NewHandler drainTimeout limits the time drain hooks are given before shutdown hooks are run,
+// draining is disabled if it's set to zero
+func NewHandler(timeout, drainTimeout time.Duration, l *zap.Logger) *Handler {
 	return &Handler{
-		hooks:   make(map[any][]ShutdownHook),
-		timeout: timeout,
-		l:       l.Sugar(),
+		hooks:        make(map[any][]ShutdownHook),
+		timeout:      timeout,
+		drainTimeout: drainTimeout,
+		l:            l.Sugar(),
 	}
 }
 
@@ -34,7 +39,12 @@ func (h *Handler) Start() int {
 
 	sig := <-c
 
-	h.l.Infow("signal caught, shutting down...", zap.String("signal", sig.String()))
+	if h.drainTimeout > 0 && len(h.drainHooks) > 0 {
+		h.l.Infow("signal caught, draining...", zap.String("signal", sig.String()))
+		h.drain(c)
+	} else {
+		h.l.Infow("signal caught, shutting down...", zap.String("signal", sig.String()))
+	}
 
 	start := time.Now()
 
@@ -61,6 +71,32 @@ func (h *Handler) RegisterShutdownHook(group any, hook ShutdownHook) {
 	h.hooks[group] = append(h.hooks[group], hook)
 }
 
+// RegisterDrainHook registers hook which is run on the first signal before any shutdown hook,
+// hook is expected to block until all the work in progress is completed or context is cancelled
+func (h *Handler) RegisterDrainHook(hook ShutdownHook) {
+	h.drainHooks = append(h.drainHooks, hook)
+}
+
+func (h *Handler) drain(c <-chan os.Signal) {
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeout(context.Background(), h.drainTimeout)
+	defer cancel()
+
+	done := runHooks(ctx, h.drainHooks, func(err error) {
+		h.l.Warnw("drain hook failed", zap.Error(err))
+	})
+
+	select {
+	case <-ctx.Done():
+		h.l.Warnf("drain did not complete within %v, shutting down", h.drainTimeout)
+	case <-done:
+		h.l.Infof("drain completed in %v, shutting down", time.Since(start))
+	case sig := <-c:
+		h.l.Infow("second signal caught, shutting down without waiting for drain", zap.String("signal", sig.String()))
+	}
+}
+
 func (h *Handler) performShutdown(ctx context.Context) <-chan struct{} {
 	var wg sync.WaitGroup
 	done := make(chan struct{})
@@ -83,3 +119,24 @@ func (h *Handler) performShutdown(ctx context.Context) <-chan struct{} {
 
 	return done
 }
+
+func runHooks(ctx context.Context, hooks []ShutdownHook, onErr func(error)) <-chan struct{} {
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for _, hook := range hooks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := hook(ctx); err != nil {
+				onErr(err)
+			}
+		}()
+	}
+
+	go func() {
+		defer close(done)
+		wg.Wait()
+	}()
+
+	return done
+}
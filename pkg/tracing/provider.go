@@ -0,0 +1,104 @@
+package tracing
+
+import (
+	"context"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/selebrow/selebrow/pkg/config"
+)
+
+// Provider wraps SDK tracer provider along with exporter output (if any) to be closed on shutdown
+type Provider struct {
+	tp  *sdktrace.TracerProvider
+	out io.Closer
+}
+
+// NewProvider creates tracer provider for the configured exporter, returns nil if tracing is disabled
+func NewProvider(ctx context.Context, cfg config.TracingConfig, serviceName, serviceVersion string) (*Provider, error) {
+	var (
+		exp sdktrace.SpanExporter
+		out io.Closer
+		err error
+	)
+
+	switch cfg.TracingExporter() {
+	case config.TracingExporterNone:
+		return nil, nil
+	case config.TracingExporterOTLPHTTP:
+		var opts []otlptracehttp.Option
+		if ep := cfg.TracingEndpoint(); ep != "" {
+			opts = append(opts, otlptracehttp.WithEndpointURL(ep))
+		}
+		exp, err = otlptracehttp.New(ctx, opts...)
+	case config.TracingExporterOTLPGRPC:
+		var opts []otlptracegrpc.Option
+		if ep := cfg.TracingEndpoint(); ep != "" {
+			opts = append(opts, otlptracegrpc.WithEndpointURL(ep))
+		}
+		exp, err = otlptracegrpc.New(ctx, opts...)
+	case config.TracingExporterStdout:
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case config.TracingExporterFile:
+		var f *os.File
+		f, err = os.OpenFile(cfg.TracingFile(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open traces file")
+		}
+		out = f
+		exp, err = stdouttrace.New(stdouttrace.WithWriter(f))
+	default:
+		return nil, errors.Errorf("unsupported tracing exporter %s", cfg.TracingExporter())
+	}
+	if err != nil {
+		if out != nil {
+			_ = out.Close()
+		}
+		return nil, errors.Wrapf(err, "failed to create %s traces exporter", cfg.TracingExporter())
+	}
+
+	res := resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", serviceVersion),
+	)
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.TracingSampleRatio()))),
+	)
+	return &Provider{tp: tp, out: out}, nil
+}
+
+// SetGlobalPropagator configures W3C trace context propagation, it's done regardless of tracing
+// being enabled, so incoming trace context is still passed through to browsers
+func SetGlobalPropagator() {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+}
+
+func (p *Provider) SetGlobal() {
+	otel.SetTracerProvider(p.tp)
+}
+
+func (p *Provider) Shutdown(ctx context.Context) error {
+	err := p.tp.Shutdown(ctx)
+	if p.out != nil {
+		if cErr := p.out.Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,81 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/selebrow/selebrow/pkg/capabilities"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+const TracerName = "github.com/selebrow/selebrow"
+
+const (
+	ProtocolKey       = attribute.Key("selebrow.protocol")
+	SessionIDKey      = attribute.Key("selebrow.session.id")
+	BrowserNameKey    = attribute.Key("selebrow.browser.name")
+	BrowserVersionKey = attribute.Key("selebrow.browser.version")
+	BrowserFlavorKey  = attribute.Key("selebrow.browser.flavor")
+	PlatformKey       = attribute.Key("selebrow.browser.platform")
+	TestNameKey       = attribute.Key("selebrow.test.name")
+	PoolHitKey        = attribute.Key("selebrow.pool.hit")
+	ImageKey          = attribute.Key("selebrow.image")
+	BackendIDKey      = attribute.Key("selebrow.backend.id")
+)
+
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Start starts internal span with given attributes
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// StartServer starts span for the incoming request
+func StartServer(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...), trace.WithSpanKind(trace.SpanKindServer))
+}
+
+// End records error if any and ends the span
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Extract returns context carrying remote span context propagated via request headers
+func Extract(ctx context.Context, h http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(h))
+}
+
+// Inject propagates span context from ctx to request headers
+func Inject(ctx context.Context, h http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(h))
+}
+
+func BrowserAttributes(protocol models.BrowserProtocol, caps capabilities.Capabilities) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		ProtocolKey.String(string(protocol)),
+		BrowserNameKey.String(caps.GetName()),
+		BrowserVersionKey.String(caps.GetVersion()),
+	}
+	if flavor := caps.GetFlavor(); flavor != "" {
+		attrs = append(attrs, BrowserFlavorKey.String(flavor))
+	}
+	if platform := caps.GetPlatform(); platform != "" {
+		attrs = append(attrs, PlatformKey.String(platform))
+	}
+	if testName := caps.GetTestName(); testName != "" {
+		attrs = append(attrs, TestNameKey.String(testName))
+	}
+	return attrs
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/selebrow/selebrow/pkg/config"
+	"github.com/selebrow/selebrow/pkg/models"
+)
+
+type testTracingConfig struct {
+	exporter config.TracingExporter
+	file     string
+}
+
+func (c testTracingConfig) TracingExporter() config.TracingExporter { return c.exporter }
+func (c testTracingConfig) TracingEndpoint() string                 { return "" }
+func (c testTracingConfig) TracingFile() string                     { return c.file }
+func (c testTracingConfig) TracingSampleRatio() float64             { return 1 }
+
+func TestBrowserAttributes(t *testing.T) {
+	g := NewWithT(t)
+
+	attrs := BrowserAttributes(models.PlaywrightProtocol, &models.PWCapabilities{
+		Browser: "chrome",
+		Version: "120.0",
+		Flavor:  "headless",
+	})
+	g.Expect(attrs).To(ConsistOf(
+		ProtocolKey.String("playwright"),
+		BrowserNameKey.String("chrome"),
+		BrowserVersionKey.String("120.0"),
+		BrowserFlavorKey.String("headless"),
+	))
+
+	attrs = BrowserAttributes(models.WebdriverProtocol, &models.Capabilities{
+		Name:            "firefox",
+		Version:         "110.0",
+		Platform:        "LINUX",
+		SelenoidOptions: &models.SelenoidOptions{TestName: "test1"},
+	})
+	g.Expect(attrs).To(ConsistOf(
+		ProtocolKey.String("webdriver"),
+		BrowserNameKey.String("firefox"),
+		BrowserVersionKey.String("110.0"),
+		PlatformKey.String("LINUX"),
+		TestNameKey.String("test1"),
+	))
+}
+
+func TestEnd(t *testing.T) {
+	g := NewWithT(t)
+	sr := tracetest.NewSpanRecorder()
+	tr := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)).Tracer(TracerName)
+
+	_, span := tr.Start(context.Background(), "ok")
+	End(span, nil)
+	_, span = tr.Start(context.Background(), "failed")
+	End(span, errors.New("test error"))
+
+	spans := sr.Ended()
+	g.Expect(spans).To(HaveLen(2))
+	g.Expect(spans[0].Status().Code).To(Equal(codes.Unset))
+	g.Expect(spans[0].Events()).To(BeEmpty())
+	g.Expect(spans[1].Status().Code).To(Equal(codes.Error))
+	g.Expect(spans[1].Status().Description).To(Equal("test error"))
+	g.Expect(spans[1].Events()).To(HaveLen(1))
+}
+
+func TestNewProvider_None(t *testing.T) {
+	g := NewWithT(t)
+	cfg := testTracingConfig{exporter: config.TracingExporterNone}
+
+	p, err := NewProvider(context.Background(), cfg, "selebrow", "v1")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(p).To(BeNil())
+}
+
+func TestNewProvider_File(t *testing.T) {
+	g := NewWithT(t)
+	fn := filepath.Join(t.TempDir(), "traces.json")
+	cfg := testTracingConfig{exporter: config.TracingExporterFile, file: fn}
+
+	p, err := NewProvider(context.Background(), cfg, "selebrow", "v1")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	_, span := p.tp.Tracer(TracerName).Start(context.Background(), "test.span")
+	span.End()
+	g.Expect(p.Shutdown(context.Background())).To(Succeed())
+
+	data, err := os.ReadFile(fn)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(data)).To(ContainSubstring(`"Name":"test.span"`))
+	g.Expect(string(data)).To(ContainSubstring(`"Value":"selebrow"`))
+}
+
+func TestNewProvider_FileError(t *testing.T) {
+	g := NewWithT(t)
+	cfg := testTracingConfig{exporter: config.TracingExporterFile, file: filepath.Join(t.TempDir(), "missing", "traces.json")}
+
+	_, err := NewProvider(context.Background(), cfg, "selebrow", "v1")
+	g.Expect(err).To(MatchError(ContainSubstring("failed to open traces file")))
+}